@@ -0,0 +1,238 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+// This file implements the client side of the go/packages driver protocol,
+// which allows an external program to supply package information in place of
+// "go list".  See golang.org/x/tools/go/packages for a description.
+//
+// The driver is named by the GOPACKAGESDRIVER environment variable.  If that
+// is unset, a program named "gopackagesdriver" is used if one is found in the
+// PATH; if it is set to "off", no driver is used.  The driver is invoked with
+// the query patterns as its arguments, and a JSON-encoded request on stdin.
+// It must reply on stdout with a JSON-encoded list of packages.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"bitbucket.org/creachadair/stringset"
+)
+
+const (
+	driverEnv     = "GOPACKAGESDRIVER"
+	defaultDriver = "gopackagesdriver"
+)
+
+// Bits of the packages.LoadMode requested from a driver.
+const (
+	needName = 1 << iota
+	needFiles
+	needCompiledGoFiles
+	needImports
+	needDeps
+	needExportsFile
+
+	driverMode = needName | needFiles | needCompiledGoFiles | needImports | needDeps | needExportsFile
+)
+
+// Fields must match go/packages; see golang.org/x/tools/go/packages.
+type driverRequest struct {
+	Mode       int               `json:"mode"`
+	Env        []string          `json:"env"`
+	BuildFlags []string          `json:"build_flags"`
+	Tests      bool              `json:"tests"`
+	Overlay    map[string][]byte `json:"overlay"`
+}
+
+type driverResponse struct {
+	// The IDs of the packages matching the query.  If empty, all the packages
+	// in the response are treated as matching.
+	Roots []string `json:",omitempty"`
+
+	// All the packages matching the query and their dependencies.
+	Packages []*driverPackage
+}
+
+type driverPackage struct {
+	ID              string
+	Name            string            `json:",omitempty"`
+	PkgPath         string            `json:",omitempty"`
+	Errors          []driverError     `json:",omitempty"`
+	GoFiles         []string          `json:",omitempty"`
+	CompiledGoFiles []string          `json:",omitempty"`
+	OtherFiles      []string          `json:",omitempty"`
+	ExportFile      string            `json:",omitempty"`
+	Imports         map[string]string `json:",omitempty"` // :: import path → package ID
+}
+
+type driverError struct {
+	Pos  string
+	Msg  string
+	Kind int
+}
+
+// findDriver returns the path of the packages driver selected by env, or ""
+// if packages should be listed by the go tool.
+func findDriver(env []string) string {
+	var driver string
+	for _, kv := range env {
+		if v := strings.TrimPrefix(kv, driverEnv+"="); v != kv {
+			driver = v // the last setting wins, as for exec.Cmd
+		}
+	}
+	if driver == "off" {
+		return ""
+	} else if driver == "" {
+		if path, err := exec.LookPath(defaultDriver); err == nil {
+			return path
+		}
+	}
+	return driver
+}
+
+// driverPackages invokes the packages driver for the given query in dir, and
+// converts its response to the same form as the output of "go list".
+func (e *Extractor) driverPackages(driver, dir string, env []string, query []string) ([]*jsonPackage, error) {
+	req := driverRequest{
+		Mode: driverMode,
+		Env:  env,
+	}
+	if tags := e.BuildContext.BuildTags; len(tags) != 0 {
+		req.BuildFlags = []string{"-tags=" + strings.Join(tags, ",")}
+	}
+	bits, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(driver, query...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(bits)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running packages driver %q: %v: %s", driver, err, stderr.Bytes())
+	}
+
+	var rsp driverResponse
+	if err := json.Unmarshal(stdout.Bytes(), &rsp); err != nil {
+		return nil, fmt.Errorf("decoding packages driver response: %v", err)
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.jsonPackages(root, e.BuildContext.GOROOT), nil
+}
+
+// jsonPackages converts the packages in rsp to the form reported by "go list".
+// The file paths of each package are made relative to its directory, which is
+// taken to be the directory of its first Go source file.  The root directory
+// is used to name files; packages whose files are under goroot are treated as
+// part of the standard library.
+func (rsp *driverResponse) jsonPackages(root, goroot string) []*jsonPackage {
+	byID := make(map[string]*driverPackage)
+	for _, dp := range rsp.Packages {
+		byID[dp.ID] = dp
+	}
+	roots := stringset.New(rsp.Roots...)
+
+	var pkgs []*jsonPackage
+	for _, dp := range rsp.Packages {
+		jp := &jsonPackage{
+			ImportPath: dp.PkgPath,
+			Name:       dp.Name,
+			Root:       root,
+			Export:     dp.ExportFile,
+			DepOnly:    !roots.Empty() && !roots.Contains(dp.ID),
+		}
+		if jp.ImportPath == "" {
+			jp.ImportPath = dp.ID
+		}
+
+		// Prefer the files actually seen by the compiler, which may include
+		// generated sources (e.g., from cgo).
+		goFiles := dp.CompiledGoFiles
+		if len(goFiles) == 0 {
+			goFiles = dp.GoFiles
+		}
+		if len(goFiles) != 0 {
+			jp.Dir = filepath.Dir(goFiles[0])
+		} else if len(dp.OtherFiles) != 0 {
+			jp.Dir = filepath.Dir(dp.OtherFiles[0])
+		}
+		jp.Goroot = goroot != "" && isWithin(jp.Dir, filepath.Join(goroot, "src"))
+		jp.GoFiles = relativeTo(jp.Dir, goFiles)
+		for _, path := range relativeTo(jp.Dir, dp.OtherFiles) {
+			switch filepath.Ext(path) {
+			case ".c":
+				jp.CFiles = append(jp.CFiles, path)
+			case ".cc", ".cpp", ".cxx":
+				jp.CXXFiles = append(jp.CXXFiles, path)
+			case ".h", ".hh", ".hpp", ".hxx":
+				jp.HFiles = append(jp.HFiles, path)
+			case ".s", ".S":
+				jp.SFiles = append(jp.SFiles, path)
+			case ".syso":
+				jp.SysoFiles = append(jp.SysoFiles, path)
+			}
+		}
+
+		for ip, id := range dp.Imports {
+			if dep := byID[id]; dep != nil && dep.PkgPath != "" {
+				ip = dep.PkgPath
+			}
+			jp.Imports = append(jp.Imports, ip)
+		}
+		sort.Strings(jp.Imports)
+
+		if len(dp.Errors) != 0 {
+			jp.Error = &jsonPackageError{
+				Pos: dp.Errors[0].Pos,
+				Err: dp.Errors[0].Msg,
+			}
+		}
+		pkgs = append(pkgs, jp)
+	}
+	return pkgs
+}
+
+// relativeTo returns paths with each made relative to dir where possible.
+func relativeTo(dir string, paths []string) []string {
+	var rel []string
+	for _, path := range paths {
+		if r, err := filepath.Rel(dir, path); err == nil && dir != "" {
+			path = r
+		}
+		rel = append(rel, path)
+	}
+	return rel
+}
+
+// isWithin reports whether path is dir or one of its descendants.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
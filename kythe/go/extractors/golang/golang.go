@@ -18,6 +18,11 @@
 // specified.  Compilations are extracted incrementally, so that partial
 // results are available to the caller.
 //
+// Packages are resolved using the go tool, so both GOPATH and module mode are
+// supported.  In module mode, packages are resolved through the go.mod and
+// go.sum files of the main module and the module cache, and VNames are derived
+// from the module path and version of each package (see govname.ForModule).
+//
 // Usage:
 //   var c golang.Extractor
 //   if _, err := c.Locate("fmt"); err != nil {
@@ -97,6 +102,7 @@ type Extractor struct {
 
 	pmap map[string]*build.Package // Map of import path to build package
 	fmap map[string]string         // Map of file path to content digest
+	mmap map[string]*Module        // Map of import path to containing module
 }
 
 // addPackage imports the specified package, if it has not already been
@@ -121,6 +127,17 @@ func (e *Extractor) mapPackage(importPath string, bp *build.Package) {
 	}
 }
 
+// mapModule records that the package with the given import path belongs to
+// module m.  A nil module is ignored.
+func (e *Extractor) mapModule(importPath string, m *Module) {
+	if m == nil {
+		return
+	} else if e.mmap == nil {
+		e.mmap = make(map[string]*Module)
+	}
+	e.mmap[importPath] = m
+}
+
 // readFile reads the contents of path as resolved through the extracted settings.
 func (e *Extractor) readFile(ctx context.Context, path string) ([]byte, error) {
 	data, err := vfs.ReadFile(ctx, path)
@@ -169,9 +186,15 @@ func (e *Extractor) findPackage(importPath string) *Package {
 	return nil
 }
 
-// vnameFor returns a vname for the specified package.
+// vnameFor returns a vname for the specified package.  Packages belonging to
+// a module are named by their module path and version.
 func (e *Extractor) vnameFor(bp *build.Package) *spb.VName {
-	v := govname.ForPackage(bp, &e.PackageVNameOptions)
+	var v *spb.VName
+	if m := e.mmap[bp.ImportPath]; m != nil {
+		v = govname.ForModule(bp.ImportPath, m.Path, m.EffectiveVersion())
+	} else {
+		v = govname.ForPackage(bp, &e.PackageVNameOptions)
+	}
 	v.Signature = "" // not useful in this context
 	return v
 }
@@ -199,8 +222,17 @@ func (e *Extractor) dirToImport(dir string) (string, error) {
 //
 // Note: multiple packages may be resolved for "/..." import paths
 func (e *Extractor) Locate(importPath string) ([]*Package, error) {
-	listedPackages, listErr := e.listPackages(importPath)
+	listedPackages, listErr := e.listPackages(e.LocalPath, importPath)
+	pkgs, err := e.addListed(listedPackages)
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, listErr
+}
 
+// addListed adds packages reported by listPackages to e, and returns those
+// that were requested directly rather than as dependencies.
+func (e *Extractor) addListed(listedPackages []*jsonPackage) ([]*Package, error) {
 	var pkgs []*Package
 	for _, pkg := range listedPackages {
 		if pkg.ForTest != "" || strings.HasSuffix(pkg.ImportPath, ".test") {
@@ -218,21 +250,38 @@ func (e *Extractor) Locate(importPath string) ([]*Package, error) {
 				Path:         importPath,
 				DepOnly:      pkg.DepOnly,
 				BuildPackage: pkg.buildPackage(),
+				Module:       pkg.Module,
 			}
 			e.Packages = append(e.Packages, p)
 			e.mapPackage(importPath, p.BuildPackage)
+			e.mapModule(importPath, pkg.Module)
 		}
 		if !pkg.DepOnly {
 			pkgs = append(pkgs, p)
 		}
 	}
-	return pkgs, listErr
+	return pkgs, nil
 }
 
 // ImportDir attempts to import the Go package located in the given directory.
-// An import path is inferred from the directory path.
+// An import path is inferred from the directory path.  If the directory is
+// within a Go module, the package is resolved by the go tool in module mode.
 func (e *Extractor) ImportDir(dir string) (*Package, error) {
 	clean := filepath.Clean(dir)
+	if findGoMod(clean) != "" {
+		listed, listErr := e.listPackages(clean, ".")
+		pkgs, err := e.addListed(listed)
+		if err != nil {
+			return nil, err
+		} else if len(pkgs) == 0 {
+			if listErr == nil {
+				listErr = fmt.Errorf("no package found in %q", clean)
+			}
+			return nil, listErr
+		}
+		return pkgs[0], nil
+	}
+
 	importPath, err := e.dirToImport(clean)
 	if err != nil {
 		return nil, err
@@ -255,6 +304,26 @@ func (e *Extractor) ImportDir(dir string) (*Package, error) {
 	return pkg, nil
 }
 
+// findGoMod returns the path of the go.mod file governing dir, or "" if there
+// is none.  Like the go tool, it searches dir and each of its parents in turn.
+func findGoMod(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(abs, "go.mod")
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		abs = parent
+	}
+}
+
 // Extract invokes the Extract method of each package in the Packages list, and
 // updates its Err field with the result.  If there were errors in extraction,
 // one of them is returned.
@@ -282,6 +351,7 @@ type Package struct {
 	DepOnly      bool                   // Whether the package is only seen as a dependency
 	Err          error                  // Error discovered during processing
 	BuildPackage *build.Package         // Package info from the go/build library
+	Module       *Module                // The containing module (nil in GOPATH mode)
 	VName        *spb.VName             // The package's Kythe vname
 	Units        []*apb.CompilationUnit // Compilations generated from Package
 }
@@ -294,7 +364,9 @@ type Package struct {
 // by the Store method.
 func (p *Package) Extract() error {
 	p.VName = p.ext.vnameFor(p.BuildPackage)
-	if r, err := govname.RepoRoot(p.Path); err == nil {
+	if p.Module != nil {
+		p.CorpusRoot = p.Module.Path
+	} else if r, err := govname.RepoRoot(p.Path); err == nil {
 		p.CorpusRoot = r.Root
 	} else {
 		p.CorpusRoot = p.VName.GetCorpus()
@@ -304,14 +376,19 @@ func (p *Package) Extract() error {
 		Argument: []string{"go", "build"},
 	}
 	bc := p.ext.BuildContext
-	if info, err := ptypes.MarshalAny(&gopb.GoDetails{
+	details := &gopb.GoDetails{
 		Gopath:     bc.GOPATH,
 		Goos:       bc.GOOS,
 		Goarch:     bc.GOARCH,
 		Compiler:   bc.Compiler,
 		BuildTags:  bc.BuildTags,
 		CgoEnabled: bc.CgoEnabled,
-	}); err == nil {
+	}
+	if m := p.Module; m != nil {
+		details.ModulePath = m.Path
+		details.ModuleVersion = m.EffectiveVersion()
+	}
+	if info, err := ptypes.MarshalAny(details); err == nil {
 		cu.Details = append(cu.Details, info)
 	}
 
@@ -336,6 +413,12 @@ func (p *Package) Extract() error {
 	p.addFiles(cu, bp.Root, srcBase, bp.HFiles)
 	p.addSource(cu, bp.Root, srcBase, bp.TestGoFiles)
 
+	// In module mode, include the module's go.mod and go.sum files, which
+	// determine how its imports are resolved.
+	if p.Module != nil {
+		p.addFiles(cu, bp.Root, "", p.moduleFiles())
+	}
+
 	// Add extra inputs that may be specified by the extractor.
 	p.addFiles(cu, filepath.Dir(bp.SrcRoot), "", p.ext.ExtraFiles)
 
@@ -467,6 +550,9 @@ func (p *Package) addFiles(cu *apb.CompilationUnit, root, base string, names []s
 		if base != "" {
 			path = filepath.Join(base, name)
 		}
+		if p.Module != nil && p.addModuleFile(cu, path) {
+			continue
+		}
 		trimmed := strings.TrimPrefix(path, root+"/")
 		vn := &spb.VName{
 			Corpus: p.ext.DefaultCorpus,
@@ -479,9 +565,11 @@ func (p *Package) addFiles(cu *apb.CompilationUnit, root, base string, names []s
 			// repository root (e.g. github.com/golang/protobuf).
 			vn.Corpus = p.VName.Corpus
 			components := strings.SplitN(vn.Path, string(filepath.Separator), 2)
-			vn.Path = strings.TrimPrefix(components[1], p.CorpusRoot+"/")
-			if components[0] != "src" {
-				vn.Root = components[0]
+			if len(components) == 2 {
+				vn.Path = strings.TrimPrefix(components[1], p.CorpusRoot+"/")
+				if components[0] != "src" {
+					vn.Root = components[0]
+				}
 			}
 		}
 		cu.RequiredInput = append(cu.RequiredInput, &apb.CompilationUnit_FileInput{
@@ -494,6 +582,49 @@ func (p *Package) addFiles(cu *apb.CompilationUnit, root, base string, names []s
 	}
 }
 
+// addModuleFile adds a required input to cu for the file at path, for a
+// package in module mode, and reports whether it did so.  Only a file within
+// the module's directory is added; it is named relative to the module path and
+// version, e.g., "github.com/golang/protobuf@v1.3.0/proto/lib.go".
+func (p *Package) addModuleFile(cu *apb.CompilationUnit, path string) bool {
+	m := p.Module
+	rel, err := filepath.Rel(m.EffectiveDir(), path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	vn := &spb.VName{
+		Corpus: m.Path,
+		Root:   m.EffectiveVersion(),
+		Path:   filepath.ToSlash(rel),
+	}
+	trimmed := m.VersionedPath() + "/" + vn.Path
+	cu.RequiredInput = append(cu.RequiredInput, &apb.CompilationUnit_FileInput{
+		VName: vn,
+		Info: &apb.FileInfo{
+			Path:   trimmed,
+			Digest: path, // provisional, until the file is loaded
+		},
+	})
+	return true
+}
+
+// moduleFiles returns the paths of the go.mod and go.sum files of the module
+// containing p, if they exist within the module's directory.
+func (p *Package) moduleFiles() []string {
+	dir := p.Module.EffectiveDir()
+	if dir == "" {
+		return nil
+	}
+	var paths []string
+	for _, name := range []string{"go.mod", "go.sum"} {
+		path := filepath.Join(dir, name)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
 // addSource acts as addFiles, and in addition marks each trimmed path as a
 // source input for the compilation.
 func (p *Package) addSource(cu *apb.CompilationUnit, root, base string, names []string) {
@@ -0,0 +1,352 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/platform/kindex"
+	"kythe.io/kythe/go/test/testutil"
+	"kythe.io/kythe/go/util/ptypes"
+
+	"github.com/google/go-cmp/cmp"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	gopb "kythe.io/kythe/proto/go_go_proto"
+)
+
+// writeFiles creates the named files under dir, with the given contents.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Creating directory: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Writing %q: %v", name, err)
+		}
+	}
+}
+
+func TestPackagesDriver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "driver_test")
+	if err != nil {
+		t.Fatalf("Creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		"foo/foo.go": "package foo\n\nimport \"github.com/fake/repo/bar\"\n\nvar X = bar.Y\n",
+		"foo/foo.h":  "// A header.\n",
+		"bar/bar.go": "package bar\n\nconst Y = 1\n",
+		"pkg/bar.a":  "fake export data for bar",
+		"pkg/foo.a":  "fake export data for foo",
+	})
+	path := func(name string) string { return filepath.Join(dir, name) }
+	rsp, err := json.Marshal(driverResponse{
+		Roots: []string{"foo"},
+		Packages: []*driverPackage{{
+			ID:              "foo",
+			Name:            "foo",
+			PkgPath:         "github.com/fake/repo/foo",
+			GoFiles:         []string{path("foo/foo.go")},
+			CompiledGoFiles: []string{path("foo/foo.go")},
+			OtherFiles:      []string{path("foo/foo.h")},
+			ExportFile:      path("pkg/foo.a"),
+			Imports:         map[string]string{"github.com/fake/repo/bar": "bar"},
+		}, {
+			ID:              "bar",
+			Name:            "bar",
+			PkgPath:         "github.com/fake/repo/bar",
+			GoFiles:         []string{path("bar/bar.go")},
+			CompiledGoFiles: []string{path("bar/bar.go")},
+			ExportFile:      path("pkg/bar.a"),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Encoding driver response: %v", err)
+	}
+	writeFiles(t, dir, map[string]string{"response.json": string(rsp)})
+
+	for name, value := range map[string]string{
+		"GOPACKAGESDRIVER":     testutil.TestFilePath(t, "testdata/fake_driver.sh"),
+		"FAKE_DRIVER_ARGS":     path("args.txt"),
+		"FAKE_DRIVER_REQUEST":  path("request.json"),
+		"FAKE_DRIVER_RESPONSE": path("response.json"),
+	} {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Setenv(name, value)
+	}
+
+	ext := &Extractor{
+		PackageVNameOptions: PackageVNameOptions{DefaultCorpus: "fake"},
+		LocalPath:           dir,
+	}
+	pkgs, err := ext.Locate("github.com/fake/repo/...")
+	if err != nil {
+		t.Fatalf("Locate failed: %v", err)
+	}
+	if len(pkgs) != 1 || pkgs[0].Path != "github.com/fake/repo/foo" {
+		t.Fatalf("Locate: got %+v, want only github.com/fake/repo/foo", pkgs)
+	}
+
+	// Check that the driver was invoked correctly.
+	args, err := ioutil.ReadFile(path("args.txt"))
+	if err != nil {
+		t.Fatalf("Reading driver arguments: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(args)), "github.com/fake/repo/..."; got != want {
+		t.Errorf("Driver arguments: got %q, want %q", got, want)
+	}
+	bits, err := ioutil.ReadFile(path("request.json"))
+	if err != nil {
+		t.Fatalf("Reading driver request: %v", err)
+	}
+	var req driverRequest
+	if err := json.Unmarshal(bits, &req); err != nil {
+		t.Fatalf("Decoding driver request: %v", err)
+	}
+	if req.Mode != driverMode {
+		t.Errorf("Driver request mode: got %#x, want %#x", req.Mode, driverMode)
+	}
+
+	if err := ext.Extract(); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	var units []*kindex.Compilation
+	if err := pkgs[0].EachUnit(context.Background(), func(idx *kindex.Compilation) error {
+		units = append(units, idx)
+		return nil
+	}); err != nil {
+		t.Fatalf("EachUnit failed: %v", err)
+	}
+	if len(units) != 1 {
+		t.Fatalf("EachUnit: got %d units, want 1", len(units))
+	}
+
+	cu := units[0].Proto
+	if diff := cmp.Diff([]string{"foo/foo.go"}, cu.SourceFile); diff != "" {
+		t.Errorf("Source files: (-want +got)\n%s", diff)
+	}
+	var inputs []string
+	for _, ri := range cu.RequiredInput {
+		inputs = append(inputs, ri.Info.GetPath())
+	}
+	sort.Strings(inputs)
+	if diff := cmp.Diff([]string{"foo/foo.go", "foo/foo.h", "pkg/bar.a"}, inputs); diff != "" {
+		t.Errorf("Required inputs: (-want +got)\n%s", diff)
+	}
+	for _, fd := range units[0].Files {
+		if fd.Info.GetPath() == "pkg/bar.a" && string(fd.Content) != "fake export data for bar" {
+			t.Errorf("Export data for bar: got %q", fd.Content)
+		}
+	}
+}
+
+// writeModuleProxy creates a module proxy under dir serving the given files
+// as version v of the module with path mpath, for use as a file:// GOPROXY.
+func writeModuleProxy(t *testing.T, dir, mpath, v string, files map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(mpath + "@" + v + "/" + name)
+		if err != nil {
+			t.Fatalf("Creating zip entry %q: %v", name, err)
+		}
+		io.WriteString(w, content)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Writing module zip: %v", err)
+	}
+	base := mpath + "/@v/" + v
+	writeFiles(t, dir, map[string]string{
+		mpath + "/@v/list": v + "\n",
+		base + ".info":     `{"Version":"` + v + `"}`,
+		base + ".mod":      files["go.mod"],
+		base + ".zip":      buf.String(),
+	})
+}
+
+func TestModuleInputs(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skipf("Go tool not available: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "module_test")
+	if err != nil {
+		t.Fatalf("Creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The main module requires a versioned dependency served by a local proxy,
+	// and a module replaced by a local directory.
+	writeModuleProxy(t, filepath.Join(dir, "proxy"), "example.com/dep", "v1.2.3", map[string]string{
+		"go.mod": "module example.com/dep\n\ngo 1.16\n",
+		"dep.go": "package dep\n\nconst X = 1\n",
+	})
+	writeFiles(t, dir, map[string]string{
+		"main/go.mod": `module example.com/main
+
+go 1.16
+
+require (
+	example.com/dep v1.2.3
+	example.com/local v0.0.0
+)
+
+replace example.com/local => ../local
+`,
+		"main/main.go":   "package main\n\nimport (\n\t\"example.com/dep\"\n\t\"example.com/local\"\n)\n\nfunc main() { _, _ = dep.X, local.Y }\n",
+		"local/go.mod":   "module example.com/local\n\ngo 1.16\n",
+		"local/local.go": "package local\n\nconst Y = 2\n",
+		"extra/data.txt": "an extra input outside any module",
+	})
+	for name, value := range map[string]string{
+		"GOPACKAGESDRIVER": "off",
+		"GO111MODULE":      "on",
+		"GOFLAGS":          "-mod=mod",
+		"GOPROXY":          "file://" + filepath.ToSlash(filepath.Join(dir, "proxy")),
+		"GOSUMDB":          "off",
+		"GOMODCACHE":       filepath.Join(dir, "modcache"),
+	} {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Setenv(name, value)
+	}
+	// The module cache is written read-only.
+	defer exec.Command("go", "clean", "-modcache").Run()
+
+	extra := filepath.Join(dir, "extra/data.txt")
+	ext := &Extractor{
+		BuildContext:        build.Default,
+		PackageVNameOptions: PackageVNameOptions{DefaultCorpus: "fake"},
+		LocalPath:           filepath.Join(dir, "main"),
+		ExtraFiles:          []string{extra},
+	}
+	mainPkg, err := ext.ImportDir(filepath.Join(dir, "main"))
+	if err != nil {
+		t.Fatalf("ImportDir failed: %v", err)
+	}
+	if err := mainPkg.Extract(); err != nil {
+		t.Fatalf("Extract main failed: %v", err)
+	}
+	pkgs := []*Package{mainPkg}
+	for _, ip := range []string{"example.com/dep", "example.com/local"} {
+		located, err := ext.Locate(ip)
+		if err != nil {
+			t.Fatalf("Locate(%q) failed: %v", ip, err)
+		} else if len(located) != 1 {
+			t.Fatalf("Locate(%q): got %d packages, want 1", ip, len(located))
+		}
+		if err := located[0].Extract(); err != nil {
+			t.Fatalf("Extract(%q) failed: %v", ip, err)
+		}
+		pkgs = append(pkgs, located[0])
+	}
+
+	// Files within a module are named by the module path and version; other
+	// inputs use the default corpus.
+	type vname struct{ Corpus, Root, Path string }
+	tests := []struct {
+		module, version string
+		inputs          map[string]vname // :: required input path → vname
+	}{{
+		module: "example.com/main",
+		inputs: map[string]vname{
+			"example.com/main/main.go": {"example.com/main", "", "main.go"},
+			"example.com/main/go.mod":  {"example.com/main", "", "go.mod"},
+			"example.com/main/go.sum":  {"example.com/main", "", "go.sum"},
+			extra:                      {"fake", "", extra},
+		},
+	}, {
+		module:  "example.com/dep",
+		version: "v1.2.3",
+		inputs: map[string]vname{
+			"example.com/dep@v1.2.3/dep.go": {"example.com/dep", "v1.2.3", "dep.go"},
+			"example.com/dep@v1.2.3/go.mod": {"example.com/dep", "v1.2.3", "go.mod"},
+			extra:                           {"fake", "", extra},
+		},
+	}, {
+		// The replacement is a local directory, so the module has no effective
+		// version.
+		module: "example.com/local",
+		inputs: map[string]vname{
+			"example.com/local/local.go": {"example.com/local", "", "local.go"},
+			"example.com/local/go.mod":   {"example.com/local", "", "go.mod"},
+			extra:                        {"fake", "", extra},
+		},
+	}}
+	for i, test := range tests {
+		pkg := pkgs[i]
+		if len(pkg.Units) != 1 {
+			t.Fatalf("Package %q: got %d units, want 1", pkg.Path, len(pkg.Units))
+		}
+		cu := pkg.Units[0]
+		var details gopb.GoDetails
+		for _, any := range cu.Details {
+			ptypes.UnmarshalAny(any, &details)
+		}
+		if details.ModulePath != test.module || details.ModuleVersion != test.version {
+			t.Errorf("Package %q: got module %q@%q, want %q@%q", pkg.Path,
+				details.ModulePath, details.ModuleVersion, test.module, test.version)
+		}
+
+		got := make(map[string]vname)
+		for _, ri := range cu.RequiredInput {
+			if isExportData(ri) {
+				continue // located in the build cache
+			}
+			v := ri.VName
+			got[ri.Info.GetPath()] = vname{v.GetCorpus(), v.GetRoot(), v.GetPath()}
+		}
+		if diff := cmp.Diff(test.inputs, got); diff != "" {
+			t.Errorf("Package %q required inputs: (-want +got)\n%s", pkg.Path, diff)
+		}
+	}
+
+	// The export data of each dependency is named for its package.
+	want := map[string]vname{
+		"example.com/dep":   {"example.com/dep", "v1.2.3", ""},
+		"example.com/local": {"example.com/local", "", ""},
+	}
+	got := make(map[string]vname)
+	for _, ri := range mainPkg.Units[0].RequiredInput {
+		if v := ri.VName; isExportData(ri) {
+			got[v.Corpus] = vname{v.Corpus, v.Root, v.Path}
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Export data inputs: (-want +got)\n%s", diff)
+	}
+}
+
+// isExportData reports whether ri is the compiled output of a package.
+func isExportData(ri *apb.CompilationUnit_FileInput) bool {
+	return ri.VName.GetLanguage() == "go"
+}
@@ -37,6 +37,7 @@ type jsonPackage struct {
 	Root       string
 	Export     string
 	Goroot     bool
+	Module     *Module
 
 	GoFiles      []string
 	CFiles       []string
@@ -114,6 +115,46 @@ func (pkg *jsonPackage) buildPackage() *build.Package {
 	return bp
 }
 
+// A Module describes a Go module, as reported by the go tool in module mode.
+// Fields must match go list; see $GOROOT/src/cmd/go/internal/modinfo/info.go.
+type Module struct {
+	Path    string  // module path
+	Version string  // module version; empty for the main module
+	Replace *Module // replacement for this module, if any
+	Main    bool    // whether this is the main module
+	Dir     string  // directory holding the module's files, if any
+	GoMod   string  // path to the go.mod file describing the module, if any
+}
+
+// VersionedPath returns the module path qualified by its effective version,
+// e.g., "github.com/golang/protobuf@v1.3.0".  The main module and modules
+// replaced by local directories are unqualified.
+func (m *Module) VersionedPath() string {
+	if v := m.EffectiveVersion(); v != "" {
+		return m.Path + "@" + v
+	}
+	return m.Path
+}
+
+// EffectiveVersion returns the version of the module whose files are used for
+// m, taking replacements into account.  It returns "" for the main module and
+// for a module replaced by a local directory.
+func (m *Module) EffectiveVersion() string {
+	if m.Replace != nil {
+		return m.Replace.Version
+	}
+	return m.Version
+}
+
+// EffectiveDir returns the directory holding the files used for m, taking
+// replacements into account.
+func (m *Module) EffectiveDir() string {
+	if m.Replace != nil && m.Replace.Dir != "" {
+		return m.Replace.Dir
+	}
+	return m.Dir
+}
+
 type jsonPackageError struct {
 	ImportStack []string
 	Pos         string
@@ -146,8 +187,20 @@ func buildContextEnv(bc build.Context) ([]string, error) {
 	return vars, nil
 }
 
-func (e *Extractor) listPackages(query ...string) ([]*jsonPackage, error) {
-	// TODO(schroederc): support GOPACKAGESDRIVER
+// listPackages runs "go list" for the given query in dir, or in the current
+// working directory if dir == "".  In module mode, dir determines the main
+// module against which the query is resolved.  If a go/packages driver is
+// selected by the environment, it is used instead of "go list".
+func (e *Extractor) listPackages(dir string, query ...string) ([]*jsonPackage, error) {
+	env, err := buildContextEnv(e.BuildContext)
+	if err != nil {
+		return nil, err
+	}
+	env = append(os.Environ(), env...)
+	if driver := findDriver(env); driver != "" {
+		return e.driverPackages(driver, dir, env, query)
+	}
+
 	args := append([]string{"list",
 		"-compiler=" + e.BuildContext.Compiler,
 		"-tags=" + strings.Join(e.BuildContext.BuildTags, ","),
@@ -164,11 +217,8 @@ func (e *Extractor) listPackages(query ...string) ([]*jsonPackage, error) {
 		goTool = filepath.Join(e.BuildContext.GOROOT, "bin/go")
 	}
 	cmd := exec.Command(goTool, args...)
-	env, err := buildContextEnv(e.BuildContext)
-	if err != nil {
-		return nil, err
-	}
-	cmd.Env = append(os.Environ(), env...)
+	cmd.Dir = dir
+	cmd.Env = env
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = os.Stderr
@@ -0,0 +1,23 @@
+#!/bin/sh
+#
+# Copyright 2019 The Kythe Authors. All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#   http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+# A fake go/packages driver for testing.  It records its arguments in the file
+# named by $FAKE_DRIVER_ARGS and its request in $FAKE_DRIVER_REQUEST, then
+# replies with the contents of $FAKE_DRIVER_RESPONSE.
+set -e
+echo "$@" > "$FAKE_DRIVER_ARGS"
+cat > "$FAKE_DRIVER_REQUEST"
+cat "$FAKE_DRIVER_RESPONSE"
@@ -125,6 +125,41 @@ func ForPackage(pkg *build.Package, opts *PackageVNameOptions) *spb.VName {
 	return v
 }
 
+// ForModule returns a VName for a Go package with the given import path that
+// belongs to the module with the given path and version.
+//
+// A module package VName has the fixed signature "package".  The corpus is the
+// module path, the root is the module version, and the path is the import path
+// relative to the module path.  The version should be empty for the main
+// module and for modules replaced by a local directory.
+//
+// Examples:
+//   ForModule("github.com/golang/protobuf/proto", "github.com/golang/protobuf", "v1.3.0") => {
+//     Corpus: "github.com/golang/protobuf",
+//     Root: "v1.3.0",
+//     Path: "proto",
+//     Language: "go",
+//     Signature: "package",
+//   }
+//
+//   ForModule("example.com/m", "example.com/m", "") => {
+//     Corpus: "example.com/m",
+//     Language: "go",
+//     Signature: "package",
+//   }
+func ForModule(importPath, modulePath, version string) *spb.VName {
+	v := &spb.VName{
+		Corpus:    modulePath,
+		Root:      version,
+		Language:  Language,
+		Signature: packageSig,
+	}
+	if importPath != modulePath {
+		v.Path = strings.TrimPrefix(importPath, modulePath+"/")
+	}
+	return v
+}
+
 // ForBuiltin returns a VName for a Go built-in with the given signature.
 func ForBuiltin(signature string) *spb.VName {
 	return &spb.VName{
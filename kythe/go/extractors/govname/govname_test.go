@@ -71,6 +71,31 @@ func TestForPackage(t *testing.T) {
 	}
 }
 
+func TestForModule(t *testing.T) {
+	tests := []struct {
+		path, module, version string
+		want                  *spb.VName
+	}{
+		{"example.com/m", "example.com/m", "",
+			&spb.VName{Corpus: "example.com/m", Language: "go", Signature: "package"}},
+		{"example.com/m/a/b", "example.com/m", "",
+			&spb.VName{Corpus: "example.com/m", Path: "a/b", Language: "go", Signature: "package"}},
+		{"github.com/golang/protobuf/proto", "github.com/golang/protobuf", "v1.3.0",
+			&spb.VName{Corpus: "github.com/golang/protobuf", Root: "v1.3.0", Path: "proto", Language: "go", Signature: "package"}},
+		{"gopkg.in/yaml.v2", "gopkg.in/yaml.v2", "v2.2.2",
+			&spb.VName{Corpus: "gopkg.in/yaml.v2", Root: "v2.2.2", Language: "go", Signature: "package"}},
+	}
+	for _, test := range tests {
+		got := ForModule(test.path, test.module, test.version)
+		if !proto.Equal(got, test.want) {
+			t.Errorf("ForModule(%q, %q, %q): got %+v, want %+v", test.path, test.module, test.version, got, test.want)
+		}
+		if ip := ImportPath(got, ""); ip != test.path {
+			t.Errorf("ImportPath(%+v): got %q, want %q", got, ip, test.path)
+		}
+	}
+}
+
 func TestIsStandardLib(t *testing.T) {
 	tests := []*spb.VName{
 		{Corpus: "golang.org"},
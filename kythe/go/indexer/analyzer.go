@@ -0,0 +1,74 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"kythe.io/kythe/go/platform/analysis"
+
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// An Analyzer implements the analysis.CompilationAnalyzer interface for Go
+// compilation units.  Each output delivered by Analyze holds a wire-format
+// Entry message, as expected by analysis.EntryOutput.
+type Analyzer struct {
+	// The source of required inputs for each compilation.  This must be set;
+	// for example, a driver.Queue that also implements analysis.Fetcher.
+	Fetcher analysis.Fetcher
+
+	// Options for resolving each compilation.  If Resolve.Info is nil, a new
+	// XRefTypeInfo is used for each compilation.
+	Resolve *ResolveOptions
+
+	// Options for emitting entries for each compilation.
+	Emit *EmitOptions
+}
+
+// Analyze implements the analysis.CompilationAnalyzer interface.
+func (a *Analyzer) Analyze(ctx context.Context, req *apb.AnalysisRequest, f analysis.OutputFunc) error {
+	if a.Fetcher == nil {
+		return errors.New("no fetcher has been specified")
+	} else if req.GetCompilation() == nil {
+		return errors.New("missing compilation unit")
+	}
+
+	var opts ResolveOptions
+	if a.Resolve != nil {
+		opts = *a.Resolve
+	}
+	if opts.Info == nil {
+		opts.Info = XRefTypeInfo()
+	}
+	pi, err := Resolve(req.Compilation, a.Fetcher, &opts)
+	if err != nil {
+		return fmt.Errorf("resolving compilation: %v", err)
+	}
+	return pi.Emit(ctx, func(ctx context.Context, entry *spb.Entry) error {
+		bits, err := proto.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("marshaling entry: %v", err)
+		}
+		return f(ctx, &apb.AnalysisOutput{Value: bits})
+	}, a.Emit)
+}
@@ -31,15 +31,14 @@ import (
 
 	"kythe.io/kythe/go/extractors/golang"
 	"kythe.io/kythe/go/indexer"
-	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/platform/analysis/sink"
 	"kythe.io/kythe/go/platform/kindex"
 	"kythe.io/kythe/go/platform/vfs"
-
-	spb "kythe.io/kythe/proto/storage_go_proto"
 )
 
 var (
 	importPath = flag.String("package", "example", "Package import path")
+	sinkFlags  = sink.DefineFlags()
 
 	bc = build.Default
 )
@@ -101,7 +100,10 @@ func main() {
 		log.Fatalf("Error extracting package; %v", err)
 	}
 
-	rw := delimited.NewWriter(os.Stdout)
+	out, err := sinkFlags.Create(os.Stdout)
+	if err != nil {
+		log.Fatalf("Error creating output: %v", err)
+	}
 	for _, pkg := range pkgs {
 		if err := pkg.EachUnit(ctx, func(unit *kindex.Compilation) error {
 			pi, err := indexer.Resolve(unit.Proto, unit, &indexer.ResolveOptions{
@@ -110,13 +112,14 @@ func main() {
 			if err != nil {
 				return err
 			}
-			return pi.Emit(ctx, func(_ context.Context, entry *spb.Entry) error {
-				return rw.PutProto(entry)
-			}, nil)
+			return pi.Emit(ctx, out.Put, nil)
 		}); err != nil {
 			log.Fatalf("Error indexing: %v", err)
 		}
 	}
+	if err := out.Close(); err != nil {
+		log.Fatalf("Error writing output: %v", err)
+	}
 }
 
 // copyFile copies the file named by path into the directory named by dir.
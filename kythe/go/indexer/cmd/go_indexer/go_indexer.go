@@ -21,55 +21,76 @@ package main
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
 	"kythe.io/kythe/go/indexer"
-	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/platform/analysis/proxy"
+	"kythe.io/kythe/go/platform/analysis/sink"
 	"kythe.io/kythe/go/platform/kindex"
 	"kythe.io/kythe/go/platform/kzip"
+	"kythe.io/kythe/go/util/flagutil"
 	"kythe.io/kythe/go/util/metadata"
 
 	apb "kythe.io/kythe/proto/analysis_go_proto"
-	spb "kythe.io/kythe/proto/storage_go_proto"
 )
 
 var (
-	doJSON      = flag.Bool("json", false, "Write output as JSON")
+	doJSON      = flag.Bool("json", false, "Write output as JSON (deprecated: use --write_format=json)")
 	doLibNodes  = flag.Bool("libnodes", false, "Emit nodes for standard library packages")
 	doCodeFacts = flag.Bool("code", false, "Emit code facts containing MarkedSource markup")
 	metaSuffix  = flag.String("meta", "", "If set, treat files with this suffix as JSON linkage metadata")
 	docBase     = flag.String("docbase", "http://godoc.org", "If set, use as the base URL for godoc links")
 	verbose     = flag.Bool("verbose", false, "Emit verbose log information")
 	contOnErr   = flag.Bool("continue", false, "Log errors encountered during analysis but do not exit unsuccessfully")
+	doDiags     = flag.Bool("diagnostics", false, "Index packages with syntax or type errors, emitting the errors as diagnostics")
+	doDataFlow  = flag.Bool("dataflow", false, "Emit influences edges for the flow of values through assignments, initializers, and calls")
+	numWorkers  = flag.Int("workers", runtime.NumCPU(), "Number of compilation units to index concurrently; up to twice this many units' entries are buffered in memory")
+	useProxy    = flag.Bool("proxy", false, "Receive compilations and send output via the analysis proxy protocol on stdin/stdout")
 
-	writeEntry func(context.Context, *spb.Entry) error
-	docURL     *url.URL
+	sinkFlags = sink.DefineFlags()
+	docURL    *url.URL
 )
 
 func init() {
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `Usage: %s [options] <path>...
+       %s [options] --proxy
 
 Generate Kythe graph data for the compilations stored in .kzip, .kindex, or
-index packs named by the path arguments. Output is written to stdout.
+index packs named by the path arguments. Output is written to stdout, or with
+--sharded_file and --shards, divided among the specified number of files by a
+hash of each entry's source VName.  Units are written in the order they are
+read even when --workers > 1.  To keep that order, each unit's entries are held
+in memory until the unit is written, and up to twice --workers units may be
+held at once; lower --workers to reduce memory use for large units.
 
 If --indexpack is set, the paths are treated as index packs. If --zip is set,
 the index packs are treaed as ZIP files. Otherwise, the paths must end in .kzip
 or .kindex and will be decoded accordingly.
 
 By default, the output is a delimited stream of wire-format Kythe Entry
-protobuf messages. With the --json flag, output is instead a stream of
-undelimited JSON messages.
+protobuf messages. With --write_format=json, output is instead a stream of
+undelimited JSON messages, and with --write_format=riegeli, a Riegeli file.
+With --dedup_cache_size, duplicate entries are dropped from the output.
+
+With --continue, errors indexing a compilation unit are logged, and a summary
+of the failed and the slowest units is printed to stderr on completion.  With
+--verbose, the number of entries written for each unit is also reported.
+
+With --proxy, no paths are read.  Instead, the indexer requests compilations,
+fetches their inputs, and delivers its output using the analysis proxy protocol
+(see kythe.io/kythe/go/platform/analysis/proxy) over stdin and stdout.
 
 Options:
-`, filepath.Base(os.Args[0]))
+`, filepath.Base(os.Args[0]), filepath.Base(os.Args[0]))
 
 		flag.PrintDefaults()
 	}
@@ -78,19 +99,15 @@ Options:
 func main() {
 	flag.Parse()
 
-	if flag.NArg() == 0 {
+	if *useProxy {
+		if flag.NArg() != 0 {
+			flagutil.UsageError("--proxy does not accept input paths")
+		}
+	} else if flag.NArg() == 0 {
 		log.Fatal("No input paths were specified to index")
 	}
 	if *doJSON {
-		enc := json.NewEncoder(os.Stdout)
-		writeEntry = func(_ context.Context, entry *spb.Entry) error {
-			return enc.Encode(entry)
-		}
-	} else {
-		rw := delimited.NewWriter(os.Stdout)
-		writeEntry = func(_ context.Context, entry *spb.Entry) error {
-			return rw.PutProto(entry)
-		}
+		sinkFlags.Format = sink.JSONFormat
 	}
 	if *docBase != "" {
 		u, err := url.Parse(*docBase)
@@ -99,20 +116,56 @@ func main() {
 		}
 		docURL = u
 	}
+	if *useProxy {
+		runProxy(context.Background())
+		return
+	}
 
+	out, err := sinkFlags.Create(os.Stdout)
+	if err != nil {
+		log.Fatalf("Error creating output: %v", err)
+	}
+
+	var stats summary
 	ctx := context.Background()
+	s := newScheduler(ctx, *numWorkers, indexGo, func(r *unitResult) error {
+		if r.err != nil {
+			if !*contOnErr {
+				return fmt.Errorf("indexing %s: %v", r.name, r.err)
+			}
+			log.Printf("Continuing after error: %v", r.err)
+		}
+		u := out.Unit(r.name)
+		for _, entry := range r.entries {
+			if err := u.Put(ctx, entry); err != nil {
+				return fmt.Errorf("writing output: %v", err)
+			}
+		}
+		if *verbose {
+			log.Printf("Indexed %s in %v (%d entries, %d duplicates)", r.name, r.elapsed, u.Entries(), u.Duplicates())
+		}
+		if *contOnErr {
+			stats.add(r, u.Entries())
+		}
+		return nil
+	})
 	for _, path := range flag.Args() {
-		if err := visitPath(ctx, path, func(ctx context.Context, unit *apb.CompilationUnit, f indexer.Fetcher) error {
-			err := indexGo(ctx, unit, f)
-			if err != nil && *contOnErr {
-				log.Printf("Continuing after error: %v", err)
-				return nil
+		if err := visitPath(path, s.submit); err != nil {
+			if ferr := s.finish(); ferr != nil {
+				err = ferr
 			}
-			return err
-		}); err != nil {
 			log.Fatalf("Error indexing %q: %v", path, err)
 		}
 	}
+	if err := s.finish(); err != nil {
+		log.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		log.Fatalf("Error writing output: %v", err)
+	}
+	if *contOnErr {
+		stats.WriteTo(os.Stderr)
+	}
 }
 
 // checkMetadata checks whether ri denotes a metadata file according to the
@@ -135,46 +188,91 @@ func checkMetadata(ri *apb.CompilationUnit_FileInput, f indexer.Fetcher) (*index
 	}, nil
 }
 
-// indexGo is a visitFunc that invokes the Kythe Go indexer on unit.
-func indexGo(ctx context.Context, unit *apb.CompilationUnit, f indexer.Fetcher) error {
-	pi, err := indexer.Resolve(unit, f, &indexer.ResolveOptions{
-		Info:       indexer.XRefTypeInfo(),
-		CheckRules: checkMetadata,
-	})
+// resolveOptions returns indexer options reflecting the flags.  A new Info
+// value is allocated for each call.
+func resolveOptions() *indexer.ResolveOptions {
+	return &indexer.ResolveOptions{
+		Info:        indexer.XRefTypeInfo(),
+		CheckRules:  checkMetadata,
+		AllowErrors: *doDiags,
+	}
+}
+
+// emitOptions returns indexer options reflecting the flags.
+func emitOptions() *indexer.EmitOptions {
+	return &indexer.EmitOptions{
+		EmitStandardLibs: *doLibNodes,
+		EmitMarkedSource: *doCodeFacts,
+		EmitLinkages:     *metaSuffix != "",
+		EmitDiagnostics:  *doDiags,
+		EmitDataFlow:     *doDataFlow,
+		DocBase:          docURL,
+	}
+}
+
+// indexGo is an indexFunc that invokes the Kythe Go indexer on unit.
+func indexGo(ctx context.Context, unit *apb.CompilationUnit, f indexer.Fetcher, emit indexer.Sink) error {
+	pi, err := indexer.Resolve(unit, f, resolveOptions())
 	if err != nil {
 		return err
 	}
 	if *verbose {
 		log.Printf("Finished resolving compilation: %s", pi.String())
 	}
-	return pi.Emit(ctx, writeEntry, &indexer.EmitOptions{
-		EmitStandardLibs: *doLibNodes,
-		EmitMarkedSource: *doCodeFacts,
-		EmitLinkages:     *metaSuffix != "",
-		DocBase:          docURL,
+	return pi.Emit(ctx, emit, emitOptions())
+}
+
+// runProxy serves analyses requested via the proxy protocol on stdin/stdout
+// until the proxy closes the connection or has no further work.
+func runProxy(ctx context.Context) {
+	c := proxy.NewClient(os.Stdin, os.Stdout)
+	opts := resolveOptions()
+	opts.Info = nil // allocated for each analysis
+	err := c.Run(ctx, &indexer.Analyzer{
+		Fetcher: c,
+		Resolve: opts,
+		Emit:    emitOptions(),
 	})
+	if _, ok := err.(proxy.ReplyError); ok {
+		log.Printf("Proxy declined further analysis: %v", err)
+	} else if err != nil {
+		log.Fatalf("Error serving proxy: %v", err)
+	}
 }
 
-type visitFunc func(context.Context, *apb.CompilationUnit, indexer.Fetcher) error
+// A visitFunc is called with a label for each compilation unit, the unit, and
+// a fetcher for its inputs.  The fetcher remains valid until the visitFunc
+// calls done, which it must do exactly once, even if it reports an error.
+type visitFunc func(name string, unit *apb.CompilationUnit, f indexer.Fetcher, done func()) error
 
 // visitPath invokes visit for each compilation denoted by path, which is
-// either a .kindex file (with a single compilation) or an index pack.
-func visitPath(ctx context.Context, path string, visit visitFunc) error {
+// either a .kindex file (with a single compilation) or a .kzip file.  The file
+// is closed once visit has released all the fetchers it was passed.
+func visitPath(path string, visit visitFunc) error {
 	f, err := os.Open(path)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	var pending sync.WaitGroup
+	defer func() {
+		go func() { pending.Wait(); f.Close() }()
+	}()
+	hold := func() func() {
+		pending.Add(1)
+		return pending.Done
+	}
+
 	switch ext := filepath.Ext(path); ext {
 	case ".kindex":
 		idx, err := kindex.New(f)
 		if err != nil {
 			return fmt.Errorf("reading .kindex: %v", err)
 		}
-		return visit(ctx, idx.Proto, idx)
+		return visit(unitName(path, idx.Proto), idx.Proto, idx, hold())
 	case ".kzip":
 		return kzip.Scan(f, func(r *kzip.Reader, unit *kzip.Unit) error {
-			return visit(ctx, unit.Proto, kzipFetcher{r})
+			name := unitName(path+":"+unit.Digest, unit.Proto)
+			return visit(name, unit.Proto, kzipFetcher{r}, hold())
 		})
 
 	default:
@@ -182,6 +280,14 @@ func visitPath(ctx context.Context, path string, visit visitFunc) error {
 	}
 }
 
+// unitName returns a label for unit, read from src, for use in logs.
+func unitName(src string, unit *apb.CompilationUnit) string {
+	if p := unit.GetVName().GetPath(); p != "" {
+		return fmt.Sprintf("%s (%s)", src, p)
+	}
+	return src
+}
+
 type kzipFetcher struct{ r *kzip.Reader }
 
 // Fetch implements the analysis.Fetcher interface. Only the digest is used in
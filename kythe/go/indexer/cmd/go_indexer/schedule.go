@@ -0,0 +1,228 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/indexer"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// An indexFunc indexes a single compilation unit, passing each entry it
+// generates to emit.
+type indexFunc func(ctx context.Context, unit *apb.CompilationUnit, f indexer.Fetcher, emit indexer.Sink) error
+
+// A unitResult records the outcome of indexing one compilation unit.
+type unitResult struct {
+	seq     int           // position of the unit in the input
+	name    string        // human-readable label for the unit
+	entries []*spb.Entry  // entries generated for the unit, in order
+	elapsed time.Duration // wall time spent indexing the unit
+	err     error         // error from indexing, or nil
+}
+
+type job struct {
+	seq  int
+	name string
+	unit *apb.CompilationUnit
+	f    indexer.Fetcher
+	done func()
+}
+
+// A scheduler indexes compilation units concurrently, and delivers the results
+// to a writer in the order the units were submitted.  Each unit's entries are
+// buffered until they are written, so the output for one unit is never
+// interleaved with that of another.
+type scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	index  indexFunc
+	write  func(*unitResult) error
+
+	jobs    chan job
+	results chan *unitResult
+	slots   chan struct{} // bounds the number of units in flight
+	workers sync.WaitGroup
+	writer  sync.WaitGroup
+	seq     int
+	err     error // the first error reported by write
+}
+
+// newScheduler starts a scheduler that runs index on up to n units at a time,
+// and calls write for each result in submission order.  If write reports an
+// error, no further units are indexed and the error is returned by finish.
+func newScheduler(ctx context.Context, n int, index indexFunc, write func(*unitResult) error) *scheduler {
+	if n <= 0 {
+		n = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &scheduler{
+		ctx:     ctx,
+		cancel:  cancel,
+		index:   index,
+		write:   write,
+		jobs:    make(chan job),
+		results: make(chan *unitResult),
+
+		// Allow some slack beyond the number of workers so that a slow unit
+		// does not idle the others while its successors wait to be written.
+		// This also bounds memory use, since every unit in flight holds all
+		// of its entries.
+		slots: make(chan struct{}, 2*n),
+	}
+	s.workers.Add(n)
+	for i := 0; i < n; i++ {
+		go s.work()
+	}
+	s.writer.Add(1)
+	go s.collect()
+	return s
+}
+
+func (s *scheduler) work() {
+	defer s.workers.Done()
+	for j := range s.jobs {
+		r := &unitResult{seq: j.seq, name: j.name}
+		if err := s.ctx.Err(); err != nil {
+			r.err = err
+		} else {
+			start := time.Now()
+			r.err = s.index(s.ctx, j.unit, j.f, func(_ context.Context, entry *spb.Entry) error {
+				r.entries = append(r.entries, entry)
+				return nil
+			})
+			r.elapsed = time.Since(start)
+		}
+		j.done()
+		s.results <- r
+	}
+}
+
+// collect reorders results into submission order and passes them to write.
+func (s *scheduler) collect() {
+	defer s.writer.Done()
+	pending := make(map[int]*unitResult)
+	next := 0
+	for r := range s.results {
+		pending[r.seq] = r
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if s.err == nil {
+				if err := s.write(r); err != nil {
+					s.err = err
+					s.cancel()
+				}
+			}
+			<-s.slots
+		}
+	}
+}
+
+// submit schedules unit to be indexed, using f to fetch its inputs.  The done
+// function is called once f is no longer needed, even if submit fails.
+func (s *scheduler) submit(name string, unit *apb.CompilationUnit, f indexer.Fetcher, done func()) error {
+	select {
+	case s.slots <- struct{}{}:
+	case <-s.ctx.Done():
+		done()
+		return s.ctx.Err()
+	}
+	s.jobs <- job{seq: s.seq, name: name, unit: unit, f: f, done: done}
+	s.seq++
+	return nil
+}
+
+// finish waits for all submitted units to be indexed and written, and returns
+// the first error reported by write, if any.
+func (s *scheduler) finish() error {
+	close(s.jobs)
+	s.workers.Wait()
+	close(s.results)
+	s.writer.Wait()
+	s.cancel()
+	return s.err
+}
+
+// A summary accumulates per-unit statistics for reporting.
+type summary struct {
+	units   []*unitResult
+	entries int
+}
+
+// add records the outcome of r, for which the given number of entries were
+// written.
+func (s *summary) add(r *unitResult, entries int) {
+	s.entries += entries
+	s.units = append(s.units, &unitResult{
+		seq:     r.seq,
+		name:    r.name,
+		elapsed: r.elapsed,
+		err:     r.err,
+	})
+}
+
+// WriteTo writes a human-readable report of the failed units and the slowest
+// units recorded in s to w.
+func (s *summary) WriteTo(w io.Writer) (int64, error) {
+	const slowest = 10
+	var total int64
+	out := func(format string, args ...interface{}) error {
+		n, err := fmt.Fprintf(w, format, args...)
+		total += int64(n)
+		return err
+	}
+
+	var failed int
+	var elapsed time.Duration
+	for _, r := range s.units {
+		elapsed += r.elapsed
+		if r.err != nil {
+			failed++
+			if err := out("FAILED\t%s\t%v\t%v\n", r.name, r.elapsed, r.err); err != nil {
+				return total, err
+			}
+		}
+	}
+
+	byTime := make([]*unitResult, len(s.units))
+	copy(byTime, s.units)
+	sort.SliceStable(byTime, func(i, j int) bool { return byTime[i].elapsed > byTime[j].elapsed })
+	if len(byTime) > slowest {
+		byTime = byTime[:slowest]
+	}
+	for _, r := range byTime {
+		if err := out("SLOW\t%s\t%v\n", r.name, r.elapsed); err != nil {
+			return total, err
+		}
+	}
+	err := out("Indexed %d units (%d failed), %d entries; %v total indexing time\n",
+		len(s.units), failed, s.entries, elapsed)
+	return total, err
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"kythe.io/kythe/go/indexer"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// fakeIndexer is an indexFunc that emits one entry per unit, naming the unit
+// by its output key.  It records the units it indexed and the order in which
+// they finished.
+type fakeIndexer struct {
+	mu       sync.Mutex
+	indexed  map[string]bool
+	finished []string
+
+	// If set, wait is called before indexing each unit, and finish after.
+	wait, finish func(name string)
+
+	// If set, fail reports whether indexing the named unit fails.
+	fail func(name string) bool
+}
+
+func (fi *fakeIndexer) index(_ context.Context, unit *apb.CompilationUnit, _ indexer.Fetcher, emit indexer.Sink) error {
+	name := unit.OutputKey
+	if fi.wait != nil {
+		fi.wait(name)
+	}
+	fi.mu.Lock()
+	fi.indexed[name] = true
+	fi.mu.Unlock()
+	defer func() {
+		fi.mu.Lock()
+		fi.finished = append(fi.finished, name)
+		fi.mu.Unlock()
+		if fi.finish != nil {
+			fi.finish(name)
+		}
+	}()
+
+	if fi.fail != nil && fi.fail(name) {
+		return fmt.Errorf("indexing %s failed", name)
+	}
+	return emit(context.Background(), &spb.Entry{FactName: "/unit", FactValue: []byte(name)})
+}
+
+// doneCounter counts the calls to the done function of each unit.
+type doneCounter struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (dc *doneCounter) done(name string) func() {
+	return func() {
+		dc.mu.Lock()
+		defer dc.mu.Unlock()
+		dc.calls[name]++
+	}
+}
+
+// check reports an error for each of the given units whose done function was
+// not called exactly once.
+func (dc *doneCounter) check(t *testing.T, names []string) {
+	t.Helper()
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	for _, name := range names {
+		if n := dc.calls[name]; n != 1 {
+			t.Errorf("Unit %s: done called %d times, want 1", name, n)
+		}
+	}
+}
+
+func fakeName(i int) string { return "u" + strconv.Itoa(i) }
+
+func fakeIndex(name string) int {
+	i, _ := strconv.Atoi(strings.TrimPrefix(name, "u"))
+	return i
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	const numUnits = 20
+
+	// Each even unit waits for its successor to finish, so the units are
+	// indexed out of order.  Every fifth unit fails.
+	gates := make(map[string]chan struct{})
+	for i := 0; i < numUnits; i++ {
+		gates[fakeName(i)] = make(chan struct{})
+	}
+	fi := &fakeIndexer{
+		indexed: make(map[string]bool),
+		wait: func(name string) {
+			if i := fakeIndex(name); i%2 == 0 {
+				<-gates[fakeName(i+1)]
+			}
+		},
+		finish: func(name string) {
+			if fakeIndex(name)%2 == 1 {
+				close(gates[name])
+			}
+		},
+		fail: func(name string) bool { return fakeIndex(name)%5 == 3 },
+	}
+	dc := &doneCounter{calls: make(map[string]int)}
+
+	var written []*unitResult
+	s := newScheduler(context.Background(), 3, fi.index, func(r *unitResult) error {
+		written = append(written, r)
+		return nil
+	})
+	var names []string
+	for i := 0; i < numUnits; i++ {
+		name := fakeName(i)
+		names = append(names, name)
+		if err := s.submit(name, &apb.CompilationUnit{OutputKey: name}, nil, dc.done(name)); err != nil {
+			t.Fatalf("Submit %s: unexpected error: %v", name, err)
+		}
+	}
+	if err := s.finish(); err != nil {
+		t.Fatalf("Finish: unexpected error: %v", err)
+	}
+
+	// Verify that the units did not finish in submission order, so that the
+	// remaining checks are meaningful.
+	inOrder := true
+	for i, name := range fi.finished {
+		if name != names[i] {
+			inOrder = false
+		}
+	}
+	if inOrder {
+		t.Errorf("Units finished in submission order: %q", fi.finished)
+	}
+
+	if len(written) != numUnits {
+		t.Fatalf("Wrote %d results, want %d", len(written), numUnits)
+	}
+	for i, r := range written {
+		if r.seq != i || r.name != names[i] {
+			t.Errorf("Result %d: got unit %d (%s), want %d (%s)", i, r.seq, r.name, i, names[i])
+		}
+		if wantErr := fi.fail(r.name); (r.err != nil) != wantErr {
+			t.Errorf("Result %s: got error %v, want error %v", r.name, r.err, wantErr)
+		} else if !wantErr && (len(r.entries) != 1 || string(r.entries[0].FactValue) != r.name) {
+			t.Errorf("Result %s: got entries %+v, want one for the unit", r.name, r.entries)
+		}
+	}
+	dc.check(t, names)
+}
+
+func TestSchedulerWriteError(t *testing.T) {
+	const failAt = 3
+	writeErr := errors.New("write failed")
+
+	fi := &fakeIndexer{indexed: make(map[string]bool)}
+	dc := &doneCounter{calls: make(map[string]int)}
+
+	var numWritten int
+	failed := make(chan struct{})
+	s := newScheduler(context.Background(), 2, fi.index, func(r *unitResult) error {
+		numWritten++
+		if r.seq == failAt {
+			close(failed)
+			return writeErr
+		}
+		return nil
+	})
+
+	// Submit units until the write error is reported.
+	var names []string
+	submit := func(i int) error {
+		name := fakeName(i)
+		names = append(names, name)
+		return s.submit(name, &apb.CompilationUnit{OutputKey: name}, nil, dc.done(name))
+	}
+	for i := 0; i <= failAt; i++ {
+		if err := submit(i); err != nil {
+			t.Fatalf("Submit %s: unexpected error: %v", fakeName(i), err)
+		}
+	}
+	<-failed
+	<-s.ctx.Done()
+
+	// Units submitted after the error must not be indexed, whether or not
+	// the submission itself succeeds.
+	late := len(names)
+	for i := late; i < late+10; i++ {
+		submit(i)
+	}
+	if err := s.finish(); err != writeErr {
+		t.Errorf("Finish: got error %v, want %v", err, writeErr)
+	}
+
+	fi.mu.Lock()
+	for _, name := range names[late:] {
+		if fi.indexed[name] {
+			t.Errorf("Unit %s was indexed after the write error", name)
+		}
+	}
+	fi.mu.Unlock()
+	if numWritten != failAt+1 {
+		t.Errorf("Wrote %d results, want %d", numWritten, failAt+1)
+	}
+	dc.check(t, names)
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package indexer
+
+import (
+	"go/ast"
+	"go/types"
+
+	"kythe.io/kythe/go/util/schema/edges"
+
+	"github.com/golang/protobuf/proto"
+
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// This file implements the optional data-flow edges emitted by the indexer.
+// An edge "x influences y" records that the value of x may flow into the
+// value of y, for example:
+//
+//   y := x         // x influences y
+//   y = f()        // f influences y
+//   T{F: x}        // x influences T.F
+//   f(x)           // x influences the corresponding parameter of f
+//   return x       // x influences the enclosing function
+//
+// Edges are emitted between semantic nodes (variables, fields, parameters,
+// and functions), so that chains of influence can be followed across
+// function boundaries in the serving tables.
+
+// A flow records an influence of src upon tgt, used to suppress duplicates.
+type flow struct{ src, tgt string }
+
+// writeInfluence emits an influences edge from src to tgt, unless the edge was
+// already emitted or src and tgt are the same node.
+func (e *emitter) writeInfluence(src, tgt *spb.VName) {
+	if src == nil || tgt == nil || proto.Equal(src, tgt) {
+		return
+	}
+	key := flow{src: src.String(), tgt: tgt.String()}
+	if _, ok := e.flows[key]; ok {
+		return
+	}
+	e.flows[key] = struct{}{}
+	e.writeEdge(src, tgt, edges.Influences)
+}
+
+// emitAssignFlow emits influences edges from the values on the right-hand side
+// of an assignment or variable declaration to the targets on the left-hand
+// side. If there is a single value for several targets, as in x, y := f(),
+// the value influences each of the targets.
+func (e *emitter) emitAssignFlow(lhs, rhs []ast.Expr) {
+	for i, expr := range lhs {
+		tgt := e.assignee(expr)
+		if tgt == nil {
+			continue
+		}
+		var val ast.Expr
+		if len(rhs) == len(lhs) {
+			val = rhs[i]
+		} else if len(rhs) == 1 {
+			val = rhs[0]
+		} else {
+			continue // mismatched counts; a type error reported elsewhere
+		}
+		for _, src := range e.influencers(val) {
+			e.writeInfluence(src, tgt)
+		}
+	}
+}
+
+// emitFieldFlow emits influences edges from the initializer of a struct field
+// in a composite literal to the field itself.
+func (e *emitter) emitFieldFlow(val ast.Expr, field *types.Var) {
+	if !e.opts.emitDataFlow() {
+		return
+	}
+	tgt := e.pi.ObjectVName(field)
+	for _, src := range e.influencers(val) {
+		e.writeInfluence(src, tgt)
+	}
+}
+
+// emitCallFlow emits influences edges from the arguments of a call to the
+// corresponding parameters of the called function, if it can be determined
+// statically.
+func (e *emitter) emitCallFlow(call *ast.CallExpr) {
+	fn, ok := e.pi.Info.Uses[calleeIdent(call.Fun)].(*types.Func)
+	if !ok {
+		return // not a call to a named function or method
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return
+	}
+	params := sig.Params()
+	for i, arg := range call.Args {
+		p := i
+		if p >= params.Len() {
+			if !sig.Variadic() {
+				break
+			}
+			p = params.Len() - 1 // additional variadic arguments
+		}
+		param := params.At(p)
+		if param.Name() == "" || param.Name() == "_" {
+			continue // unnamed parameters have no binding
+		}
+		tgt := e.pi.ObjectVName(param)
+		for _, src := range e.influencers(arg) {
+			e.writeInfluence(src, tgt)
+		}
+	}
+}
+
+// emitReturnFlow emits influences edges from the results of a return statement
+// to the function that contains it.
+func (e *emitter) emitReturnFlow(stmt *ast.ReturnStmt, stack stackFunc) {
+	fi := e.callContext(stack)
+	if fi == nil {
+		return
+	}
+	for _, res := range stmt.Results {
+		for _, src := range e.influencers(res) {
+			e.writeInfluence(src, fi.vname)
+		}
+	}
+}
+
+// assignee returns the VName of the node whose value is changed by assigning
+// to expr, or nil if there is none (e.g., for the blank identifier). Storing
+// through a pointer or into an element of a slice, array, or map is treated as
+// an assignment to the operand.
+func (e *emitter) assignee(expr ast.Expr) *spb.VName {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		obj := e.pi.Info.Defs[t]
+		if obj == nil {
+			obj = e.pi.Info.Uses[t]
+		}
+		if v, ok := obj.(*types.Var); ok && v.Pkg() != nil {
+			return e.pi.ObjectVName(v)
+		}
+	case *ast.SelectorExpr:
+		return e.assignee(t.Sel)
+	case *ast.ParenExpr:
+		return e.assignee(t.X)
+	case *ast.StarExpr:
+		return e.assignee(t.X)
+	case *ast.IndexExpr:
+		return e.assignee(t.X)
+	}
+	return nil
+}
+
+// influencers returns the VNames of the nodes whose values may influence the
+// value of expr: the variables, fields, and constants it mentions, and the
+// functions whose results it uses. Function literals are not inspected, since
+// their bodies do not contribute to the value of the enclosing expression.
+func (e *emitter) influencers(expr ast.Expr) []*spb.VName {
+	var srcs []*spb.VName
+	add := func(obj types.Object) {
+		if obj != nil && obj.Pkg() != nil {
+			srcs = append(srcs, e.pi.ObjectVName(obj))
+		}
+	}
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch t := node.(type) {
+		case *ast.FuncLit:
+			return false
+
+		case *ast.KeyValueExpr:
+			// Keys of a struct literal name fields rather than values.
+			if v, ok := e.pi.Info.Uses[baseIdent(t.Key)].(*types.Var); !ok || !v.IsField() {
+				srcs = append(srcs, e.influencers(t.Key)...)
+			}
+			srcs = append(srcs, e.influencers(t.Value)...)
+			return false
+
+		case *ast.CallExpr:
+			if tv, ok := e.pi.Info.Types[t.Fun]; ok && tv.IsType() {
+				return true // a conversion; the operand flows through
+			}
+			if fn, ok := e.pi.Info.Uses[calleeIdent(t.Fun)].(*types.Func); ok {
+				add(fn)
+				// The receiver of a method call may influence its result.
+				if sel, ok := t.Fun.(*ast.SelectorExpr); ok {
+					srcs = append(srcs, e.influencers(sel.X)...)
+				}
+				return false
+			}
+			// A call of a function value: the value determines the result.
+			srcs = append(srcs, e.influencers(t.Fun)...)
+			return false
+
+		case *ast.Ident:
+			switch obj := e.pi.Info.Uses[t].(type) {
+			case *types.Var, *types.Const, *types.Func:
+				add(obj)
+			}
+		}
+		return true
+	})
+	return srcs
+}
+
+// calleeIdent returns the identifier naming the function called by fun, or
+// nil if there is none.
+func calleeIdent(fun ast.Expr) *ast.Ident {
+	switch t := fun.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.SelectorExpr:
+		return t.Sel
+	case *ast.ParenExpr:
+		return calleeIdent(t.X)
+	}
+	return nil
+}
+
+// baseIdent returns expr if it is an identifier, or nil.
+func baseIdent(expr ast.Expr) *ast.Ident {
+	id, _ := expr.(*ast.Ident)
+	return id
+}
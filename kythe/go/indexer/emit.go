@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"go/ast"
+	"go/scanner"
 	"go/token"
 	"go/types"
 	"log"
@@ -35,6 +36,7 @@ import (
 	"kythe.io/kythe/go/util/schema/nodes"
 
 	"github.com/golang/protobuf/proto"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/types/typeutil"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
@@ -55,6 +57,16 @@ type EmitOptions struct {
 	// If true, emit linkages specified by metadata rules.
 	EmitLinkages bool
 
+	// If true, emit a diagnostic for each error reported while resolving the
+	// package, e.g., by the type checker.  The diagnostic is attached to an
+	// anchor at the location of the error, if it has one.
+	EmitDiagnostics bool
+
+	// If true, emit influences edges recording the flow of values into
+	// variables, fields, and parameters from assignments, initializers, and
+	// function calls, and out of functions via their return statements.
+	EmitDataFlow bool
+
 	// If set, use this as the base URL for links to godoc.  The import path is
 	// appended to the path of this URL to obtain the target URL to link to.
 	DocBase *url.URL
@@ -67,6 +79,10 @@ func (e *EmitOptions) emitMarkedSource() bool {
 	return e.EmitMarkedSource
 }
 
+func (e *EmitOptions) emitDiagnostics() bool { return e != nil && e.EmitDiagnostics }
+
+func (e *EmitOptions) emitDataFlow() bool { return e != nil && e.EmitDataFlow }
+
 // shouldEmit reports whether the indexer should emit a node for the given
 // vname.  Presently this is true if vname denotes a standard library and the
 // corresponding option is enabled.
@@ -99,6 +115,7 @@ func (pi *PackageInfo) Emit(ctx context.Context, sink Sink, opts *EmitOptions) e
 		opts:     opts,
 		impl:     make(map[impl]struct{}),
 		anchored: make(map[ast.Node]struct{}),
+		flows:    make(map[flow]struct{}),
 	}
 
 	// Emit a node to represent the package as a whole.
@@ -142,6 +159,14 @@ func (pi *PackageInfo) Emit(ctx context.Context, sink Sink, opts *EmitOptions) e
 				e.visitRangeStmt(n, stack)
 			case *ast.CompositeLit:
 				e.visitCompositeLit(n, stack)
+			case *ast.CallExpr:
+				if e.opts.emitDataFlow() {
+					e.emitCallFlow(n)
+				}
+			case *ast.ReturnStmt:
+				if e.opts.emitDataFlow() {
+					e.emitReturnFlow(n, stack)
+				}
 			}
 			return true
 		}), file)
@@ -151,13 +176,52 @@ func (pi *PackageInfo) Emit(ctx context.Context, sink Sink, opts *EmitOptions) e
 	// those interface types that are known to this compiltion.
 	e.emitSatisfactions()
 
-	// TODO(fromberger): Add diagnostics for type-checker errors.
+	// Emit diagnostics for parser and type-checker errors, if requested.
 	for _, err := range pi.Errors {
-		log.Printf("WARNING: Type resolution error: %v", err)
+		if e.opts.emitDiagnostics() {
+			e.emitErrorDiagnostic(err)
+		} else {
+			log.Printf("WARNING: Type resolution error: %v", err)
+		}
 	}
 	return e.firstErr
 }
 
+// emitErrorDiagnostic emits a diagnostic for an error reported while resolving
+// the package.  If the error has a known location in one of the package's
+// source files, the diagnostic is attached to an anchor spanning the innermost
+// syntax node beginning at that location; otherwise it is attached to the
+// package itself.
+func (e *emitter) emitErrorDiagnostic(err error) {
+	var pos token.Pos
+	d := diagnostic{Message: err.Error()}
+	switch t := err.(type) {
+	case types.Error:
+		pos = t.Pos
+		d.Message = t.Msg
+	case *scanner.Error:
+		pos = e.pi.position(t.Pos)
+		d.Message = t.Msg
+	}
+
+	file := e.pi.fileLoc[e.pi.FileSet.File(pos)]
+	if file == nil {
+		e.writeDiagnostic(e.pi.VName, d)
+		return
+	}
+	var node ast.Node = posNode{pos, pos}
+	if path, _ := astutil.PathEnclosingInterval(file, pos, pos); len(path) != 0 && path[0].Pos() == pos {
+		node = path[0]
+	}
+	e.writeNodeDiagnostic(node, d)
+}
+
+// A posNode is an ast.Node spanning the given positions.
+type posNode struct{ pos, end token.Pos }
+
+func (p posNode) Pos() token.Pos { return p.pos }
+func (p posNode) End() token.Pos { return p.end }
+
 type emitter struct {
 	ctx      context.Context
 	pi       *PackageInfo
@@ -166,6 +230,7 @@ type emitter struct {
 	impl     map[impl]struct{}                    // see checkImplements
 	rmap     map[*ast.File]map[int]metadata.Rules // see applyRules
 	anchored map[ast.Node]struct{}                // see writeAnchor
+	flows    map[flow]struct{}                    // see writeInfluence
 	firstErr error
 }
 
@@ -431,6 +496,13 @@ func (e *emitter) visitValueSpec(spec *ast.ValueSpec, stack stackFunc) {
 		}
 		e.writeDoc(doc, target)
 	}
+	if e.opts.emitDataFlow() && len(spec.Values) != 0 {
+		lhs := make([]ast.Expr, len(spec.Names))
+		for i, id := range spec.Names {
+			lhs[i] = id
+		}
+		e.emitAssignFlow(lhs, spec.Values)
+	}
 
 	// Handle members of anonymous types declared in situ.
 	if spec.Type != nil {
@@ -454,6 +526,15 @@ func (e *emitter) visitTypeSpec(spec *ast.TypeSpec, stack stackFunc) {
 	e.writeDef(spec, target)
 	e.writeDoc(specComment(spec, stack), target)
 
+	// Type aliases do not introduce a new type; record the alias and the type
+	// it refers to, but leave the structure of that type to its declaration.
+	if tn, ok := obj.(*types.TypeName); ok && tn.IsAlias() {
+		e.writeFact(target, facts.NodeKind, nodes.TAlias)
+		e.writeEdge(target, e.aliasedType(spec.Type), edges.Aliases)
+		e.emitAnonMembers(spec.Type)
+		return
+	}
+
 	// Emit type-specific structure.
 	switch t := obj.Type().Underlying().(type) {
 	case *types.Struct:
@@ -552,6 +633,9 @@ func (e *emitter) visitImportSpec(spec *ast.ImportSpec, stack stackFunc) {
 // visitAssignStmt handles bindings introduced by short-declaration syntax in
 // assignment statments, e.g., "x, y := 1, 2".
 func (e *emitter) visitAssignStmt(stmt *ast.AssignStmt, stack stackFunc) {
+	if e.opts.emitDataFlow() {
+		e.emitAssignFlow(stmt.Lhs, stmt.Rhs)
+	}
 	if stmt.Tok != token.DEFINE {
 		return // no new bindings in this statement
 	}
@@ -568,12 +652,17 @@ func (e *emitter) visitAssignStmt(stmt *ast.AssignStmt, stack stackFunc) {
 			}
 		}
 	}
-
-	// TODO(fromberger): Add information about initializers where available.
 }
 
 // visitRangeStmt handles the bindings introduced by a for ... range statement.
 func (e *emitter) visitRangeStmt(stmt *ast.RangeStmt, stack stackFunc) {
+	if e.opts.emitDataFlow() {
+		for _, v := range []ast.Expr{stmt.Key, stmt.Value} {
+			if v != nil {
+				e.emitAssignFlow([]ast.Expr{v}, []ast.Expr{stmt.X})
+			}
+		}
+	}
 	if stmt.Tok != token.DEFINE {
 		return // no new bindings in this statement
 	}
@@ -628,8 +717,10 @@ func (e *emitter) visitCompositeLit(expr *ast.CompositeLit, stack stackFunc) {
 				continue
 			}
 			e.emitPosRef(t.Value, sv.Field(f), edges.RefInit)
+			e.emitFieldFlow(t.Value, sv.Field(f))
 		default:
 			e.emitPosRef(t, sv.Field(i), edges.RefInit)
+			e.emitFieldFlow(t, sv.Field(i))
 		}
 	}
 }
@@ -689,6 +780,27 @@ func (e *emitter) emitAnonMembers(expr ast.Expr) {
 	}
 }
 
+// aliasedType returns the VName of the type named by expr, the right-hand side
+// of an alias declaration. If expr names another alias, the result refers to
+// that alias rather than to the type it ultimately denotes. Predeclared types
+// have no declaration, and refer to their type node.
+func (e *emitter) aliasedType(expr ast.Expr) *spb.VName {
+	var id *ast.Ident
+	switch t := expr.(type) {
+	case *ast.Ident:
+		id = t
+	case *ast.SelectorExpr:
+		id = t.Sel
+	}
+	if obj, ok := e.pi.Info.Uses[id].(*types.TypeName); ok {
+		if obj.Pkg() == nil {
+			return e.emitType(obj.Type())
+		}
+		return e.pi.ObjectVName(obj)
+	}
+	return e.emitTypeOf(expr)
+}
+
 // An override represents the relationship that x overrides y.
 type override struct {
 	x, y types.Object
@@ -41,6 +41,7 @@ import (
 	"go/ast"
 	"go/build"
 	"go/parser"
+	"go/scanner"
 	"go/token"
 	"go/types"
 	"io"
@@ -85,7 +86,7 @@ type PackageInfo struct {
 	Vendored     map[string]string             // Mapping from package to its vendor path
 
 	Info   *types.Info // If non-nil, contains type-checker results
-	Errors []error     // All errors reported by the type checker
+	Errors []error     // All errors reported by the parser and type checker
 
 	// A lazily-initialized mapping from an object on the RHS of a selection
 	// (lhs.RHS) to the nearest enclosing named struct or interface type; or in
@@ -210,6 +211,11 @@ type ResolveOptions struct {
 	//    _, err     -- an error attempting to load a ruleset
 	//
 	CheckRules func(ri *apb.CompilationUnit_FileInput, f Fetcher) (*Ruleset, error)
+
+	// If true, syntax errors in the source files do not cause Resolve to fail.
+	// Instead, whatever portion of each file could be parsed is type-checked
+	// and the syntax errors are added to the Errors field of the PackageInfo.
+	AllowErrors bool
 }
 
 func (r *ResolveOptions) info() *types.Info {
@@ -219,6 +225,8 @@ func (r *ResolveOptions) info() *types.Info {
 	return nil
 }
 
+func (r *ResolveOptions) allowErrors() bool { return r != nil && r.AllowErrors }
+
 func (r *ResolveOptions) checkRules(ri *apb.CompilationUnit_FileInput, f Fetcher) (*Ruleset, error) {
 	if r == nil || r.CheckRules == nil {
 		return nil, nil
@@ -249,6 +257,7 @@ func Resolve(unit *apb.CompilationUnit, f Fetcher, opts *ResolveOptions) (*Packa
 	details := goDetails(unit)
 	var files []*ast.File // parsed sources
 	var rules []*Ruleset  // parsed linkage rules
+	var errs []error      // syntax errors, if allowed
 
 	// Classify the required inputs as either sources, which are to be parsed,
 	// or dependencies, which are to be "imported" via the type-checker's
@@ -283,7 +292,11 @@ func Resolve(unit *apb.CompilationUnit, f Fetcher, opts *ResolveOptions) (*Packa
 				vpath = fpath
 			}
 			parsed, err := parser.ParseFile(fset, vpath, data, parser.AllErrors|parser.ParseComments)
-			if err != nil {
+			if list, ok := err.(scanner.ErrorList); ok && parsed != nil && opts.allowErrors() {
+				for _, e := range list {
+					errs = append(errs, e)
+				}
+			} else if err != nil {
 				return nil, fmt.Errorf("parsing %q: %v", fpath, err)
 			}
 
@@ -347,6 +360,7 @@ func Resolve(unit *apb.CompilationUnit, f Fetcher, opts *ResolveOptions) (*Packa
 		FileSet:      fset,
 		Files:        files,
 		Info:         opts.info(),
+		Errors:       errs,
 		SourceText:   srcs,
 		PackageVName: make(map[*types.Package]*spb.VName),
 		Dependencies: make(map[string]*types.Package), // :: import path → package
@@ -520,6 +534,17 @@ func (pi *PackageInfo) Span(node ast.Node) (file *ast.File, start, end int) {
 	return
 }
 
+// position returns the position in pi.FileSet corresponding to p, or
+// token.NoPos if p does not denote a location in one of the source files.
+func (pi *PackageInfo) position(p token.Position) token.Pos {
+	for tf := range pi.fileLoc {
+		if tf.Name() == p.Filename && p.Offset <= tf.Size() {
+			return tf.Pos(p.Offset)
+		}
+	}
+	return token.NoPos
+}
+
 const (
 	isBuiltin = "builtin-"
 	tagConst  = "const"
@@ -700,9 +725,12 @@ func (pi *PackageInfo) addOwners(pkg *types.Package) {
 	for _, name := range scope.Names() {
 		switch obj := scope.Lookup(name).(type) {
 		case *types.TypeName:
-			// TODO(schroederc): support for type aliases.  For now, skip these so we
-			// don't wind up emitting redundant declaration sites for the aliased
-			// type.
+			// Type aliases do not own the fields and methods of the aliased
+			// type; skip them so we don't wind up emitting redundant
+			// declaration sites for the aliased type.
+			if obj.IsAlias() {
+				continue
+			}
 			named, ok := obj.Type().(*types.Named)
 			if !ok {
 				continue
@@ -25,8 +25,11 @@ import (
 	"go/token"
 	"io/ioutil"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
+	"kythe.io/kythe/go/platform/analysis"
 	"kythe.io/kythe/go/test/testutil"
 	"kythe.io/kythe/go/util/metadata"
 	"kythe.io/kythe/go/util/ptypes"
@@ -182,6 +185,133 @@ func TestResolveErrors(t *testing.T) {
 	}
 }
 
+func TestResolveSyntaxErrors(t *testing.T) {
+	const input = `package pkg
+
+func f() int {
+	return 1 +
+}
+`
+	unit, digest := oneFileCompilation("bad.go", "pkg", input)
+	fetcher := memFetcher{digest: input}
+	if pi, err := Resolve(unit, fetcher, nil); err == nil {
+		t.Errorf("Resolving package with syntax errors: got %+v, wanted error", pi)
+	}
+
+	pi, err := Resolve(unit, fetcher, &ResolveOptions{AllowErrors: true})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v\nInput unit:\n%s", err, proto.MarshalTextString(unit))
+	}
+	if len(pi.Errors) == 0 {
+		t.Error("Resolve reported no errors for package with syntax errors")
+	}
+	for _, err := range pi.Errors {
+		t.Logf("Got expected error: %v", err)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	const input = `package pkg
+
+var x int = "bogus"
+
+func f() string {
+	undefined()
+	return 0 +
+}
+`
+	unit, digest := oneFileCompilation("diag.go", "pkg", input)
+	pi, err := Resolve(unit, memFetcher{digest: input}, &ResolveOptions{
+		Info:        XRefTypeInfo(),
+		AllowErrors: true,
+	})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v\nInput unit:\n%s", err, proto.MarshalTextString(unit))
+	}
+
+	// Record the spans of the anchors tagged with diagnostics.
+	tagged := make(map[string]bool)
+	diags := make(map[string]bool)
+	if err := pi.Emit(context.Background(), func(_ context.Context, e *spb.Entry) error {
+		if e.EdgeKind == "/kythe/edge/tagged" {
+			tagged[e.Source.Signature] = true
+		} else if e.FactName == "/kythe/node/kind" && string(e.FactValue) == "diagnostic" {
+			diags[e.Source.Signature] = true
+		}
+		return nil
+	}, &EmitOptions{EmitDiagnostics: true}); err != nil {
+		t.Fatalf("Emit unexpectedly failed: %v", err)
+	}
+
+	// Identical errors are reported by a single diagnostic node.
+	msgs := make(map[string]bool)
+	for _, err := range pi.Errors {
+		msgs[err.Error()] = true
+	}
+	if got, want := len(diags), len(msgs); got != want {
+		t.Errorf("Wrong number of diagnostics: got %d, want %d", got, want)
+	}
+	span := func(s string) string {
+		start := strings.Index(input, s)
+		return "#" + strconv.Itoa(start) + ":" + strconv.Itoa(start+len(s))
+	}
+	for _, want := range []string{
+		span(`"bogus"`),   // type error
+		span("undefined"), // type error
+	} {
+		if !tagged[want] {
+			t.Errorf("Missing diagnostic for anchor %q; got %v", want, tagged)
+		}
+	}
+}
+
+func TestDataFlow(t *testing.T) {
+	const input = `package pkg
+
+type T struct{ F int }
+
+func f(p int) int { return p }
+
+var x = f(1)
+var y = T{F: x}
+`
+	unit, digest := oneFileCompilation("flow.go", "pkg", input)
+	pi, err := Resolve(unit, memFetcher{digest: input}, &ResolveOptions{
+		Info: XRefTypeInfo(),
+	})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v\nInput unit:\n%s", err, proto.MarshalTextString(unit))
+	}
+
+	flows := func(opts *EmitOptions) map[string]bool {
+		got := make(map[string]bool)
+		if err := pi.Emit(context.Background(), func(_ context.Context, e *spb.Entry) error {
+			if e.EdgeKind == "/kythe/edge/influences" {
+				got[e.Source.Signature+" -> "+e.Target.Signature] = true
+			}
+			return nil
+		}, opts); err != nil {
+			t.Fatalf("Emit unexpectedly failed: %v", err)
+		}
+		return got
+	}
+
+	if got := flows(&EmitOptions{}); len(got) != 0 {
+		t.Errorf("Unexpected influences edges without EmitDataFlow: %v", got)
+	}
+	got := flows(&EmitOptions{EmitDataFlow: true})
+	for _, want := range []string{
+		"param f:p -> func f", // return p
+		"func f -> var x",     // x = f(1)
+		"var x -> field T.F",  // T{F: x}
+		"var x -> var y",
+	} {
+		if !got[want] {
+			t.Errorf("Missing influences edge %q; got %v", want, got)
+		}
+	}
+}
+
 func TestSpan(t *testing.T) {
 	const input = `package main
 
@@ -221,6 +351,36 @@ type fakeNode struct{ pos, end token.Pos }
 func (f fakeNode) Pos() token.Pos { return f.pos }
 func (f fakeNode) End() token.Pos { return f.end }
 
+func TestAnalyzer(t *testing.T) {
+	const input = "package pkg\n\nvar V int\n"
+	unit, digest := oneFileCompilation("pkg.go", "pkg", input)
+	ctx := context.Background()
+
+	var kinds []string
+	a := &Analyzer{Fetcher: memFetcher{digest: input}}
+	if err := a.Analyze(ctx, &apb.AnalysisRequest{Compilation: unit}, analysis.EntryOutput(func(_ context.Context, e *spb.Entry) error {
+		if e.FactName == "/kythe/node/kind" && proto.Equal(e.Source, unit.VName) {
+			kinds = append(kinds, string(e.FactValue))
+		}
+		return nil
+	})); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(kinds) != 1 || kinds[0] != "package" {
+		t.Errorf("Package node kinds: got %q, want [package]", kinds)
+	}
+
+	// An analyzer without a fetcher, or a request without a compilation,
+	// should be rejected.
+	nop := func(context.Context, *apb.AnalysisOutput) error { return nil }
+	if err := new(Analyzer).Analyze(ctx, &apb.AnalysisRequest{Compilation: unit}, nop); err == nil {
+		t.Error("Analyze without a fetcher: got nil, want error")
+	}
+	if err := a.Analyze(ctx, new(apb.AnalysisRequest), nop); err == nil {
+		t.Error("Analyze without a compilation: got nil, want error")
+	}
+}
+
 func TestSink(t *testing.T) {
 	var facts, edges []*spb.Entry
 
@@ -18,6 +18,7 @@ package indexer
 
 import (
 	"fmt"
+	"go/ast"
 	"go/types"
 	"log"
 	"strings"
@@ -146,6 +147,21 @@ func (pi *PackageInfo) MarkedSource(obj types.Object) *cpb.MarkedSource {
 		ms = repl
 
 	case *types.TypeName:
+		if t.IsAlias() {
+			// For type aliases, include the aliased type.
+			ms = &cpb.MarkedSource{
+				Kind:          cpb.MarkedSource_BOX,
+				PostChildText: " ",
+				Child: []*cpb.MarkedSource{
+					{PreText: "type"},
+					ms,
+					{PreText: "="},
+					{Kind: cpb.MarkedSource_TYPE, PreText: pi.aliasedTypeName(t)},
+				},
+			}
+			break
+		}
+
 		// For named types, include the underlying type.
 		repl := &cpb.MarkedSource{
 			Kind:          cpb.MarkedSource_BOX,
@@ -193,6 +209,36 @@ func typeName(typ types.Type) string {
 	return typ.String()
 }
 
+// aliasedTypeName returns a human-readable name for the type on the right-hand
+// side of the declaration of alias. If that side names another alias, the
+// result is the name of that alias rather than of the type it denotes.
+func (pi *PackageInfo) aliasedTypeName(alias *types.TypeName) string {
+	pos := alias.Pos()
+	for _, file := range pi.Files {
+		if pos < file.Pos() || pos >= file.End() {
+			continue
+		}
+		var name string
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.TypeSpec)
+			if !ok || spec.Name.Pos() != pos {
+				return name == ""
+			}
+			switch t := spec.Type.(type) {
+			case *ast.Ident:
+				name = t.Name
+			case *ast.SelectorExpr:
+				name = t.Sel.Name
+			}
+			return false
+		})
+		if name != "" {
+			return name
+		}
+	}
+	return typeName(alias.Type())
+}
+
 // typeContext returns the package, type, and function context identifiers that
 // qualify the name of obj, if any are applicable. The result is empty if there
 // are no appropriate qualifiers.
@@ -0,0 +1,62 @@
+// Package alias tests properties of type alias declarations.
+package alias
+
+import "fmt"
+
+//- @T defines/binding T
+//- T.node/kind record
+type T struct {
+	//- @F defines/binding F
+	//- F childof T
+	F int
+}
+
+//- @A defines/binding A
+//- @"A = T" defines A
+//- A.node/kind talias
+//- A aliases T
+//- @T ref T
+type A = T
+
+//- !{F childof A}
+
+//- @B defines/binding B
+//- B.node/kind talias
+//- B aliases A
+//- !{B aliases T}
+type B = A
+
+//- @Str defines/binding Str
+//- Str.node/kind talias
+//- Str aliases Stringer
+//- @Stringer ref Stringer
+type Str = fmt.Stringer
+
+//- @Int defines/binding Int
+//- Int.node/kind talias
+//- Int aliases IntType
+//- IntType.node/kind tbuiltin
+type Int = int
+
+//- @Anon defines/binding Anon
+//- Anon.node/kind talias
+//- Anon aliases AnonType
+//- AnonType.node/kind record
+type Anon = struct {
+	//- @G defines/binding G
+	//- G.node/kind variable
+	//- G.subkind field
+	G string
+}
+
+// Refs through an alias resolve to the alias binding.
+//
+//- @A ref A
+//- !{@A ref T}
+var x A
+
+//- @Int ref Int
+var y Int
+
+//- @F ref F
+var z = x.F
@@ -0,0 +1,39 @@
+// Package flow tests data-flow edges for assignments and initializers.
+package flow
+
+//- @T defines/binding T
+type T struct {
+	//- @F defines/binding F
+	F int
+}
+
+//- @source defines/binding Source
+//- Source influences X
+func source() int { return 1 }
+
+//- @seed defines/binding Seed
+//- Seed influences Y
+var seed = 5
+
+//- @x defines/binding X
+var x = source()
+
+//- @y defines/binding Y
+//- Y influences F
+//- Y influences Z
+var y = seed
+
+// A composite literal initializes the field from its value.
+var t = T{F: y}
+
+//- @sink defines/binding Sink
+//- @p defines/binding P
+//- P influences Sink
+func sink(p int) int {
+	//- @z defines/binding Z
+	//- X influences P
+	//- Y influences Z
+	z := y
+	z = sink(x)
+	return p
+}
@@ -0,0 +1,36 @@
+// Package alias tests code facts for a type alias.
+package alias
+
+type T struct{}
+
+//- @A defines/binding Alias
+//- Alias code ACode
+//-
+//- ACode.kind "BOX"
+//- ACode.post_child_text " "
+//- ACode child.0 AType
+//- ACode child.1 AName
+//- ACode child.2 AEq
+//- ACode child.3 ATarget
+//-
+//- AType.pre_text "type"
+//- AEq.pre_text "="
+//-
+//- AName child.0 AContext
+//- AName child.1 AIdent
+//- AContext.kind "CONTEXT"
+//- AContext child.0 APkg
+//- APkg.pre_text "alias"
+//- AIdent.kind "IDENTIFIER"
+//- AIdent.pre_text "A"
+//-
+//- ATarget.kind "TYPE"
+//- ATarget.pre_text "T"
+type A = T
+
+//- @B defines/binding AliasB
+//- AliasB code BCode
+//- BCode child.3 BTarget
+//- BTarget.kind "TYPE"
+//- BTarget.pre_text "A"
+type B = A
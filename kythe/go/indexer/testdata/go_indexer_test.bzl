@@ -150,6 +150,10 @@ def _go_entries(ctx):
     if ctx.attr.has_marked_source:
         iargs.append("-code")
 
+    # If the test wants data-flow edges, enable support for them in the indexer.
+    if ctx.attr.has_data_flow:
+        iargs.append("-dataflow")
+
     # If the test wants linkage metadata, enable support for it in the indexer.
     if ctx.attr.metadata_suffix:
         iargs += ["-meta", ctx.attr.metadata_suffix]
@@ -170,6 +174,9 @@ def _go_entries(ctx):
 go_entries = rule(
     _go_entries,
     attrs = {
+        # Whether to enable emission of data-flow (influences) edges.
+        "has_data_flow": attr.bool(default = False),
+
         # Whether to enable explosion of MarkedSource facts.
         "has_marked_source": attr.bool(default = False),
 
@@ -225,6 +232,7 @@ def _go_indexer(
         importpath = None,
         data = None,
         has_marked_source = False,
+        has_data_flow = False,
         allow_duplicates = False,
         metadata_suffix = ""):
     if len(deps) > 0:
@@ -248,6 +256,7 @@ def _go_indexer(
     entries = name + "_entries"
     go_entries(
         name = entries,
+        has_data_flow = has_data_flow,
         has_marked_source = has_marked_source,
         kzip = ":" + kzip,
         metadata_suffix = metadata_suffix,
@@ -266,12 +275,14 @@ def go_indexer_test(
         log_entries = False,
         data = None,
         has_marked_source = False,
+        has_data_flow = False,
         allow_duplicates = False,
         metadata_suffix = ""):
     entries = _go_indexer(
         name = name,
         srcs = srcs,
         data = data,
+        has_data_flow = has_data_flow,
         has_marked_source = has_marked_source,
         importpath = import_path,
         metadata_suffix = metadata_suffix,
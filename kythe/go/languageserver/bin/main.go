@@ -29,7 +29,7 @@ import (
 	"time"
 
 	"kythe.io/kythe/go/languageserver"
-	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/api"
 
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -38,13 +38,15 @@ var (
 	pageSize = flag.Int("page_size", 0, "Set the default xrefs page size")
 
 	serverAddr = flag.String("server", "localhost:8080",
-		"The address of the Kythe service to use")
+		"The address of the Kythe HTTP service to use (ignored if --api is set)")
+
+	apiFlag = api.Flag("api", "", api.CommonFlagUsage)
 )
 
 func main() {
 	flag.Parse()
-	if *serverAddr == "" {
-		log.Fatal("You must provide a --server address")
+	if *apiFlag == nil && *serverAddr == "" {
+		log.Fatal("You must provide an --api specification or --server address")
 	}
 
 	// Set up the log file
@@ -56,15 +58,23 @@ func main() {
 	}
 	log.SetOutput(file)
 
-	// Check to see that xref service is reachable. We won't hold open a
-	// connection to the server here, as the client manages the connection.
-	conn, err := net.DialTimeout("tcp", *serverAddr, 5*time.Second)
-	if err != nil {
-		log.Fatalf("Dialing Kythe service: %v", err)
+	client := *apiFlag
+	if client == nil {
+		// Check to see that xref service is reachable. We won't hold open a
+		// connection to the server here, as the client manages the connection.
+		conn, err := net.DialTimeout("tcp", *serverAddr, 5*time.Second)
+		if err != nil {
+			log.Fatalf("Dialing Kythe service: %v", err)
+		}
+		conn.Close()
+
+		client, err = api.ParseSpec("http://" + *serverAddr)
+		if err != nil {
+			log.Fatalf("Invalid --server address: %v", err)
+		}
 	}
-	conn.Close()
+	defer client.Close(context.Background())
 
-	client := xrefs.WebClient("http://" + *serverAddr)
 	server := languageserver.NewServer(client, &languageserver.Options{
 		PageSize: *pageSize,
 	})
@@ -0,0 +1,324 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package languageserver
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"kythe.io/kythe/go/util/markedsource"
+	"kythe.io/kythe/go/util/schema/edges"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+)
+
+// The call hierarchy requests were added in v3.16 of the protocol, after the
+// version supported by the lsp package, so their messages are defined here.
+
+// CallHierarchyItem represents a function in a call hierarchy.
+type CallHierarchyItem struct {
+	Name           string          `json:"name"`
+	Kind           lsp.SymbolKind  `json:"kind"`
+	Detail         string          `json:"detail,omitempty"`
+	URI            lsp.DocumentURI `json:"uri"`
+	Range          lsp.Range       `json:"range"`
+	SelectionRange lsp.Range       `json:"selectionRange"`
+
+	// Data is preserved by the client between requests; it holds the Kythe
+	// ticket of the function.
+	Data string `json:"data,omitempty"`
+}
+
+// CallHierarchyPrepareParams are the parameters of a
+// textDocument/prepareCallHierarchy request.
+type CallHierarchyPrepareParams struct {
+	lsp.TextDocumentPositionParams
+}
+
+// CallHierarchyIncomingCallsParams are the parameters of a
+// callHierarchy/incomingCalls request.
+type CallHierarchyIncomingCallsParams struct {
+	Item CallHierarchyItem `json:"item"`
+}
+
+// CallHierarchyIncomingCall represents a caller of a function.
+type CallHierarchyIncomingCall struct {
+	From CallHierarchyItem `json:"from"`
+
+	// FromRanges are the call sites within From.
+	FromRanges []lsp.Range `json:"fromRanges"`
+}
+
+// CallHierarchyOutgoingCallsParams are the parameters of a
+// callHierarchy/outgoingCalls request.
+type CallHierarchyOutgoingCallsParams struct {
+	Item CallHierarchyItem `json:"item"`
+}
+
+// CallHierarchyOutgoingCall represents a callee of a function.
+type CallHierarchyOutgoingCall struct {
+	To CallHierarchyItem `json:"to"`
+
+	// FromRanges are the call sites within the calling function.
+	FromRanges []lsp.Range `json:"fromRanges"`
+}
+
+// TextDocumentPrepareCallHierarchy resolves the function referenced at a given
+// location to the items used for subsequent call hierarchy requests. This
+// requires a Graph service.
+//
+// NOTE: As per the lsp spec, if no error is returned, a non-nil item slice
+// must be returned
+func (ls *Server) TextDocumentPrepareCallHierarchy(params CallHierarchyPrepareParams) ([]CallHierarchyItem, error) {
+	if ls.Graph == nil {
+		return []CallHierarchyItem{}, nil
+	}
+	local, err := ls.localFromURI(params.TextDocument.URI)
+	if err != nil {
+		return []CallHierarchyItem{}, err
+	}
+
+	// If we don't have decorations we can't find the function
+	doc, exists := ls.docs[local]
+	if !exists {
+		log.Printf("Call hierarchy requested from unknown file %q", local)
+		return []CallHierarchyItem{}, nil
+	}
+
+	ref := doc.xrefs(params.Position)
+	if ref == nil {
+		log.Printf("No ref found at %v", params.Position)
+		return []CallHierarchyItem{}, nil
+	}
+
+	xrefs, err := ls.XRefs.CrossReferences(context.TODO(), &xpb.CrossReferencesRequest{
+		Ticket:         []string{ref.ticket},
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find definitions for ticket %q: %v", ref.ticket, err)
+	}
+
+	items := []CallHierarchyItem{}
+	if item := ls.callItem(local.Workspace, xrefs.CrossReferences[ref.ticket]); item != nil {
+		ls.setCallItemKinds([]*CallHierarchyItem{item})
+		items = append(items, *item)
+	}
+	return items, nil
+}
+
+// CallHierarchyIncomingCalls produces the callers of a call hierarchy item,
+// along with the sites of their calls. This requires a Graph service.
+//
+// NOTE: As per the lsp spec, if no error is returned, a non-nil call slice
+// must be returned
+func (ls *Server) CallHierarchyIncomingCalls(params CallHierarchyIncomingCallsParams) ([]CallHierarchyIncomingCall, error) {
+	ticket := params.Item.Data
+	if ls.Graph == nil || ticket == "" {
+		return []CallHierarchyIncomingCall{}, nil
+	}
+	local, err := ls.localFromURI(params.Item.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	xrefs, err := ls.XRefs.CrossReferences(context.TODO(), &xpb.CrossReferencesRequest{
+		Ticket:     []string{ticket},
+		CallerKind: xpb.CrossReferencesRequest_DIRECT_CALLERS,
+		PageSize:   int32(ls.opts.pageSize()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find callers for ticket %q: %v", ticket, err)
+	}
+
+	calls := []CallHierarchyIncomingCall{}
+	for _, c := range xrefs.CrossReferences[ticket].GetCaller() {
+		from := ls.callItemFromAnchor(local.Workspace, c.Ticket, c.Anchor, c.MarkedSource)
+		if from == nil {
+			continue
+		}
+		calls = append(calls, CallHierarchyIncomingCall{
+			From:       *from,
+			FromRanges: ls.siteRanges(local.Workspace, c.Site),
+		})
+	}
+	items := make([]*CallHierarchyItem, len(calls))
+	for i := range calls {
+		items[i] = &calls[i].From
+	}
+	ls.setCallItemKinds(items)
+	return calls, nil
+}
+
+// CallHierarchyOutgoingCalls produces the callees of a call hierarchy item,
+// along with the sites of the item's calls to them. The calls are the
+// ref/call anchors in the item's file whose semantic scope is the item
+// itself. This requires a Graph service.
+//
+// NOTE: As per the lsp spec, if no error is returned, a non-nil call slice
+// must be returned
+func (ls *Server) CallHierarchyOutgoingCalls(params CallHierarchyOutgoingCallsParams) ([]CallHierarchyOutgoingCall, error) {
+	ticket := params.Item.Data
+	if ls.Graph == nil || ticket == "" {
+		return []CallHierarchyOutgoingCall{}, nil
+	}
+	local, err := ls.localFromURI(params.Item.URI)
+	if err != nil {
+		return nil, err
+	}
+	file, err := local.KytheURI()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.TODO()
+	dec, err := ls.XRefs.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:       &xpb.Location{Ticket: file.String()},
+		References:     true,
+		SemanticScopes: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find decorations for %q: %v", local, err)
+	}
+
+	var callees []string
+	sites := make(map[string][]lsp.Range)
+	for _, r := range dec.Reference {
+		if r.SemanticScope != ticket || !edges.IsVariant(r.Kind, edges.RefCall) {
+			continue
+		}
+		rng := spanToRange(r.Span)
+		if rng == nil {
+			continue
+		}
+		if _, ok := sites[r.TargetTicket]; !ok {
+			callees = append(callees, r.TargetTicket)
+		}
+		l := ls.locationInNewSource(lsp.Location{URI: local.URI(), Range: *rng})
+		sites[r.TargetTicket] = append(sites[r.TargetTicket], l.Range)
+	}
+	if len(callees) == 0 {
+		return []CallHierarchyOutgoingCall{}, nil
+	}
+
+	xrefs, err := ls.XRefs.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:         callees,
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find definitions for callees of %q: %v", ticket, err)
+	}
+
+	calls := []CallHierarchyOutgoingCall{}
+	for _, callee := range callees {
+		to := ls.callItem(local.Workspace, xrefs.CrossReferences[callee])
+		if to == nil {
+			continue
+		}
+		calls = append(calls, CallHierarchyOutgoingCall{
+			To:         *to,
+			FromRanges: sites[callee],
+		})
+	}
+	items := make([]*CallHierarchyItem, len(calls))
+	for i := range calls {
+		items[i] = &calls[i].To
+	}
+	ls.setCallItemKinds(items)
+	return calls, nil
+}
+
+// setCallItemKinds sets the kinds of the given items from the kinds and
+// subkinds of their nodes, and those of their parents, in the Graph service.
+// Items whose nodes are unknown are left as functions.
+func (ls *Server) setCallItemKinds(items []*CallHierarchyItem) {
+	tickets := make([]string, len(items))
+	for i, item := range items {
+		tickets[i] = item.Data
+	}
+	info := ls.symbolInfo(tickets)
+
+	var parents []string
+	for _, n := range info {
+		if n.parent != "" {
+			parents = append(parents, n.parent)
+		}
+	}
+	parentInfo := ls.symbolInfo(parents)
+
+	for _, item := range items {
+		if n, ok := info[item.Data]; ok && n.kind != "" {
+			item.Kind = symbolKind(n.kind, n.subkind, parentInfo[n.parent].kind)
+		}
+	}
+}
+
+// callItem produces a call hierarchy item for the first binding definition in
+// the given cross-reference set, or nil if there is none.
+func (ls *Server) callItem(w Workspace, set *xpb.CrossReferencesReply_CrossReferenceSet) *CallHierarchyItem {
+	for _, def := range set.GetDefinition() {
+		if item := ls.callItemFromAnchor(w, set.Ticket, def.Anchor, set.MarkedSource); item != nil {
+			return item
+		}
+	}
+	return nil
+}
+
+// callItemFromAnchor produces a call hierarchy item for the function with the
+// given ticket and defining anchor, or nil if the anchor has no location. The
+// item is reported as a function until refined by setCallItemKinds.
+func (ls *Server) callItemFromAnchor(w Workspace, ticket string, a *xpb.Anchor, ms *cpb.MarkedSource) *CallHierarchyItem {
+	loc := ls.anchorToLoc(w, a)
+	if loc == nil {
+		return nil
+	}
+	l := ls.locationInNewSource(*loc)
+
+	name := a.Text
+	var detail string
+	if ms != nil {
+		if id := markedsource.RenderSimpleIdentifier(ms); id != "" {
+			name = id
+		}
+		detail = markedsource.Render(ms)
+	}
+	return &CallHierarchyItem{
+		Name:           name,
+		Kind:           lsp.SKFunction,
+		Detail:         detail,
+		URI:            l.URI,
+		Range:          l.Range,
+		SelectionRange: l.Range,
+		Data:           ticket,
+	}
+}
+
+// siteRanges maps call site anchors to their ranges in the current source.
+// Sites without a location are dropped. The result is non-nil.
+func (ls *Server) siteRanges(w Workspace, sites []*xpb.Anchor) []lsp.Range {
+	rs := []lsp.Range{}
+	for _, s := range sites {
+		if l := ls.anchorToLoc(w, s); l != nil {
+			rs = append(rs, ls.locationInNewSource(*l).Range)
+		}
+	}
+	return rs
+}
@@ -20,6 +20,8 @@ import (
 	"sort"
 	"strings"
 
+	"kythe.io/kythe/go/util/schema/edges"
+
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 )
@@ -67,6 +69,106 @@ func (doc *document) xrefs(pos lsp.Position) *RefResolution {
 	return smallestValidRef
 }
 
+// bindings returns the refs in the document that define a binding and that
+// are still valid in the current file contents, in document order.
+func (doc *document) bindings() []*RefResolution {
+	if doc.staleRefs {
+		doc.generateNewRefs()
+	}
+
+	var bs []*RefResolution
+	for _, r := range doc.refs {
+		if r.newRange != nil && edges.IsVariant(r.kind, edges.DefinesBinding) {
+			bs = append(bs, r)
+		}
+	}
+	return bs
+}
+
+// callSite finds the innermost unclosed call enclosing the given position in
+// the current file contents. It returns the ref for the callee immediately
+// preceding the call's opening parenthesis, and the index of the argument
+// containing pos. If pos is not within a call, or the callee is unknown, nil
+// is returned.
+func (doc *document) callSite(pos lsp.Position) (*RefResolution, int) {
+	off := offsetOf(doc.newSrc, pos)
+	if off < 0 {
+		return nil, 0
+	}
+
+	var depth, arg int
+	for i := off - 1; i >= 0; i-- {
+		switch doc.newSrc[i] {
+		case ')', ']', '}':
+			depth++
+		case '[', '{':
+			if depth == 0 {
+				return nil, 0
+			}
+			depth--
+		case ';':
+			if depth == 0 {
+				return nil, 0
+			}
+		case ',':
+			if depth == 0 {
+				arg++
+			}
+		case '(':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			// Skip any whitespace between the callee and its arguments.
+			end := strings.TrimRight(doc.newSrc[:i], " \t\r\n")
+			if end == "" {
+				return nil, 0
+			}
+			callee := positionOf(doc.newSrc, len(end))
+			if ref := doc.xrefs(callee); ref != nil && ref.newRange.End == callee {
+				return ref, arg
+			}
+			return nil, 0
+		}
+	}
+	return nil, 0
+}
+
+// text returns the contents of the current file within the given range.
+func (doc *document) text(r lsp.Range) string {
+	start, end := offsetOf(doc.newSrc, r.Start), offsetOf(doc.newSrc, r.End)
+	if start < 0 || end < start {
+		return ""
+	}
+	return doc.newSrc[start:end]
+}
+
+// offsetOf returns the byte offset of pos within src, or -1 if pos is not
+// within src.
+func offsetOf(src string, pos lsp.Position) int {
+	var off int
+	for line := 0; line < pos.Line; line++ {
+		i := strings.IndexByte(src[off:], '\n')
+		if i < 0 {
+			return -1
+		}
+		off += i + 1
+	}
+	if off+pos.Character > len(src) {
+		return -1
+	}
+	return off + pos.Character
+}
+
+// positionOf returns the position of the given byte offset within src.
+func positionOf(src string, off int) lsp.Position {
+	line := strings.Count(src[:off], "\n")
+	return lsp.Position{
+		Line:      line,
+		Character: off - (strings.LastIndexByte(src[:off], '\n') + 1),
+	}
+}
+
 // updateSource accepts new file contents to be used for diffing when next
 // required This invalidates the previous diff
 func (doc *document) updateSource(newSrc string) {
@@ -257,10 +359,12 @@ diffLoop:
 // Kythe ticket
 type RefResolution struct {
 	ticket   string
+	kind     string     // the reference edge kind
 	def      string     // the target definition anchor ticket
 	markup   string     // a rendering of marked source
 	comment  string     // if available, a comment
 	lang     string     // a language label
+	params   []string   // rendered parameter names, if any
 	oldRange lsp.Range  // the range indexed
 	newRange *lsp.Range // the range after patching (if viable)
 }
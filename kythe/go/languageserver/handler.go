@@ -79,6 +79,48 @@ func ServerHandler(ls *Server) jsonrpc2.Handler {
 					return nil, err
 				}
 				ret, err = ls.TextDocumentHover(p)
+			case "textDocument/documentSymbol":
+				var p lsp.DocumentSymbolParams
+				if err := json.Unmarshal(*req.Params, &p); err != nil {
+					return nil, err
+				}
+				ret, err = ls.TextDocumentSymbol(p)
+			case "textDocument/signatureHelp":
+				var p lsp.TextDocumentPositionParams
+				if err := json.Unmarshal(*req.Params, &p); err != nil {
+					return nil, err
+				}
+				ret, err = ls.TextDocumentSignatureHelp(p)
+			case "textDocument/implementation":
+				var p lsp.TextDocumentPositionParams
+				if err := json.Unmarshal(*req.Params, &p); err != nil {
+					return nil, err
+				}
+				ret, err = ls.TextDocumentImplementation(p)
+			case "textDocument/prepareCallHierarchy":
+				var p CallHierarchyPrepareParams
+				if err := json.Unmarshal(*req.Params, &p); err != nil {
+					return nil, err
+				}
+				ret, err = ls.TextDocumentPrepareCallHierarchy(p)
+			case "callHierarchy/incomingCalls":
+				var p CallHierarchyIncomingCallsParams
+				if err := json.Unmarshal(*req.Params, &p); err != nil {
+					return nil, err
+				}
+				ret, err = ls.CallHierarchyIncomingCalls(p)
+			case "callHierarchy/outgoingCalls":
+				var p CallHierarchyOutgoingCallsParams
+				if err := json.Unmarshal(*req.Params, &p); err != nil {
+					return nil, err
+				}
+				ret, err = ls.CallHierarchyOutgoingCalls(p)
+			case "workspace/symbol":
+				var p lsp.WorkspaceSymbolParams
+				if err := json.Unmarshal(*req.Params, &p); err != nil {
+					return nil, err
+				}
+				ret, err = ls.WorkspaceSymbol(p)
 			case "shutdown":
 				log.Println("shutdown command received...")
 				shutdownIssued = true
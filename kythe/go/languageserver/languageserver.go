@@ -19,6 +19,13 @@
 // This server implements the following capabilities:
 // 		textDocumentSync (full)
 //		referenceProvider
+//		hoverProvider
+//		definitionProvider
+//		documentSymbolProvider
+//		workspaceSymbolProvider (if an identifiers service is available)
+//		signatureHelpProvider
+//		implementationProvider (if a graph service is available)
+//		callHierarchyProvider (if a graph service is available)
 package languageserver
 
 import (
@@ -26,13 +33,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
+	"unicode"
 
+	"kythe.io/kythe/go/services/graph"
 	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/identifiers"
 	"kythe.io/kythe/go/util/kytheuri"
 	"kythe.io/kythe/go/util/markedsource"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/schema/nodes"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
 	xpb "kythe.io/kythe/proto/xref_go_proto"
 
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
@@ -44,6 +60,15 @@ type Server struct {
 	docs       map[LocalFile]*document
 	XRefs      xrefs.Service
 	opts       *Options
+
+	// Graph is used to determine the kinds and containers of document
+	// symbols.  If nil, document symbols are reported without this detail,
+	// and the server does not provide implementations or call hierarchies.
+	Graph graph.Service
+
+	// Identifiers backs workspace symbol searches.  If nil, the server does
+	// not provide workspace symbols.
+	Identifiers identifiers.Service
 }
 
 // Options control optional behaviours of the language server implementation.
@@ -72,31 +97,62 @@ func (o *Options) newWorkspace(u lsp.DocumentURI) (Workspace, error) {
 }
 
 // NewServer constructs a server that delegates cross-reference requests to the
-// specified xrefs implementation. If opts == nil, sensible defaults are used.
+// specified xrefs implementation. If xrefs also implements graph.Service or
+// identifiers.Service (e.g. an api.Interface), it is used for the Graph and
+// Identifiers services as well. If opts == nil, sensible defaults are used.
 func NewServer(xrefs xrefs.Service, opts *Options) Server {
-	return Server{
+	ls := Server{
 		docs:       make(map[LocalFile]*document),
 		workspaces: nil,
 		XRefs:      xrefs,
 		opts:       opts,
 	}
+	if gs, ok := xrefs.(graph.Service); ok {
+		ls.Graph = gs
+	}
+	if id, ok := xrefs.(identifiers.Service); ok {
+		ls.Identifiers = id
+	}
+	return ls
+}
+
+// ServerCapabilities extends the capabilities known to the lsp package with
+// those added in later versions of the protocol.
+type ServerCapabilities struct {
+	lsp.ServerCapabilities
+
+	CallHierarchyProvider bool `json:"callHierarchyProvider,omitempty"`
+}
+
+// InitializeResult is the result of an initialize request.
+type InitializeResult struct {
+	Capabilities ServerCapabilities `json:"capabilities"`
 }
 
 // Initialize is invoked before any other methods, and allows the Server to
 // receive configuration info (such as the project root) and announce its capabilities.
-func (ls *Server) Initialize(params lsp.InitializeParams) (*lsp.InitializeResult, error) {
+func (ls *Server) Initialize(params lsp.InitializeParams) (*InitializeResult, error) {
 	log.Println("Server Initializing...")
 
 	fullSync := lsp.TDSKFull
-	return &lsp.InitializeResult{
-		Capabilities: lsp.ServerCapabilities{
-			TextDocumentSync: &lsp.TextDocumentSyncOptionsOrKind{
-				Kind:    &fullSync,
-				Options: nil,
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			ServerCapabilities: lsp.ServerCapabilities{
+				TextDocumentSync: &lsp.TextDocumentSyncOptionsOrKind{
+					Kind:    &fullSync,
+					Options: nil,
+				},
+				ReferencesProvider:      true,
+				HoverProvider:           true,
+				DefinitionProvider:      true,
+				DocumentSymbolProvider:  true,
+				WorkspaceSymbolProvider: ls.Identifiers != nil,
+				ImplementationProvider:  ls.Graph != nil,
+				SignatureHelpProvider: &lsp.SignatureHelpOptions{
+					TriggerCharacters: []string{"(", ","},
+				},
 			},
-			ReferencesProvider: true,
-			HoverProvider:      true,
-			DefinitionProvider: true,
+			CallHierarchyProvider: ls.Graph != nil,
 		},
 	}, nil
 }
@@ -148,6 +204,7 @@ func (ls *Server) TextDocumentDidOpen(params lsp.DidOpenTextDocumentParams) erro
 
 		refs = append(refs, &RefResolution{
 			ticket:   r.TargetTicket,
+			kind:     r.Kind,
 			def:      r.TargetDefinition,
 			oldRange: *rng,
 		})
@@ -336,6 +393,73 @@ func (ls *Server) TextDocumentDefinition(params lsp.TextDocumentPositionParams)
 	return ls.refLocs(local.Workspace, refs), nil
 }
 
+// TextDocumentImplementation uses a position in code to produce a list of
+// locations defining the implementations of the semantic node at that
+// position: the types that satisfy an interface, or the methods that override
+// a method. This requires a Graph service.
+//
+// NOTE: As per the lsp spec, if no error is returned, a non-nil location
+// slice must be returned
+func (ls *Server) TextDocumentImplementation(params lsp.TextDocumentPositionParams) ([]lsp.Location, error) {
+	if ls.Graph == nil {
+		return []lsp.Location{}, nil
+	}
+	local, err := ls.localFromURI(params.TextDocument.URI)
+	if err != nil {
+		return []lsp.Location{}, err
+	}
+
+	// If we don't have decorations we can't find implementations
+	doc, exists := ls.docs[local]
+	if !exists {
+		log.Printf("Implementations requested from unknown file %q", local)
+		return []lsp.Location{}, nil
+	}
+
+	ref := doc.xrefs(params.Position)
+	if ref == nil {
+		log.Printf("No ref found at %v", params.Position)
+		return []lsp.Location{}, nil
+	}
+
+	ctx := context.TODO()
+	reply, err := graph.AllEdges(ctx, ls.Graph, &gpb.EdgesRequest{
+		Ticket: []string{ref.ticket},
+		Kind:   []string{edges.Mirror(edges.Satisfies), edges.Mirror(edges.Overrides)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find implementations for ticket %q: %v", ref.ticket, err)
+	}
+
+	var impls []string
+	for _, grp := range reply.EdgeSets[ref.ticket].GetGroups() {
+		for _, e := range grp.Edge {
+			impls = append(impls, e.TargetTicket)
+		}
+	}
+	if len(impls) == 0 {
+		return []lsp.Location{}, nil
+	}
+	sort.Strings(impls)
+
+	xrefs, err := ls.XRefs.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:         impls,
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		PageSize:       int32(ls.opts.pageSize()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find definitions of implementations for ticket %q: %v", ref.ticket, err)
+	}
+
+	locs := []lsp.Location{}
+	for _, impl := range impls {
+		if set := xrefs.CrossReferences[impl]; set != nil {
+			locs = append(locs, ls.refLocs(local.Workspace, set)...)
+		}
+	}
+	return locs, nil
+}
+
 // TextDocumentHover produces a documentation string for the entity referenced at a given location
 func (ls *Server) TextDocumentHover(params lsp.TextDocumentPositionParams) (lsp.Hover, error) {
 	local, err := ls.localFromURI(params.TextDocument.URI)
@@ -358,28 +482,8 @@ func (ls *Server) TextDocumentHover(params lsp.TextDocumentPositionParams) (lsp.
 	}
 
 	// The first time we hover over a reference, generate hover documentation for it.
-	if ref.markup == "" {
-		docReply, err := ls.XRefs.Documentation(context.TODO(), &xpb.DocumentationRequest{
-			Ticket: []string{ref.ticket},
-		})
-		if err != nil {
-			log.Printf("Error fetching documentation for %q: %v", ref.ticket, err)
-			return lsp.Hover{}, nil
-		}
-
-		if len(docReply.Document) < 1 || docReply.Document[0].MarkedSource == nil {
-			log.Printf("No Documentation found for %q", ref.ticket)
-			return lsp.Hover{}, nil
-		}
-
-		kuri, err := kytheuri.Parse(docReply.Document[0].Ticket)
-		if err != nil {
-			log.Printf("Invalid ticket returned from documentation request: %v", err)
-			return lsp.Hover{}, nil
-		}
-		ref.markup = markedsource.Render(docReply.Document[0].MarkedSource)
-		ref.comment = stripComment(docReply.Document[0].GetText().GetRawText())
-		ref.lang = kuri.Language
+	if !ls.fetchDocumentation(ref) {
+		return lsp.Hover{}, nil
 	}
 
 	contents := []lsp.MarkedString{{
@@ -398,6 +502,313 @@ func (ls *Server) TextDocumentHover(params lsp.TextDocumentPositionParams) (lsp.
 	}, nil
 }
 
+// TextDocumentSymbol produces the symbols defined in a document, using the
+// binding definitions in its decorations. If a Graph service is available, it
+// is used to determine each symbol's kind and its container within the
+// document.
+//
+// NOTE: As per the lsp spec, if no error is returned, a non-nil symbol slice
+// must be returned
+func (ls *Server) TextDocumentSymbol(params lsp.DocumentSymbolParams) ([]lsp.SymbolInformation, error) {
+	local, err := ls.localFromURI(params.TextDocument.URI)
+	if err != nil {
+		return []lsp.SymbolInformation{}, err
+	}
+
+	// If we don't have decorations we can't find symbols
+	doc, exists := ls.docs[local]
+	if !exists {
+		log.Printf("Symbols requested from unknown file %q", local)
+		return []lsp.SymbolInformation{}, nil
+	}
+
+	bindings := doc.bindings()
+	names := make(map[string]string)
+	var tickets []string
+	for _, b := range bindings {
+		if _, ok := names[b.ticket]; !ok {
+			names[b.ticket] = doc.text(*b.newRange)
+			tickets = append(tickets, b.ticket)
+		}
+	}
+
+	info := ls.symbolInfo(tickets)
+	syms := []lsp.SymbolInformation{}
+	for _, b := range bindings {
+		n := info[b.ticket]
+		if n.skip {
+			continue
+		}
+		parent := info[n.parent]
+		syms = append(syms, lsp.SymbolInformation{
+			Name:          names[b.ticket],
+			Kind:          symbolKind(n.kind, n.subkind, parent.kind),
+			ContainerName: names[n.parent],
+			Location: lsp.Location{
+				URI:   local.URI(),
+				Range: *b.newRange,
+			},
+		})
+	}
+	return syms, nil
+}
+
+// symbolNode records the facts of a document symbol relevant to its
+// presentation.
+type symbolNode struct {
+	kind, subkind string
+	parent        string // the ticket of the node's childof parent, if any
+	skip          bool   // whether the node should not be reported
+}
+
+// symbolInfo fetches the kinds and parents of the given nodes from the Graph
+// service. Errors are logged and result in missing information.
+func (ls *Server) symbolInfo(tickets []string) map[string]symbolNode {
+	info := make(map[string]symbolNode)
+	if ls.Graph == nil || len(tickets) == 0 {
+		return info
+	}
+
+	ctx := context.TODO()
+	nodesReply, err := ls.Graph.Nodes(ctx, &gpb.NodesRequest{
+		Ticket: tickets,
+		Filter: []string{facts.NodeKind, facts.Subkind},
+	})
+	if err != nil {
+		log.Printf("Error fetching nodes for symbols: %v", err)
+		return info
+	}
+	for ticket, n := range nodesReply.Nodes {
+		sn := symbolNode{
+			kind:    string(n.Facts[facts.NodeKind]),
+			subkind: string(n.Facts[facts.Subkind]),
+		}
+		sn.skip = sn.subkind == nodes.Local || sn.subkind == nodes.LocalParameter
+		info[ticket] = sn
+	}
+
+	edgesReply, err := graph.AllEdges(ctx, ls.Graph, &gpb.EdgesRequest{
+		Ticket: tickets,
+		Kind:   []string{edges.ChildOf},
+	})
+	if err != nil {
+		log.Printf("Error fetching edges for symbols: %v", err)
+		return info
+	}
+	for ticket, set := range edgesReply.EdgeSets {
+		if es := set.Groups[edges.ChildOf].GetEdge(); len(es) > 0 {
+			sn := info[ticket]
+			sn.parent = es[0].TargetTicket
+			info[ticket] = sn
+		}
+	}
+	return info
+}
+
+// shortQueryMaxMatches is the maximum number of symbols requested for a
+// workspace symbol query too short for a substring search.
+const shortQueryMaxMatches = 20
+
+// WorkspaceSymbol searches the Identifiers service for symbols matching the
+// given query, reporting each at its binding definition.
+//
+// NOTE: As per the lsp spec, if no error is returned, a non-nil symbol slice
+// must be returned
+func (ls *Server) WorkspaceSymbol(params lsp.WorkspaceSymbolParams) ([]lsp.SymbolInformation, error) {
+	syms := []lsp.SymbolInformation{}
+	if ls.Identifiers == nil || params.Query == "" {
+		return syms, nil
+	}
+
+	// Substring searches require a full trigram, so fall back to prefix
+	// searches for short queries.  These match many symbols, so only a few
+	// are requested.
+	mode := ipb.FindRequest_SUBSTRING
+	maxMatches := int32(params.Limit)
+	if len(params.Query) < 3 {
+		mode = ipb.FindRequest_PREFIX
+		if maxMatches <= 0 || maxMatches > shortQueryMaxMatches {
+			maxMatches = shortQueryMaxMatches
+		}
+	}
+	ctx := context.TODO()
+	found, err := ls.Identifiers.Find(ctx, &ipb.FindRequest{
+		Identifier:      params.Query,
+		MatchMode:       mode,
+		CaseInsensitive: true,
+		MaxMatches:      maxMatches,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find symbols matching %q: %v", params.Query, err)
+	} else if len(found.Matches) == 0 {
+		return syms, nil
+	}
+
+	var tickets []string
+	for _, m := range found.Matches {
+		tickets = append(tickets, m.Ticket)
+	}
+	xrefs, err := ls.XRefs.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:         tickets,
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		PageSize:       int32(ls.opts.pageSize()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find definitions for symbols matching %q: %v", params.Query, err)
+	}
+
+	for _, m := range found.Matches {
+		set := xrefs.CrossReferences[m.Ticket]
+		if set == nil {
+			continue
+		}
+		for _, def := range set.Definition {
+			loc := ls.anchorToWorkspaceLoc(def.Anchor)
+			if loc == nil {
+				continue
+			}
+			syms = append(syms, lsp.SymbolInformation{
+				Name:          m.BaseName,
+				Kind:          symbolKind(m.NodeKind, m.NodeSubkind, ""),
+				ContainerName: containerName(m.QualifiedName, m.BaseName),
+				Location:      ls.locationInNewSource(*loc),
+			})
+			break
+		}
+	}
+	return syms, nil
+}
+
+// TextDocumentSignatureHelp produces the signature of the function being
+// called at a given location, rendered from the callee's MarkedSource.
+func (ls *Server) TextDocumentSignatureHelp(params lsp.TextDocumentPositionParams) (*lsp.SignatureHelp, error) {
+	local, err := ls.localFromURI(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	// If we don't have decorations we can't find the callee
+	doc, exists := ls.docs[local]
+	if !exists {
+		log.Printf("Signature help requested from unknown file %q", local)
+		return nil, nil
+	}
+
+	ref, arg := doc.callSite(params.Position)
+	if ref == nil {
+		log.Printf("No call found at %v", params.Position)
+		return nil, nil
+	} else if !ls.fetchDocumentation(ref) {
+		return nil, nil
+	}
+
+	sig := lsp.SignatureInformation{
+		Label:         ref.markup,
+		Documentation: ref.comment,
+	}
+	for _, p := range ref.params {
+		sig.Parameters = append(sig.Parameters, lsp.ParameterInformation{Label: p})
+	}
+	if n := len(sig.Parameters); arg >= n && n > 0 {
+		// Extra arguments belong to a trailing variadic parameter.
+		arg = n - 1
+	}
+	return &lsp.SignatureHelp{
+		Signatures:      []lsp.SignatureInformation{sig},
+		ActiveParameter: arg,
+	}, nil
+}
+
+// fetchDocumentation populates the rendered documentation of ref if it has
+// not already been fetched, and reports whether any documentation exists.
+func (ls *Server) fetchDocumentation(ref *RefResolution) bool {
+	if ref.markup != "" {
+		return true
+	}
+
+	docReply, err := ls.XRefs.Documentation(context.TODO(), &xpb.DocumentationRequest{
+		Ticket: []string{ref.ticket},
+	})
+	if err != nil {
+		log.Printf("Error fetching documentation for %q: %v", ref.ticket, err)
+		return false
+	}
+
+	if len(docReply.Document) < 1 || docReply.Document[0].MarkedSource == nil {
+		log.Printf("No Documentation found for %q", ref.ticket)
+		return false
+	}
+
+	kuri, err := kytheuri.Parse(docReply.Document[0].Ticket)
+	if err != nil {
+		log.Printf("Invalid ticket returned from documentation request: %v", err)
+		return false
+	}
+	ms := docReply.Document[0].MarkedSource
+	ref.markup = markedsource.Render(ms)
+	ref.params = markedsource.RenderSimpleParams(ms)
+	ref.comment = stripComment(docReply.Document[0].GetText().GetRawText())
+	ref.lang = kuri.Language
+	return true
+}
+
+// symbolKind maps a Kythe node kind and subkind to the closest LSP symbol
+// kind. The kind of the node's parent, if known, distinguishes methods from
+// functions.
+func symbolKind(kind, subkind, parentKind string) lsp.SymbolKind {
+	switch kind {
+	case nodes.Package:
+		return lsp.SKPackage
+	case nodes.File:
+		return lsp.SKFile
+	case nodes.Function:
+		if subkind == "constructor" {
+			return lsp.SKConstructor
+		} else if parentKind == nodes.Record || parentKind == nodes.Interface {
+			return lsp.SKMethod
+		}
+		return lsp.SKFunction
+	case nodes.Record, nodes.TAlias:
+		if subkind == nodes.Enum || subkind == nodes.EnumClass {
+			return lsp.SKEnum
+		}
+		return lsp.SKClass
+	case nodes.Interface:
+		return lsp.SKInterface
+	case nodes.Constant:
+		return lsp.SKConstant
+	case nodes.Variable:
+		if subkind == nodes.Field {
+			return lsp.SKField
+		}
+		return lsp.SKVariable
+	default:
+		return lsp.SKVariable
+	}
+}
+
+// containerName returns the portion of a qualified name that precedes its base
+// name, without any trailing separator.
+func containerName(qname, baseName string) string {
+	if !strings.HasSuffix(qname, baseName) {
+		return ""
+	}
+	return strings.TrimRightFunc(strings.TrimSuffix(qname, baseName), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
+
+// anchorToWorkspaceLoc maps an anchor into the first known workspace
+// containing its file, or returns nil if there is none.
+func (ls *Server) anchorToWorkspaceLoc(a *xpb.Anchor) *lsp.Location {
+	for _, w := range ls.workspaces {
+		if l := ls.anchorToLoc(w, a); l != nil {
+			return l
+		}
+	}
+	return nil
+}
+
 func (ls *Server) localFromURI(u lsp.DocumentURI) (LocalFile, error) {
 	for _, w := range ls.workspaces {
 		local, err := w.LocalFromURI(u)
@@ -25,6 +25,7 @@ import (
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
 	gpb "kythe.io/kythe/proto/graph_go_proto"
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
 	xpb "kythe.io/kythe/proto/xref_go_proto"
 
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
@@ -43,9 +44,13 @@ type mockDoc struct {
 	resp   xpb.DocumentationReply
 }
 type MockClient struct {
-	decRsp []mockDec
-	refRsp []mockRef
-	docRsp []mockDoc
+	decRsp  []mockDec
+	refRsp  []mockRef
+	docRsp  []mockDoc
+	nodeRsp *gpb.NodesReply
+	edgeRsp *gpb.EdgesReply
+	findRsp *ipb.FindReply
+	findReq *[]*ipb.FindRequest // if non-nil, records each FindRequest
 }
 
 func (c MockClient) Decorations(_ context.Context, d *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
@@ -73,10 +78,25 @@ func (c MockClient) Documentation(_ context.Context, x *xpb.DocumentationRequest
 	return nil, fmt.Errorf("no CrossReferences Found")
 }
 func (c MockClient) Edges(_ context.Context, x *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
-	return nil, fmt.Errorf("not Implemented")
+	if c.edgeRsp == nil {
+		return nil, fmt.Errorf("not Implemented")
+	}
+	return c.edgeRsp, nil
 }
 func (c MockClient) Nodes(_ context.Context, x *gpb.NodesRequest) (*gpb.NodesReply, error) {
-	return nil, fmt.Errorf("not Implemented")
+	if c.nodeRsp == nil {
+		return nil, fmt.Errorf("not Implemented")
+	}
+	return c.nodeRsp, nil
+}
+func (c MockClient) Find(_ context.Context, x *ipb.FindRequest) (*ipb.FindReply, error) {
+	if c.findReq != nil {
+		*c.findReq = append(*c.findReq, x)
+	}
+	if c.findRsp == nil {
+		return nil, fmt.Errorf("not Implemented")
+	}
+	return c.findRsp, nil
 }
 func TestReferences(t *testing.T) {
 	const sourceText = "hi\nthere\nhi\nend"
@@ -294,3 +314,422 @@ func TestReferences(t *testing.T) {
 		t.Errorf("Hover results:\ngot  %+v\nwant %+v", hovExpected, hover)
 	}
 }
+
+func binding(ticket string, line, start, end int32) *xpb.DecorationsReply_Reference {
+	return &xpb.DecorationsReply_Reference{
+		TargetTicket: ticket,
+		Kind:         "/kythe/edge/defines/binding",
+		Span: &cpb.Span{
+			Start: &cpb.Point{LineNumber: line, ColumnOffset: start},
+			End:   &cpb.Point{LineNumber: line, ColumnOffset: end}},
+	}
+}
+
+func node(kind, subkind string) *cpb.NodeInfo {
+	n := &cpb.NodeInfo{Facts: map[string][]byte{"/kythe/node/kind": []byte(kind)}}
+	if subkind != "" {
+		n.Facts["/kythe/subkind"] = []byte(subkind)
+	}
+	return n
+}
+
+func TestSymbols(t *testing.T) {
+	const sourceText = "type T struct {\n  f int\n}\nfunc g(a, b int) {}\nfunc h() { g(1, 2) }"
+	const (
+		file   = "kythe://corpus?path=file.txt"
+		ticket = "kythe://corpus?lang=go?path=file.txt#"
+	)
+	var finds []*ipb.FindRequest
+	c := MockClient{
+		decRsp: []mockDec{{
+			ticket: file,
+			resp: xpb.DecorationsReply{
+				SourceText: []byte(sourceText),
+				Reference: []*xpb.DecorationsReply_Reference{
+					binding(ticket+"T", 1, 5, 6),
+					binding(ticket+"f", 2, 2, 3),
+					binding(ticket+"g", 4, 5, 6),
+					binding(ticket+"a", 4, 7, 8),
+					binding(ticket+"b", 4, 10, 11),
+					binding(ticket+"h", 5, 5, 6),
+					{
+						TargetTicket: ticket + "g",
+						Kind:         "/kythe/edge/ref",
+						Span: &cpb.Span{
+							Start: &cpb.Point{LineNumber: 5, ColumnOffset: 11},
+							End:   &cpb.Point{LineNumber: 5, ColumnOffset: 12}},
+					},
+				}}}},
+		refRsp: []mockRef{{
+			ticket: ticket + "g",
+			resp: xpb.CrossReferencesReply{
+				CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+					ticket + "g": {
+						Ticket: ticket + "g",
+						Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{
+							Anchor: &xpb.Anchor{
+								Ticket: file + "#g",
+								Parent: file,
+								Span: &cpb.Span{
+									Start: &cpb.Point{LineNumber: 4, ColumnOffset: 5},
+									End:   &cpb.Point{LineNumber: 4, ColumnOffset: 6},
+								}}}}}}}}},
+		docRsp: []mockDoc{{
+			ticket: ticket + "g",
+			resp: xpb.DocumentationReply{
+				Document: []*xpb.DocumentationReply_Document{{
+					Ticket: ticket + "g",
+					Text:   &xpb.Printable{RawText: "g does [nothing]"},
+					MarkedSource: &cpb.MarkedSource{
+						Child: []*cpb.MarkedSource{{
+							PreText: "func ",
+						}, {
+							Kind:    cpb.MarkedSource_IDENTIFIER,
+							PreText: "g",
+						}, {
+							Kind:          cpb.MarkedSource_PARAMETER,
+							PreText:       "(",
+							PostChildText: ", ",
+							PostText:      ")",
+							Child: []*cpb.MarkedSource{{
+								Kind:    cpb.MarkedSource_IDENTIFIER,
+								PreText: "a",
+							}, {
+								Kind:    cpb.MarkedSource_IDENTIFIER,
+								PreText: "b",
+							}}}}}}}}}},
+		nodeRsp: &gpb.NodesReply{
+			Nodes: map[string]*cpb.NodeInfo{
+				ticket + "T": node("record", "struct"),
+				ticket + "f": node("variable", "field"),
+				ticket + "g": node("function", ""),
+				ticket + "a": node("variable", "local/parameter"),
+				ticket + "b": node("variable", "local/parameter"),
+				ticket + "h": node("function", ""),
+			}},
+		edgeRsp: &gpb.EdgesReply{
+			EdgeSets: map[string]*gpb.EdgeSet{
+				ticket + "f": {
+					Groups: map[string]*gpb.EdgeSet_Group{
+						"/kythe/edge/childof": {
+							Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: ticket + "T"}},
+						}}}}},
+		findReq: &finds,
+		findRsp: &ipb.FindReply{
+			Matches: []*ipb.FindReply_Match{{
+				Ticket:        ticket + "g",
+				NodeKind:      "function",
+				BaseName:      "g",
+				QualifiedName: "pkg.g",
+			}}},
+	}
+
+	srv := NewServer(c, &Options{
+		NewWorkspace: func(_ lsp.DocumentURI) (Workspace, error) {
+			return NewSettingsWorkspace(Settings{
+				Root: "/root/dir/",
+				Mappings: []MappingConfig{{
+					Local: ":path*",
+					VName: VNameConfig{
+						Path:   ":path*",
+						Corpus: "corpus",
+					}},
+				},
+			})
+		},
+	})
+
+	init, err := srv.Initialize(lsp.InitializeParams{})
+	if err != nil {
+		t.Fatalf("Unexpected error initializing: %v", err)
+	} else if caps := init.Capabilities; !caps.DocumentSymbolProvider || !caps.WorkspaceSymbolProvider || caps.SignatureHelpProvider == nil {
+		t.Errorf("Missing symbol capabilities: %+v", caps)
+	}
+
+	const u = "file:///root/dir/file.txt"
+	if err := srv.TextDocumentDidOpen(lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:  u,
+			Text: sourceText,
+		},
+	}); err != nil {
+		t.Fatalf("Unexpected error opening document (%s): %v", u, err)
+	}
+
+	// Shift the file down a line to ensure symbols are patched.
+	if err := srv.TextDocumentDidChange(lsp.DidChangeTextDocumentParams{
+		TextDocument: lsp.VersionedTextDocumentIdentifier{
+			TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: u},
+		},
+		ContentChanges: []lsp.TextDocumentContentChangeEvent{{
+			Text: "\n" + sourceText,
+		}},
+	}); err != nil {
+		t.Fatalf("Unexpected error saving changes to document (%s): %v", u, err)
+	}
+
+	loc := func(line, start, end int) lsp.Location {
+		return lsp.Location{
+			URI: u,
+			Range: lsp.Range{
+				Start: lsp.Position{Line: line, Character: start},
+				End:   lsp.Position{Line: line, Character: end},
+			},
+		}
+	}
+
+	syms, err := srv.TextDocumentSymbol(lsp.DocumentSymbolParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: u},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error finding document symbols: %v", err)
+	}
+	expected := []lsp.SymbolInformation{
+		{Name: "T", Kind: lsp.SKClass, Location: loc(1, 5, 6)},
+		{Name: "f", Kind: lsp.SKField, Location: loc(2, 2, 3), ContainerName: "T"},
+		{Name: "g", Kind: lsp.SKFunction, Location: loc(4, 5, 6)},
+		{Name: "h", Kind: lsp.SKFunction, Location: loc(5, 5, 6)},
+	}
+	if err := testutil.DeepEqual(expected, syms); err != nil {
+		t.Errorf("Incorrect document symbols returned: %v", err)
+	}
+
+	syms, err = srv.WorkspaceSymbol(lsp.WorkspaceSymbolParams{Query: "g"})
+	if err != nil {
+		t.Errorf("Unexpected error finding workspace symbols: %v", err)
+	}
+	expected = []lsp.SymbolInformation{
+		{Name: "g", Kind: lsp.SKFunction, Location: loc(4, 5, 6), ContainerName: "pkg"},
+	}
+	if err := testutil.DeepEqual(expected, syms); err != nil {
+		t.Errorf("Incorrect workspace symbols returned: %v", err)
+	}
+	if n := len(finds); n != 1 {
+		t.Errorf("Expected 1 FindRequest; found %d", n)
+	} else if req := finds[0]; req.MatchMode != ipb.FindRequest_PREFIX || req.MaxMatches != shortQueryMaxMatches {
+		t.Errorf("Unexpected FindRequest for short query: %v", req)
+	}
+
+	sig, err := srv.TextDocumentSignatureHelp(lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: u},
+		Position:     lsp.Position{Line: 5, Character: 16},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error fetching signature help: %v", err)
+	}
+	sigExpected := &lsp.SignatureHelp{
+		Signatures: []lsp.SignatureInformation{{
+			Label:         "func g(a, b)",
+			Documentation: "g does nothing",
+			Parameters:    []lsp.ParameterInformation{{Label: "a"}, {Label: "b"}},
+		}},
+		ActiveParameter: 1,
+	}
+	if err := testutil.DeepEqual(sigExpected, sig); err != nil {
+		t.Errorf("Incorrect signature help returned: %v", err)
+	}
+
+	// Outside of a call there is no signature.
+	if sig, err := srv.TextDocumentSignatureHelp(lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: u},
+		Position:     lsp.Position{Line: 5, Character: 9},
+	}); err != nil || sig != nil {
+		t.Errorf("Unexpected signature help outside of a call: %+v (err: %v)", sig, err)
+	}
+}
+
+func TestCallHierarchy(t *testing.T) {
+	const sourceText = "func f() { g() }\nfunc g() {}\ntype I interface{ M() }\ntype T struct{}"
+	const (
+		file   = "kythe://corpus?path=file.txt"
+		ticket = "kythe://corpus?lang=go?path=file.txt#"
+	)
+	anchor := func(name string, line, start, end int32) *xpb.Anchor {
+		return &xpb.Anchor{
+			Ticket: file + "#" + name,
+			Parent: file,
+			Text:   name,
+			Span: &cpb.Span{
+				Start: &cpb.Point{LineNumber: line, ColumnOffset: start},
+				End:   &cpb.Point{LineNumber: line, ColumnOffset: end},
+			},
+		}
+	}
+	ident := func(name string) *cpb.MarkedSource {
+		return &cpb.MarkedSource{Kind: cpb.MarkedSource_IDENTIFIER, PreText: name}
+	}
+	c := MockClient{
+		decRsp: []mockDec{{
+			ticket: file,
+			resp: xpb.DecorationsReply{
+				SourceText: []byte(sourceText),
+				Reference: []*xpb.DecorationsReply_Reference{
+					binding(ticket+"f", 1, 5, 6),
+					{
+						TargetTicket:  ticket + "g",
+						Kind:          "/kythe/edge/ref/call",
+						SemanticScope: ticket + "f",
+						Span: &cpb.Span{
+							Start: &cpb.Point{LineNumber: 1, ColumnOffset: 11},
+							End:   &cpb.Point{LineNumber: 1, ColumnOffset: 14}},
+					},
+					binding(ticket+"g", 2, 5, 6),
+					binding(ticket+"I", 3, 5, 6),
+					binding(ticket+"T", 4, 5, 6),
+				}}}},
+		refRsp: []mockRef{{
+			ticket: ticket + "g",
+			resp: xpb.CrossReferencesReply{
+				CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+					ticket + "g": {
+						Ticket:       ticket + "g",
+						MarkedSource: ident("g"),
+						Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{
+							Anchor: anchor("g", 2, 5, 6),
+						}},
+						Caller: []*xpb.CrossReferencesReply_RelatedAnchor{{
+							Ticket:       ticket + "f",
+							Anchor:       anchor("f", 1, 5, 6),
+							MarkedSource: ident("f"),
+							Site:         []*xpb.Anchor{anchor("g()", 1, 11, 14)},
+						}}}}}}, {
+			ticket: ticket + "T",
+			resp: xpb.CrossReferencesReply{
+				CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+					ticket + "T": {
+						Ticket: ticket + "T",
+						Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{
+							Anchor: anchor("T", 4, 5, 6),
+						}}}}}}},
+		nodeRsp: &gpb.NodesReply{
+			Nodes: map[string]*cpb.NodeInfo{
+				ticket + "f": node("function", ""),
+				ticket + "g": node("function", ""),
+				ticket + "T": node("record", "struct"),
+			}},
+		edgeRsp: &gpb.EdgesReply{
+			EdgeSets: map[string]*gpb.EdgeSet{
+				ticket + "I": {
+					Groups: map[string]*gpb.EdgeSet_Group{
+						"%/kythe/edge/satisfies": {
+							Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: ticket + "T"}},
+						}}},
+				// Report g as a method of T to check that item kinds are
+				// taken from the graph.
+				ticket + "g": {
+					Groups: map[string]*gpb.EdgeSet_Group{
+						"/kythe/edge/childof": {
+							Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: ticket + "T"}},
+						}}}}},
+	}
+
+	srv := NewServer(c, &Options{
+		NewWorkspace: func(_ lsp.DocumentURI) (Workspace, error) {
+			return NewSettingsWorkspace(Settings{
+				Root: "/root/dir/",
+				Mappings: []MappingConfig{{
+					Local: ":path*",
+					VName: VNameConfig{
+						Path:   ":path*",
+						Corpus: "corpus",
+					}},
+				},
+			})
+		},
+	})
+
+	init, err := srv.Initialize(lsp.InitializeParams{})
+	if err != nil {
+		t.Fatalf("Unexpected error initializing: %v", err)
+	} else if caps := init.Capabilities; !caps.CallHierarchyProvider || !caps.ImplementationProvider {
+		t.Errorf("Missing call hierarchy capabilities: %+v", caps)
+	}
+
+	const u = "file:///root/dir/file.txt"
+	if err := srv.TextDocumentDidOpen(lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:  u,
+			Text: sourceText,
+		},
+	}); err != nil {
+		t.Fatalf("Unexpected error opening document (%s): %v", u, err)
+	}
+
+	rng := func(line, start, end int) lsp.Range {
+		return lsp.Range{
+			Start: lsp.Position{Line: line, Character: start},
+			End:   lsp.Position{Line: line, Character: end},
+		}
+	}
+	itemF := CallHierarchyItem{
+		Name:           "f",
+		Kind:           lsp.SKFunction,
+		Detail:         "f",
+		URI:            u,
+		Range:          rng(0, 5, 6),
+		SelectionRange: rng(0, 5, 6),
+		Data:           ticket + "f",
+	}
+	itemG := CallHierarchyItem{
+		Name:           "g",
+		Kind:           lsp.SKMethod,
+		Detail:         "g",
+		URI:            u,
+		Range:          rng(1, 5, 6),
+		SelectionRange: rng(1, 5, 6),
+		Data:           ticket + "g",
+	}
+
+	items, err := srv.TextDocumentPrepareCallHierarchy(CallHierarchyPrepareParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: u},
+			Position:     lsp.Position{Line: 1, Character: 5},
+		},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error preparing call hierarchy: %v", err)
+	}
+	if err := testutil.DeepEqual([]CallHierarchyItem{itemG}, items); err != nil {
+		t.Errorf("Incorrect call hierarchy items returned: %v", err)
+	}
+
+	incoming, err := srv.CallHierarchyIncomingCalls(CallHierarchyIncomingCallsParams{Item: itemG})
+	if err != nil {
+		t.Errorf("Unexpected error finding incoming calls: %v", err)
+	}
+	inExpected := []CallHierarchyIncomingCall{{
+		From:       itemF,
+		FromRanges: []lsp.Range{rng(0, 11, 14)},
+	}}
+	if err := testutil.DeepEqual(inExpected, incoming); err != nil {
+		t.Errorf("Incorrect incoming calls returned: %v", err)
+	}
+
+	outgoing, err := srv.CallHierarchyOutgoingCalls(CallHierarchyOutgoingCallsParams{Item: itemF})
+	if err != nil {
+		t.Errorf("Unexpected error finding outgoing calls: %v", err)
+	}
+	outExpected := []CallHierarchyOutgoingCall{{
+		To:         itemG,
+		FromRanges: []lsp.Range{rng(0, 11, 14)},
+	}}
+	if err := testutil.DeepEqual(outExpected, outgoing); err != nil {
+		t.Errorf("Incorrect outgoing calls returned: %v", err)
+	}
+
+	// g makes no calls.
+	if outgoing, err := srv.CallHierarchyOutgoingCalls(CallHierarchyOutgoingCallsParams{Item: itemG}); err != nil || len(outgoing) != 0 {
+		t.Errorf("Unexpected outgoing calls from g: %+v (err: %v)", outgoing, err)
+	}
+
+	impls, err := srv.TextDocumentImplementation(lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: u},
+		Position:     lsp.Position{Line: 2, Character: 5},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error finding implementations: %v", err)
+	}
+	if err := testutil.DeepEqual([]lsp.Location{{URI: u, Range: rng(3, 5, 6)}}, impls); err != nil {
+		t.Errorf("Incorrect implementations returned: %v", err)
+	}
+}
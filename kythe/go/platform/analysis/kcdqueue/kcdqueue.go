@@ -0,0 +1,134 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kcdqueue implements a driver.Queue that reads compilations from a
+// compilation database.
+package kcdqueue
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"kythe.io/kythe/go/platform/analysis/driver"
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+)
+
+// Options control the behaviour of a Queue.
+type Options struct {
+	// The revision marker to attribute to each compilation.
+	Revision string
+}
+
+func (o *Options) revision() string {
+	if o == nil {
+		return ""
+	}
+	return o.Revision
+}
+
+// A Queue is a driver.Queue that delivers each Kythe compilation in a
+// compilation database matching a filter.  The Queue also implements the
+// analysis.Fetcher interface, reading files from the same database.
+//
+// The matching units are found on the first call to Next.  Units stored in
+// formats other than kythe.Format are skipped.
+type Queue struct {
+	ctx      context.Context
+	db       kcd.Reader
+	filter   *kcd.FindFilter
+	revision string
+
+	found   bool     // whether the filter has been applied
+	digests []string // unit digests waiting to be delivered
+}
+
+// New returns a new Queue over the compilations in db matching filter.  If
+// filter == nil, all compilations are matched.  The ctx governs calls to the
+// Fetch method.
+func New(ctx context.Context, db kcd.Reader, filter *kcd.FindFilter, opts *Options) *Queue {
+	if filter == nil {
+		filter = new(kcd.FindFilter)
+	}
+	return &Queue{
+		ctx:      ctx,
+		db:       db,
+		filter:   filter,
+		revision: opts.revision(),
+	}
+}
+
+// Next implements the driver.Queue interface.
+func (q *Queue) Next(ctx context.Context, f driver.CompilationFunc) error {
+	if !q.found {
+		if err := q.db.Find(ctx, q.filter, func(digest string) error {
+			q.digests = append(q.digests, digest)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("finding compilations: %v", err)
+		}
+		q.found = true
+	}
+
+	for len(q.digests) != 0 {
+		digest := q.digests[0]
+		q.digests = q.digests[1:]
+
+		var unit *apb.CompilationUnit
+		if err := q.db.Units(ctx, []string{digest}, func(_, key string, data []byte) error {
+			if key != kythe.Format {
+				return nil // not a Kythe compilation; skip it
+			}
+			var pb apb.CompilationUnit
+			if err := proto.Unmarshal(data, &pb); err != nil {
+				return fmt.Errorf("decoding compilation %q: %v", digest, err)
+			}
+			unit = &pb
+			return nil
+		}); err != nil {
+			return err
+		} else if unit == nil {
+			continue
+		}
+		return f(ctx, driver.Compilation{
+			Unit:       unit,
+			Revision:   q.revision,
+			UnitDigest: digest,
+		})
+	}
+	return driver.ErrEndOfQueue
+}
+
+// Fetch implements the analysis.Fetcher interface by reading the file with the
+// given digest from the database.  The path is ignored.
+func (q *Queue) Fetch(_, digest string) ([]byte, error) {
+	var data []byte
+	var found bool
+	if err := q.db.Files(q.ctx, []string{digest}, func(_ string, bits []byte) error {
+		data, found = bits, true
+		return nil
+	}); err != nil {
+		return nil, err
+	} else if !found {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
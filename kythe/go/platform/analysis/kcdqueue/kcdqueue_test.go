@@ -0,0 +1,106 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kcdqueue
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/platform/analysis"
+	"kythe.io/kythe/go/platform/analysis/driver"
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+	"kythe.io/kythe/go/platform/kcd/memdb"
+
+	"github.com/google/go-cmp/cmp"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// fetchAll is a CompilationAnalyzer that fetches the required inputs of each
+// compilation, and records their contents.
+type fetchAll struct {
+	f        analysis.Fetcher
+	contents []string
+	revs     []string
+}
+
+func (a *fetchAll) Analyze(_ context.Context, req *apb.AnalysisRequest, _ analysis.OutputFunc) error {
+	a.revs = append(a.revs, req.Revision)
+	for _, ri := range req.Compilation.RequiredInput {
+		data, err := a.f.Fetch(ri.Info.Path, ri.Info.Digest)
+		if err != nil {
+			return err
+		}
+		a.contents = append(a.contents, string(data))
+	}
+	return nil
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	db := new(memdb.DB)
+
+	files := make(map[string]string) // :: content → digest
+	for _, s := range []string{"alpha", "bravo", "charlie"} {
+		digest, err := db.WriteFile(ctx, strings.NewReader(s))
+		if err != nil {
+			t.Fatalf("WriteFile(%q): %v", s, err)
+		}
+		files[s] = digest
+	}
+	writeUnit := func(formatKey, rev, lang string, inputs ...string) {
+		t.Helper()
+		cu := &apb.CompilationUnit{VName: &spb.VName{Language: lang, Signature: rev}}
+		for _, s := range inputs {
+			cu.RequiredInput = append(cu.RequiredInput, &apb.CompilationUnit_FileInput{
+				Info: &apb.FileInfo{Path: s, Digest: files[s]},
+			})
+		}
+		if _, err := db.WriteUnit(ctx, rev, "corpus", formatKey, kythe.Unit{Proto: cu}); err != nil {
+			t.Fatalf("WriteUnit: %v", err)
+		}
+	}
+	writeUnit(kythe.Format, "r1", "go", "alpha", "bravo")
+	writeUnit(kythe.Format, "r1", "java", "charlie")
+	writeUnit(kythe.Format, "r2", "go", "charlie")
+	writeUnit("other", "r1", "go", "charlie")
+
+	q := New(ctx, db, &kcd.FindFilter{
+		Revisions: []string{"r1"},
+		Languages: []string{"go"},
+	}, &Options{Revision: "marker"})
+	a := &fetchAll{f: q}
+	if err := (&driver.Driver{Analyzer: a}).Run(ctx, q); err != nil {
+		t.Fatalf("Driver failed: %v", err)
+	}
+	sort.Strings(a.contents)
+	if diff := cmp.Diff([]string{"alpha", "bravo"}, a.contents); diff != "" {
+		t.Errorf("Fetched inputs: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"marker"}, a.revs); diff != "" {
+		t.Errorf("Revisions: (-want +got)\n%s", diff)
+	}
+
+	if data, err := q.Fetch("", "nonesuch"); err != os.ErrNotExist {
+		t.Errorf("Fetch(nonesuch): got (%q, %v), want %v", data, err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"kythe.io/kythe/go/platform/analysis"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// outputBatch is the maximum number of entries sent in one output request.
+const outputBatch = 1024
+
+// A ReplyError is an error reported by the proxy in reply to a request.
+type ReplyError string
+
+func (e ReplyError) Error() string { return string(e) }
+
+// A Client implements the indexer side of the proxy protocol, reading replies
+// from in and writing requests to out.  A *Client implements the
+// analysis.Fetcher interface, by issuing file requests to the proxy.
+type Client struct {
+	in  *json.Decoder // replies from the proxy
+	out *json.Encoder // requests to the proxy
+}
+
+// NewClient returns a client that reads replies from in and writes requests
+// to out.
+func NewClient(in io.Reader, out io.Writer) *Client {
+	return &Client{
+		in:  json.NewDecoder(in),
+		out: json.NewEncoder(out),
+	}
+}
+
+// call sends a request of the given type and args to the proxy, and decodes
+// the args of its reply into rsp, if rsp != nil.  If the proxy replies with an
+// error, call returns a ReplyError.
+func (c *Client) call(req string, args, rsp interface{}) error {
+	msg := struct {
+		Type string      `json:"req"`
+		Args interface{} `json:"args,omitempty"`
+	}{Type: req, Args: args}
+	if err := c.out.Encode(&msg); err != nil {
+		return err
+	}
+	var reply struct {
+		Status string          `json:"rsp"`
+		Args   json.RawMessage `json:"args,omitempty"`
+	}
+	if err := c.in.Decode(&reply); err != nil {
+		return err
+	}
+	if reply.Status != "ok" {
+		var msg string
+		if err := json.Unmarshal(reply.Args, &msg); err != nil {
+			msg = string(reply.Args)
+		}
+		return ReplyError(msg)
+	} else if rsp != nil && reply.Args != nil {
+		return json.Unmarshal(reply.Args, rsp)
+	}
+	return nil
+}
+
+// Analysis requests a new analysis from the proxy.
+func (c *Client) Analysis() (*apb.AnalysisRequest, error) {
+	var u unit
+	if err := c.call("analysis", nil, &u); err != nil {
+		return nil, err
+	}
+	return &apb.AnalysisRequest{
+		Compilation:     u.Unit,
+		Revision:        u.Revision,
+		FileDataService: u.FileDataService,
+	}, nil
+}
+
+// Output sends entries for the current analysis to the proxy.  If the proxy
+// reports an error, the analysis is abandoned.
+func (c *Client) Output(entries ...*spb.Entry) error {
+	return c.call("output", entries, nil)
+}
+
+// Done reports to the proxy that the current analysis is complete, and
+// whether it succeeded according to err.
+func (c *Client) Done(err error) error {
+	stat := status{OK: err == nil}
+	if err != nil {
+		stat.Message = err.Error()
+	}
+	return c.call("done", &stat, nil)
+}
+
+// Fetch implements the analysis.Fetcher interface by requesting the file with
+// the given path and digest from the proxy.
+func (c *Client) Fetch(path, digest string) ([]byte, error) {
+	var f file
+	if err := c.call("file", &file{Path: path, Digest: digest}, &f); err != nil {
+		return nil, err
+	}
+	return f.Content, nil
+}
+
+// Run requests analyses from the proxy and passes each to a in turn, sending
+// the entries it outputs back to the proxy.  The analyzer should fetch its
+// inputs via c.  Run returns nil when the proxy closes its end of the
+// connection; if the proxy refuses a request for analysis, Run returns the
+// resulting ReplyError.
+func (c *Client) Run(ctx context.Context, a analysis.CompilationAnalyzer) error {
+	for {
+		req, err := c.Analysis()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var batch []*spb.Entry
+		var abandoned bool // whether the proxy rejected an output
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			err := c.Output(batch...)
+			batch = batch[:0]
+			if _, ok := err.(ReplyError); ok {
+				abandoned = true
+			}
+			return err
+		}
+		err = a.Analyze(ctx, req, analysis.EntryOutput(func(_ context.Context, entry *spb.Entry) error {
+			batch = append(batch, entry)
+			if len(batch) >= outputBatch {
+				return flush()
+			}
+			return nil
+		}))
+		if err == nil {
+			err = flush()
+		}
+		if abandoned {
+			continue // the proxy has already ended this analysis
+		} else if err := c.Done(err); err != nil {
+			return err
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package proxy
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -24,6 +25,10 @@ import (
 	"reflect"
 	"testing"
 
+	"kythe.io/kythe/go/platform/analysis"
+
+	"github.com/golang/protobuf/proto"
+
 	apb "kythe.io/kythe/proto/analysis_go_proto"
 	spb "kythe.io/kythe/proto/storage_go_proto"
 )
@@ -302,3 +307,79 @@ func TestAnalysisWorks(t *testing.T) {
 		t.Errorf("Wrong incorrect responses:\n got: %+v\nwant: %+v", rsps, want)
 	}
 }
+
+// entryAnalyzer is a CompilationAnalyzer that fetches the file named "input"
+// and outputs testEntries, or fails if the fetch fails.
+type entryAnalyzer struct{ f analysis.Fetcher }
+
+func (a entryAnalyzer) Analyze(ctx context.Context, req *apb.AnalysisRequest, out analysis.OutputFunc) error {
+	if _, err := a.f.Fetch("input", ""); err != nil {
+		return err
+	}
+	for _, entry := range testEntries {
+		bits, err := proto.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if err := out(ctx, &apb.AnalysisOutput{Value: bits}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestClient(t *testing.T) {
+	pin, pout := io.Pipe() // proxy to indexer
+	xin, xout := io.Pipe() // indexer to proxy
+
+	// The proxy offers two analyses, the second of which cannot fetch its
+	// input, and then reports that there is no more work.
+	var served int
+	var gotEntries []*spb.Entry
+	var gotDone []error
+	h := handler{
+		analysis: func() (*apb.AnalysisRequest, error) {
+			if served == 2 {
+				return nil, errors.New("no more work")
+			}
+			served++
+			return testReq, nil
+		},
+		output: func(es ...*spb.Entry) error {
+			gotEntries = append(gotEntries, es...)
+			return nil
+		},
+		done: func(err error) { gotDone = append(gotDone, err) },
+		file: func(path, _ string) ([]byte, error) {
+			if served == 1 && path == "input" {
+				return []byte("data"), nil
+			}
+			return nil, errors.New("notfound")
+		},
+	}
+	perr := make(chan error, 1)
+	go func() { perr <- New(xin, pout).Run(h) }()
+
+	c := NewClient(pin, xout)
+	err := c.Run(context.Background(), entryAnalyzer{c})
+	if want := ReplyError("no more work"); err != want {
+		t.Errorf("Client Run: got error %v, want %v", err, want)
+	}
+	xout.Close() // signal EOF to the proxy
+	if err := <-perr; err != nil {
+		t.Errorf("Proxy Run: unexpected error: %v", err)
+	}
+
+	if len(gotEntries) != len(testEntries) {
+		t.Errorf("Incorrect entries:\n got: %+v\nwant: %+v", gotEntries, testEntries)
+	} else {
+		for i, got := range gotEntries {
+			if !proto.Equal(got, testEntries[i]) {
+				t.Errorf("Entry %d: got %+v, want %+v", i, got, testEntries[i])
+			}
+		}
+	}
+	if len(gotDone) != 2 || gotDone[0] != nil || gotDone[1] == nil {
+		t.Errorf("Done: got %v, want [<nil> <error>]", gotDone)
+	}
+}
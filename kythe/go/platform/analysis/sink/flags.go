@@ -0,0 +1,70 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sink
+
+import (
+	"errors"
+	"flag"
+	"io"
+
+	"kythe.io/kythe/go/util/datasize"
+	"kythe.io/kythe/go/util/riegeli"
+)
+
+// Flags holds the values of the standard output flags shared by indexers.
+type Flags struct {
+	Format         string         // --write_format
+	RiegeliOptions string         // --riegeli_writer_options
+	DedupCacheSize *datasize.Size // --dedup_cache_size
+	ShardedFile    string         // --sharded_file
+	Shards         int            // --shards
+}
+
+// DefineFlags defines the standard output flags on the default flag set, and
+// returns a value that will hold their settings once the flags are parsed.
+func DefineFlags() *Flags {
+	f := new(Flags)
+	flag.StringVar(&f.Format, "write_format", DelimitedFormat, "Format of the output stream (accepted formats: {delimited,json,riegeli})")
+	flag.StringVar(&f.RiegeliOptions, "riegeli_writer_options", "", "Riegeli writer options")
+	f.DedupCacheSize = datasize.Flag("dedup_cache_size", "0", `If nonzero, drop duplicate entries using a cache of known entry hashes of this size (e.g. "512MiB")`)
+	flag.StringVar(&f.ShardedFile, "sharded_file", "", "If given, write output to files named <path>-NNNNN-of-NNNNN instead of stdout, divided by source VName (requires --shards)")
+	flag.IntVar(&f.Shards, "shards", 0, "Number of output files among which to divide entries")
+	return f
+}
+
+// Create returns a Writer configured by the flags.  Output is written to w
+// unless --sharded_file is set.
+func (f *Flags) Create(w io.Writer) (*Writer, error) {
+	if (f.ShardedFile == "") != (f.Shards <= 0) {
+		return nil, errors.New("--sharded_file and --shards must be given together")
+	}
+	opts := &Options{Format: f.Format}
+	if f.DedupCacheSize != nil {
+		opts.DedupCacheSize = int(*f.DedupCacheSize)
+	}
+	if f.RiegeliOptions != "" {
+		ropts, err := riegeli.ParseOptions(f.RiegeliOptions)
+		if err != nil {
+			return nil, err
+		}
+		opts.Riegeli = ropts
+	}
+	if f.ShardedFile != "" {
+		return Create(f.ShardedFile, f.Shards, opts)
+	}
+	return New(opts, w)
+}
@@ -0,0 +1,370 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sink implements a shared output stage for Kythe indexers.
+//
+// A Writer accepts entries, either directly or as analysis outputs, and writes
+// them to one or more outputs in delimited, JSON, or Riegeli format.  Entries
+// may optionally be deduplicated, and divided among several outputs by a hash
+// of their source VName, so that all the entries for a given node are written
+// to the same output.  The Writer tracks the number of entries written for
+// each compilation unit that uses it.
+//
+// Example:
+//
+//   w, err := sink.New(&sink.Options{Format: sink.RiegeliFormat}, os.Stdout)
+//   ...
+//   u := w.Unit("//my/package")
+//   err := pi.Emit(ctx, u.Put, nil)
+//   ...
+//   if err := w.Close(); err != nil { ... }
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/util/dedup"
+	"kythe.io/kythe/go/util/riegeli"
+
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// Accepted output formats.
+const (
+	DelimitedFormat = "delimited" // varint-delimited wire-format Entry messages
+	JSONFormat      = "json"      // undelimited JSON Entry messages
+	RiegeliFormat   = "riegeli"   // a Riegeli file of wire-format Entry messages
+)
+
+// Options control the behaviour of a Writer.
+type Options struct {
+	// The output format; one of the *Format constants.  If empty,
+	// DelimitedFormat is used.
+	Format string
+
+	// Options for RiegeliFormat output.  If nil, defaults are used.
+	Riegeli *riegeli.WriterOptions
+
+	// If positive, duplicate entries are dropped, using a cache of entry
+	// hashes no larger than this many bytes.  See dedup.New.
+	DedupCacheSize int
+}
+
+func (o *Options) format() string {
+	if o == nil || o.Format == "" {
+		return DelimitedFormat
+	}
+	return strings.ToLower(o.Format)
+}
+
+// A Writer writes entries to one or more outputs.  It is safe for concurrent
+// use by multiple goroutines.
+type Writer struct {
+	json   bool
+	outs   []*output
+	closer []io.Closer // closed by Close after outputs are flushed
+
+	mu      sync.Mutex
+	dedup   *dedup.Deduper
+	units   []*Unit
+	written int
+}
+
+// An output is a single destination for entries.
+type output struct {
+	buf *bufio.Writer
+	put func([]byte) error // write a wire-format entry
+	enc *json.Encoder      // if non-nil, used instead of put
+	rw  *riegeli.Writer    // if non-nil, closed by close
+}
+
+func (o *output) close() error {
+	if o.rw != nil {
+		if err := o.rw.Close(); err != nil {
+			return err
+		}
+	}
+	return o.buf.Flush()
+}
+
+// New returns a Writer that writes to each of ws.  If len(ws) > 1, each entry
+// is written to exactly one of them, chosen by a hash of its source VName.
+// The caller must call Close to flush output when finished; Close does not
+// close the elements of ws.
+func New(opts *Options, ws ...io.Writer) (*Writer, error) {
+	if len(ws) == 0 {
+		return nil, errors.New("no outputs specified")
+	}
+	w := &Writer{json: opts.format() == JSONFormat}
+	if opts != nil && opts.DedupCacheSize > 0 {
+		d, err := dedup.New(opts.DedupCacheSize)
+		if err != nil {
+			return nil, err
+		}
+		w.dedup = d
+	}
+	for _, out := range ws {
+		buf := bufio.NewWriter(out)
+		o := &output{buf: buf}
+		switch f := opts.format(); f {
+		case DelimitedFormat:
+			dw := delimited.NewWriter(buf)
+			o.put = dw.Put
+		case JSONFormat:
+			o.enc = json.NewEncoder(buf)
+		case RiegeliFormat:
+			var ropts *riegeli.WriterOptions
+			if opts != nil {
+				ropts = opts.Riegeli
+			}
+			o.rw = riegeli.NewWriter(buf, ropts)
+			o.put = o.rw.Put
+		default:
+			return nil, fmt.Errorf("unsupported output format %q", f)
+		}
+		w.outs = append(w.outs, o)
+	}
+	return w, nil
+}
+
+// ShardPath returns the path of the ith of n output shards named by prefix.
+func ShardPath(prefix string, i, n int) string {
+	return fmt.Sprintf("%s-%.5d-of-%.5d", prefix, i, n)
+}
+
+// Create returns a Writer that divides its output among n newly-created files
+// whose names are given by ShardPath(prefix, i, n).  The files are closed by
+// the Writer's Close method.
+func Create(prefix string, n int, opts *Options) (*Writer, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid shard count: %d", n)
+	}
+	var files []io.Writer
+	var closers []io.Closer
+	for i := 0; i < n; i++ {
+		f, err := os.Create(ShardPath(prefix, i, n))
+		if err != nil {
+			for _, c := range closers {
+				c.Close()
+			}
+			return nil, err
+		}
+		files = append(files, f)
+		closers = append(closers, f)
+	}
+	w, err := New(opts, files...)
+	if err != nil {
+		for _, c := range closers {
+			c.Close()
+		}
+		return nil, err
+	}
+	w.closer = closers
+	return w, nil
+}
+
+// Put writes entry to the output selected by its source VName, unless it is
+// a duplicate of an entry already written.  Put has the signature of an
+// indexer.Sink.
+func (w *Writer) Put(_ context.Context, entry *spb.Entry) error {
+	_, err := w.put(entry, nil)
+	return err
+}
+
+// PutOutput writes the entry encoded by the value of out, which must be a
+// wire-format Entry message.  PutOutput has the signature of an
+// analysis.OutputFunc.
+func (w *Writer) PutOutput(_ context.Context, out *apb.AnalysisOutput) error {
+	_, err := w.putOutput(out)
+	return err
+}
+
+func (w *Writer) putOutput(out *apb.AnalysisOutput) (bool, error) {
+	var entry spb.Entry
+	if err := proto.Unmarshal(out.Value, &entry); err != nil {
+		return false, fmt.Errorf("unmarshaling entry from output: %v", err)
+	}
+	return w.put(&entry, out.Value)
+}
+
+// put writes entry, whose wire encoding is rec if non-nil, and reports
+// whether it was written (false for a duplicate).
+func (w *Writer) put(entry *spb.Entry, rec []byte) (bool, error) {
+	if rec == nil {
+		var err error
+		rec, err = proto.Marshal(entry)
+		if err != nil {
+			return false, fmt.Errorf("marshaling entry: %v", err)
+		}
+	}
+	o := w.outs[w.shard(entry.Source)]
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.dedup.IsUnique(rec) {
+		return false, nil
+	}
+	var err error
+	if o.enc != nil {
+		err = o.enc.Encode(entry)
+	} else {
+		err = o.put(rec)
+	}
+	if err != nil {
+		return false, err
+	}
+	w.written++
+	return true, nil
+}
+
+// shard returns the index of the output for entries with the given source.
+func (w *Writer) shard(src *spb.VName) int {
+	if len(w.outs) == 1 {
+		return 0
+	}
+	h := fnv.New64a()
+	for _, s := range []string{src.GetSignature(), src.GetCorpus(), src.GetRoot(), src.GetPath(), src.GetLanguage()} {
+		io.WriteString(h, s)
+		h.Write([]byte{0})
+	}
+	return int(h.Sum64() % uint64(len(w.outs)))
+}
+
+// Close flushes all buffered output, and closes any files created by the
+// Writer.  The Writer must not be used after Close.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var err error
+	for _, o := range w.outs {
+		if cerr := o.close(); err == nil {
+			err = cerr
+		}
+	}
+	for _, c := range w.closer {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Written returns the total number of entries written.
+func (w *Writer) Written() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.written
+}
+
+// Duplicates returns the total number of duplicate entries dropped.
+func (w *Writer) Duplicates() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return int(w.dedup.Duplicates())
+}
+
+// A Unit is a view of a Writer that counts the entries written on behalf of
+// a single compilation unit.  A Unit is safe for concurrent use.
+type Unit struct {
+	w    *Writer
+	name string
+
+	mu                  sync.Mutex
+	entries, duplicates int
+}
+
+// Unit returns a view of w that counts the entries written for the unit with
+// the given name.
+func (w *Writer) Unit(name string) *Unit {
+	u := &Unit{w: w, name: name}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.units = append(w.units, u)
+	return u
+}
+
+// Put acts as the Writer's Put method, attributing entry to u.
+func (u *Unit) Put(_ context.Context, entry *spb.Entry) error {
+	ok, err := u.w.put(entry, nil)
+	u.count(ok, err)
+	return err
+}
+
+// PutOutput acts as the Writer's PutOutput method, attributing out to u.
+func (u *Unit) PutOutput(_ context.Context, out *apb.AnalysisOutput) error {
+	ok, err := u.w.putOutput(out)
+	u.count(ok, err)
+	return err
+}
+
+func (u *Unit) count(written bool, err error) {
+	if err != nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if written {
+		u.entries++
+	} else {
+		u.duplicates++
+	}
+}
+
+// Name returns the name of the unit.
+func (u *Unit) Name() string { return u.name }
+
+// Entries returns the number of entries written for u.
+func (u *Unit) Entries() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.entries
+}
+
+// Duplicates returns the number of duplicate entries dropped for u.
+func (u *Unit) Duplicates() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.duplicates
+}
+
+// WriteReport writes the number of entries written and dropped for each
+// unit, followed by totals for w, to out.
+func (w *Writer) WriteReport(out io.Writer) error {
+	w.mu.Lock()
+	units := append([]*Unit(nil), w.units...)
+	w.mu.Unlock()
+
+	for _, u := range units {
+		if _, err := fmt.Fprintf(out, "%s\t%d entries\t%d duplicates\n", u.Name(), u.Entries(), u.Duplicates()); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(out, "Total: %d entries written, %d duplicates dropped\n", w.Written(), w.Duplicates())
+	return err
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/util/riegeli"
+
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func testEntry(sig, fact string) *spb.Entry {
+	return &spb.Entry{
+		Source:    &spb.VName{Signature: sig, Language: "test"},
+		FactName:  "/kythe/" + fact,
+		FactValue: []byte(fact),
+	}
+}
+
+// readDelimited decodes all the entries in a delimited stream.
+func readDelimited(t *testing.T, data []byte) []*spb.Entry {
+	t.Helper()
+	var entries []*spb.Entry
+	rd := delimited.NewReader(bytes.NewReader(data))
+	for {
+		var entry spb.Entry
+		if err := rd.NextProto(&entry); err == io.EOF {
+			return entries
+		} else if err != nil {
+			t.Fatalf("Reading delimited output: %v", err)
+		}
+		entries = append(entries, &entry)
+	}
+}
+
+func checkEntries(t *testing.T, got, want []*spb.Entry) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("Got %d entries, want %d:\n%v", len(got), len(want), got)
+	}
+	for i, entry := range got {
+		if !proto.Equal(entry, want[i]) {
+			t.Errorf("Entry %d: got %v, want %v", i, entry, want[i])
+		}
+	}
+}
+
+func TestDedupAndCounts(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	w, err := New(&Options{DedupCacheSize: 1 << 20}, &buf)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	a, b := testEntry("a", "x"), testEntry("b", "y")
+	u1, u2 := w.Unit("u1"), w.Unit("u2")
+	for _, e := range []*spb.Entry{a, b, a} {
+		if err := u1.Put(ctx, e); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	rec, err := proto.Marshal(b)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if err := u2.PutOutput(ctx, &apb.AnalysisOutput{Value: rec}); err != nil {
+		t.Fatalf("PutOutput failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	checkEntries(t, readDelimited(t, buf.Bytes()), []*spb.Entry{a, b})
+	for _, test := range []struct {
+		u                   *Unit
+		entries, duplicates int
+	}{{u1, 2, 1}, {u2, 0, 1}} {
+		if got, dups := test.u.Entries(), test.u.Duplicates(); got != test.entries || dups != test.duplicates {
+			t.Errorf("Unit %q: got %d entries, %d duplicates; want %d, %d",
+				test.u.Name(), got, dups, test.entries, test.duplicates)
+		}
+	}
+	if got := w.Written(); got != 2 {
+		t.Errorf("Written: got %d, want 2", got)
+	}
+
+	var report bytes.Buffer
+	if err := w.WriteReport(&report); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	if want := "u1\t2 entries\t1 duplicates\n"; !strings.HasPrefix(report.String(), want) {
+		t.Errorf("Report: got %q, want prefix %q", report.String(), want)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	ctx := context.Background()
+	want := []*spb.Entry{testEntry("a", "x"), testEntry("b", "y"), testEntry("a", "x")}
+	write := func(opts *Options) []byte {
+		var buf bytes.Buffer
+		w, err := New(opts, &buf)
+		if err != nil {
+			t.Fatalf("New(%+v) failed: %v", opts, err)
+		}
+		for _, e := range want {
+			if err := w.Put(ctx, e); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	// Without deduplication, all entries are preserved.
+	checkEntries(t, readDelimited(t, write(nil)), want)
+
+	var got []*spb.Entry
+	dec := json.NewDecoder(bytes.NewReader(write(&Options{Format: JSONFormat})))
+	for dec.More() {
+		var entry spb.Entry
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatalf("Decoding JSON output: %v", err)
+		}
+		got = append(got, &entry)
+	}
+	checkEntries(t, got, want)
+
+	got = nil
+	rd := riegeli.NewReader(bytes.NewReader(write(&Options{
+		Format:  RiegeliFormat,
+		Riegeli: &riegeli.WriterOptions{Compression: riegeli.NoCompression},
+	})))
+	for {
+		var entry spb.Entry
+		if err := rd.NextProto(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Reading Riegeli output: %v", err)
+		}
+		got = append(got, &entry)
+	}
+	checkEntries(t, got, want)
+
+	if w, err := New(&Options{Format: "bogus"}, ioutil.Discard); err == nil {
+		t.Errorf("New with bogus format: got %v, want error", w)
+	}
+}
+
+func TestSharding(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sink_test")
+	if err != nil {
+		t.Fatalf("Creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	const shards = 4
+	prefix := filepath.Join(dir, "out")
+	w, err := Create(prefix, shards, nil)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	ctx := context.Background()
+	const numSources = 20
+	for i := 0; i < numSources; i++ {
+		for _, fact := range []string{"x", "y"} {
+			if err := w.Put(ctx, testEntry(fmt.Sprint(i), fact)); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Every entry should be written exactly once, and all the entries for a
+	// given source should be written to the same shard.
+	shardOf := make(map[string]int)
+	var total int
+	for i := 0; i < shards; i++ {
+		data, err := ioutil.ReadFile(ShardPath(prefix, i, shards))
+		if err != nil {
+			t.Fatalf("Reading shard %d: %v", i, err)
+		}
+		for _, entry := range readDelimited(t, data) {
+			total++
+			sig := entry.Source.Signature
+			if s, ok := shardOf[sig]; ok && s != i {
+				t.Errorf("Source %q written to shards %d and %d", sig, s, i)
+			}
+			shardOf[sig] = i
+		}
+	}
+	if total != 2*numSources {
+		t.Errorf("Total entries: got %d, want %d", total, 2*numSources)
+	}
+}
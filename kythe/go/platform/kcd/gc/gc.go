@@ -0,0 +1,342 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gc implements garbage collection for compilation databases.
+//
+// Collection proceeds in three phases:
+//
+//  1. Revisions are expired according to a retention policy, either by age or
+//     by keeping only the most recent N revisions of each corpus.
+//  2. Units indexed under an expired revision marker, and under no retained
+//     marker, are deleted.
+//  3. Files required by a deleted unit, and by no remaining unit, are deleted.
+//
+// Because a kcd.Reader cannot enumerate its entire contents, collection only
+// considers units and files reachable from expired revisions. Data that was
+// never associated with a revision is left alone. A file is kept if it is
+// required by any remaining unit that has a source, output, or target, even
+// when that unit's revision marker has no revision record.
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"time"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+
+	"bitbucket.org/creachadair/stringset"
+	"github.com/golang/protobuf/proto"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+)
+
+// Options control which data are collected.
+type Options struct {
+	// If positive, revisions whose latest timestamp is older than MaxAge
+	// (relative to Now) are expired.
+	MaxAge time.Duration
+
+	// If positive, only the KeepLatest most recent revisions of each corpus
+	// are retained; older revisions are expired.
+	KeepLatest int
+
+	// The reference time for MaxAge. If zero, time.Now() is used.
+	Now time.Time
+
+	// If true, compute and report what would be deleted without modifying
+	// the database.
+	DryRun bool
+
+	// If set, this function is used to extract the digests of the required
+	// inputs of a stored unit. If it returns an error, the unit is treated as
+	// opaque and no files are collected on its behalf. If nil, units stored
+	// with the kythe.Format key are decoded as Kythe compilation units.
+	Inputs func(formatKey string, data []byte) ([]string, error)
+}
+
+func (o *Options) now() time.Time {
+	if o == nil || o.Now.IsZero() {
+		return time.Now()
+	}
+	return o.Now
+}
+
+func (o *Options) inputs(formatKey string, data []byte) ([]string, error) {
+	if o != nil && o.Inputs != nil {
+		return o.Inputs(formatKey, data)
+	}
+	return KytheInputs(formatKey, data)
+}
+
+// KytheInputs returns the digests of the required inputs of a unit stored with
+// the kythe.Format key. It returns an error for any other format.
+func KytheInputs(formatKey string, data []byte) ([]string, error) {
+	if formatKey != kythe.Format {
+		return nil, fmt.Errorf("unknown unit format %q", formatKey)
+	}
+	var pb apb.CompilationUnit
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return nil, err
+	}
+	return kythe.Unit{Proto: &pb}.Index().Inputs, nil
+}
+
+// A Report records the data that were (or in a dry run, would be) deleted by
+// a call to Collect.
+type Report struct {
+	Revisions []kcd.Revision // expired revisions
+	Units     []string       // digests of unreachable units
+	Files     []string       // digests of unreferenced files
+
+	// Digests of retained units whose inputs could not be determined.  If
+	// this is nonempty, no files are collected.
+	OpaqueUnits []string
+}
+
+// WriteTo writes a human-readable summary of r to w.
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	out := func(format string, args ...interface{}) error {
+		n, err := fmt.Fprintf(w, format, args...)
+		total += int64(n)
+		return err
+	}
+	for _, rev := range r.Revisions {
+		if err := out("revision\t%s\t%s\t%s\n", rev.Corpus, rev.Revision, rev.Timestamp.Format(time.RFC3339)); err != nil {
+			return total, err
+		}
+	}
+	for _, digest := range r.Units {
+		if err := out("unit\t%s\n", digest); err != nil {
+			return total, err
+		}
+	}
+	for _, digest := range r.Files {
+		if err := out("file\t%s\n", digest); err != nil {
+			return total, err
+		}
+	}
+	for _, digest := range r.OpaqueUnits {
+		if err := out("opaque\t%s\n", digest); err != nil {
+			return total, err
+		}
+	}
+	err := out("%d revisions, %d units, %d files; %d opaque units\n",
+		len(r.Revisions), len(r.Units), len(r.Files), len(r.OpaqueUnits))
+	return total, err
+}
+
+// Collect expires revisions in db according to opts, and deletes the units and
+// files that are no longer reachable. It returns a report of what was deleted.
+// If opts.DryRun is true, db is not modified. If a deletion fails, Collect may
+// be retried to finish the collection.
+func Collect(ctx context.Context, db kcd.ReadWriteDeleter, opts *Options) (*Report, error) {
+	if opts == nil || (opts.MaxAge <= 0 && opts.KeepLatest <= 0) {
+		return nil, errors.New("no retention policy specified")
+	}
+
+	expired, retained, err := expireRevisions(ctx, db, opts)
+	if err != nil {
+		return nil, err
+	}
+	rep := &Report{Revisions: expired}
+	if len(expired) == 0 {
+		return rep, nil
+	}
+
+	// Unit markers are not qualified by corpus, so a unit is retained if any
+	// retained revision shares one of its markers.
+	live := stringset.New()
+	for _, rev := range retained {
+		live.Add(rev.Revision)
+	}
+	dead := stringset.New()
+	for _, rev := range expired {
+		if !live.Contains(rev.Revision) {
+			dead.Add(rev.Revision)
+		}
+	}
+
+	liveUnits, err := findUnits(ctx, db, live)
+	if err != nil {
+		return nil, err
+	}
+	deadUnits, err := findUnits(ctx, db, dead)
+	if err != nil {
+		return nil, err
+	}
+	rep.Units = deadUnits.Diff(liveUnits).Elements()
+
+	// Files required by the collected units are candidates; those also
+	// required by any remaining unit are kept.  The remaining units include
+	// those whose revision markers have no revision record, so they are not
+	// limited to the units of retained revisions.
+	candidates := stringset.New()
+	if err := db.Units(ctx, rep.Units, func(digest, key string, data []byte) error {
+		inputs, err := opts.inputs(key, data)
+		if err == nil {
+			candidates.Add(inputs...)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("reading expired units: %v", err)
+	}
+	if !candidates.Empty() {
+		found, err := allUnits(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		remaining := found.Union(liveUnits).Diff(stringset.New(rep.Units...))
+
+		keep := stringset.New()
+		if err := db.Units(ctx, remaining.Elements(), func(digest, key string, data []byte) error {
+			inputs, err := opts.inputs(key, data)
+			if err != nil {
+				rep.OpaqueUnits = append(rep.OpaqueUnits, digest)
+			} else {
+				keep.Add(inputs...)
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("reading remaining units: %v", err)
+		}
+		if len(rep.OpaqueUnits) == 0 {
+			if err := db.FilesExist(ctx, candidates.Diff(keep).Elements(), func(digest string) error {
+				rep.Files = append(rep.Files, digest)
+				return nil
+			}); err != nil {
+				return nil, fmt.Errorf("checking files: %v", err)
+			}
+			sort.Strings(rep.Files)
+		}
+	}
+
+	if opts.DryRun {
+		return rep, nil
+	}
+
+	// Delete the data before the records that lead to it, so that if a deletion
+	// fails, whatever remains is still reachable from an expired revision and
+	// is collected by a later run.
+	for _, digest := range rep.Files {
+		if err := db.DeleteFile(ctx, digest); err != nil {
+			return rep, fmt.Errorf("deleting file %q: %v", digest, err)
+		}
+	}
+	for _, digest := range rep.Units {
+		if err := db.DeleteUnit(ctx, digest); err != nil {
+			return rep, fmt.Errorf("deleting unit %q: %v", digest, err)
+		}
+	}
+	for _, rev := range rep.Revisions {
+		if err := db.DeleteRevision(ctx, rev.Revision, rev.Corpus); err != nil {
+			return rep, fmt.Errorf("deleting revision %v: %v", rev, err)
+		}
+	}
+	return rep, nil
+}
+
+// expireRevisions partitions the revisions of db into those expired and those
+// retained by opts. Each distinct (revision, corpus) pair is reported once,
+// with its most recent timestamp.
+func expireRevisions(ctx context.Context, db kcd.Reader, opts *Options) (expired, retained []kcd.Revision, _ error) {
+	type key struct{ revision, corpus string }
+	latest := make(map[key]kcd.Revision)
+	if err := db.Revisions(ctx, nil, func(rev kcd.Revision) error {
+		k := key{rev.Revision, rev.Corpus}
+		if old, ok := latest[k]; !ok || rev.Timestamp.After(old.Timestamp) {
+			latest[k] = rev
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("listing revisions: %v", err)
+	}
+
+	byCorpus := make(map[string][]kcd.Revision)
+	for _, rev := range latest {
+		byCorpus[rev.Corpus] = append(byCorpus[rev.Corpus], rev)
+	}
+	var cutoff time.Time
+	if opts.MaxAge > 0 {
+		cutoff = opts.now().Add(-opts.MaxAge)
+	}
+	for _, revs := range byCorpus {
+		// Order newest first, breaking ties by marker for stability.
+		sort.Slice(revs, func(i, j int) bool {
+			if !revs[i].Timestamp.Equal(revs[j].Timestamp) {
+				return revs[i].Timestamp.After(revs[j].Timestamp)
+			}
+			return revs[i].Revision < revs[j].Revision
+		})
+		for i, rev := range revs {
+			if (opts.KeepLatest > 0 && i >= opts.KeepLatest) || (!cutoff.IsZero() && rev.Timestamp.Before(cutoff)) {
+				expired = append(expired, rev)
+			} else {
+				retained = append(retained, rev)
+			}
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		if expired[i].Corpus != expired[j].Corpus {
+			return expired[i].Corpus < expired[j].Corpus
+		}
+		return expired[i].Revision < expired[j].Revision
+	})
+	return expired, retained, nil
+}
+
+// findUnits returns the digests of all units indexed under any of the given
+// revision markers.
+func findUnits(ctx context.Context, db kcd.Reader, revisions stringset.Set) (stringset.Set, error) {
+	units := stringset.New()
+	if revisions.Empty() {
+		return units, nil
+	}
+	if err := db.Find(ctx, &kcd.FindFilter{Revisions: revisions.Elements()}, func(digest string) error {
+		units.Add(digest)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding units: %v", err)
+	}
+	return units, nil
+}
+
+// allUnits returns the digests of all units in db having a source, output, or
+// target.  Find does not accept an empty filter, so these are found by
+// matching any value of each of those fields.
+func allUnits(ctx context.Context, db kcd.Reader) (stringset.Set, error) {
+	anyValue := []*regexp.Regexp{regexp.MustCompile(`.*`)}
+	units := stringset.New()
+	for _, filter := range []*kcd.FindFilter{
+		{Sources: anyValue},
+		{Outputs: anyValue},
+		{Targets: anyValue},
+	} {
+		if err := db.Find(ctx, filter, func(digest string) error {
+			units.Add(digest)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("finding units: %v", err)
+		}
+	}
+	return units, nil
+}
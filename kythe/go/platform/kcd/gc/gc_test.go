@@ -0,0 +1,264 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+	"kythe.io/kythe/go/platform/kcd/memdb"
+
+	"github.com/google/go-cmp/cmp"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+const corpus = "corpus"
+
+// testDB is a compilation database populated by newTestDB.
+type testDB struct {
+	*memdb.DB
+	units map[string]string // :: unit name → digest
+	files map[string]string // :: file content → digest
+}
+
+// newTestDB returns a database containing three revisions of a single corpus
+// at timestamps 1, 2 and 3. Revision r1 has a unit requiring files a and b;
+// revision r3 has a unit requiring files b and c.
+func newTestDB(t *testing.T) *testDB {
+	t.Helper()
+	ctx := context.Background()
+	db := &testDB{
+		DB:    new(memdb.DB),
+		units: make(map[string]string),
+		files: make(map[string]string),
+	}
+	for i, rev := range []string{"r1", "r2", "r3"} {
+		if err := db.WriteRevision(ctx, kcd.Revision{
+			Revision:  rev,
+			Corpus:    corpus,
+			Timestamp: time.Unix(int64(i+1), 0),
+		}, false); err != nil {
+			t.Fatalf("WriteRevision(%q): %v", rev, err)
+		}
+	}
+	for _, s := range []string{"a", "b", "c"} {
+		digest, err := db.WriteFile(ctx, strings.NewReader(s))
+		if err != nil {
+			t.Fatalf("WriteFile(%q): %v", s, err)
+		}
+		db.files[s] = digest
+	}
+	db.writeUnit(t, kythe.Format, "r1", "a", "b")
+	db.writeUnit(t, kythe.Format, "r3", "b", "c")
+	return db
+}
+
+func (db *testDB) writeUnit(t *testing.T, formatKey, revision string, files ...string) {
+	t.Helper()
+	cu := &apb.CompilationUnit{
+		VName: &spb.VName{Signature: revision, Language: "go"},
+	}
+	for _, f := range files {
+		cu.RequiredInput = append(cu.RequiredInput, &apb.CompilationUnit_FileInput{
+			Info: &apb.FileInfo{Path: f, Digest: db.files[f]},
+		})
+	}
+	digest, err := db.WriteUnit(context.Background(), revision, corpus, formatKey, kythe.Unit{Proto: cu})
+	if err != nil {
+		t.Fatalf("WriteUnit(%q): %v", revision, err)
+	}
+	db.units[revision] = digest
+}
+
+func revisions(db kcd.Reader) []string {
+	var revs []string
+	db.Revisions(context.Background(), nil, func(rev kcd.Revision) error {
+		revs = append(revs, rev.Revision)
+		return nil
+	})
+	return revs
+}
+
+func TestKeepLatest(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	want := &Report{
+		Revisions: []kcd.Revision{
+			{Revision: "r1", Corpus: corpus, Timestamp: time.Unix(1, 0).In(time.UTC)},
+			{Revision: "r2", Corpus: corpus, Timestamp: time.Unix(2, 0).In(time.UTC)},
+		},
+		Units: []string{db.units["r1"]},
+		Files: []string{db.files["a"]},
+	}
+
+	// A dry run reports what would be deleted, but leaves the database alone.
+	rep, err := Collect(ctx, db, &Options{KeepLatest: 1, DryRun: true})
+	if err != nil {
+		t.Fatalf("Collect (dry run): %v", err)
+	}
+	if diff := cmp.Diff(want, rep); diff != "" {
+		t.Errorf("Dry run report: (-want +got)\n%s", diff)
+	}
+	if got := revisions(db); len(got) != 3 {
+		t.Errorf("Dry run modified revisions: got %q", got)
+	}
+	if len(db.Unit) != 2 || len(db.File) != 3 {
+		t.Errorf("Dry run modified data: got %d units, %d files", len(db.Unit), len(db.File))
+	}
+
+	rep, err = Collect(ctx, db, &Options{KeepLatest: 1})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if diff := cmp.Diff(want, rep); diff != "" {
+		t.Errorf("Report: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"r3"}, revisions(db)); diff != "" {
+		t.Errorf("Remaining revisions: (-want +got)\n%s", diff)
+	}
+	if _, ok := db.Unit[db.units["r1"]]; ok {
+		t.Errorf("Unit for r1 was not deleted")
+	}
+	if _, ok := db.Unit[db.units["r3"]]; !ok {
+		t.Errorf("Unit for r3 was deleted")
+	}
+	for s, digest := range db.files {
+		if _, ok := db.File[digest]; ok == (s == "a") {
+			t.Errorf("File %q: present = %v, want %v", s, ok, s != "a")
+		}
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	db := newTestDB(t)
+	rep, err := Collect(context.Background(), db, &Options{
+		MaxAge: 2 * time.Second,
+		Now:    time.Unix(4, 0),
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	var got []string
+	for _, rev := range rep.Revisions {
+		got = append(got, rev.Revision)
+	}
+	if diff := cmp.Diff([]string{"r1"}, got); diff != "" {
+		t.Errorf("Expired revisions: (-want +got)\n%s", diff)
+	}
+}
+
+func TestOpaqueUnits(t *testing.T) {
+	db := newTestDB(t)
+	db.writeUnit(t, "other", "r2", "c")
+	rep, err := Collect(context.Background(), db, &Options{KeepLatest: 2, DryRun: true})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if diff := cmp.Diff([]string{db.units["r1"]}, rep.Units); diff != "" {
+		t.Errorf("Units: (-want +got)\n%s", diff)
+	}
+	if len(rep.Files) != 0 {
+		t.Errorf("Files: got %q, want none", rep.Files)
+	}
+	if diff := cmp.Diff([]string{db.units["r2"]}, rep.OpaqueUnits); diff != "" {
+		t.Errorf("OpaqueUnits: (-want +got)\n%s", diff)
+	}
+}
+
+func TestUnrecordedRevision(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// A unit whose revision marker has no revision record is neither retained
+	// nor expired, but the files it requires must not be collected.
+	db.writeUnit(t, kythe.Format, "unrecorded", "a")
+	rep, err := Collect(ctx, db, &Options{KeepLatest: 1})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if diff := cmp.Diff([]string{db.units["r1"]}, rep.Units); diff != "" {
+		t.Errorf("Units: (-want +got)\n%s", diff)
+	}
+	if len(rep.Files) != 0 {
+		t.Errorf("Files: got %q, want none", rep.Files)
+	}
+	if _, ok := db.Unit[db.units["unrecorded"]]; !ok {
+		t.Errorf("Unit for unrecorded revision was deleted")
+	}
+	if _, ok := db.File[db.files["a"]]; !ok {
+		t.Errorf("File %q required by a remaining unit was deleted", "a")
+	}
+}
+
+// failingDB is a testDB whose first unit deletion fails.
+type failingDB struct {
+	*testDB
+	failed bool
+}
+
+func (db *failingDB) DeleteUnit(ctx context.Context, digest string) error {
+	if !db.failed {
+		db.failed = true
+		return errors.New("unit deletion failed")
+	}
+	return db.testDB.DeleteUnit(ctx, digest)
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	db := &failingDB{testDB: newTestDB(t)}
+
+	if _, err := Collect(ctx, db, &Options{KeepLatest: 1}); err == nil {
+		t.Fatal("Collect: unexpected success")
+	}
+	if diff := cmp.Diff([]string{"r1", "r2", "r3"}, revisions(db)); diff != "" {
+		t.Errorf("Revisions after failure: (-want +got)\n%s", diff)
+	}
+
+	// The revisions were kept, so a second run finds and deletes the unit.
+	rep, err := Collect(ctx, db, &Options{KeepLatest: 1})
+	if err != nil {
+		t.Fatalf("Collect (retry): %v", err)
+	}
+	if diff := cmp.Diff([]string{db.units["r1"]}, rep.Units); diff != "" {
+		t.Errorf("Units: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"r3"}, revisions(db)); diff != "" {
+		t.Errorf("Remaining revisions: (-want +got)\n%s", diff)
+	}
+	if _, ok := db.Unit[db.units["r1"]]; ok {
+		t.Errorf("Unit for r1 was not deleted")
+	}
+	if _, ok := db.File[db.files["a"]]; ok {
+		t.Errorf("File %q was not deleted", "a")
+	}
+}
+
+func TestNoPolicy(t *testing.T) {
+	if rep, err := Collect(context.Background(), newTestDB(t), &Options{DryRun: true}); err == nil {
+		t.Errorf("Collect with no policy: got %+v, want error", rep)
+	}
+}
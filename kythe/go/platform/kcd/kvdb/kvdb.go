@@ -0,0 +1,586 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kvdb implements kcd.ReadWriteDeleter using a keyvalue.DB as its
+// backing store, giving a compilation database that persists across runs when
+// the underlying store does (e.g., LevelDB).
+//
+// Records are stored under the following key layouts:
+//
+//   rev:<corpus>\x00<revision>\x00<timestamp>  → (empty)
+//   unit:<digest>                              → <format key length><format key><data>
+//   idx:<digest>                               → JSON-encoded index terms
+//   idx:<key>:<value>\x00<digest>              → (empty)
+//   file:<digest>                              → file content
+//
+// Timestamps are encoded as 8-byte big-endian nanoseconds since the Unix epoch.
+// Each index term of a unit is also recorded as a separate idx:<key>:<value>
+// record, so that Find can scan only the terms named by its filter.
+package kvdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/storage/leveldb"
+
+	"bitbucket.org/creachadair/stringset"
+)
+
+// Key prefixes for each kind of record.
+const (
+	revPrefix  = "rev:"
+	unitPrefix = "unit:"
+	idxPrefix  = "idx:"
+	filePrefix = "file:"
+)
+
+// String tags for index keys matching the fields of a kcd.FindFilter.
+const (
+	RevisionKey = "revision"
+	CorpusKey   = "corpus"
+	OutputKey   = "output"
+	LanguageKey = "language"
+	TargetKey   = "target"
+	SourceKey   = "source"
+)
+
+// An Index is a mapping from index keys (e.g., "corpus", "source") to distinct
+// values for those keys.
+type Index map[string][]string
+
+// add adds value to the index terms for key, if it is not already present.
+func (idx Index) add(key, value string) {
+	for _, v := range idx[key] {
+		if v == value {
+			return
+		}
+	}
+	idx[key] = append(idx[key], value)
+}
+
+// termKeys returns the index term keys recording idx for the given digest.
+func (idx Index) termKeys(digest string) [][]byte {
+	var keys [][]byte
+	for key, values := range idx {
+		for _, value := range values {
+			keys = append(keys, []byte(termPrefix(key)+value+"\x00"+digest))
+		}
+	}
+	return keys
+}
+
+// DB implements kcd.ReadWriteDeleter using a keyvalue.DB.
+type DB struct {
+	kv keyvalue.DB
+	mu sync.Mutex // serializes read-modify-write updates
+}
+
+// New returns a compilation database stored in kv.  Closing the returned *DB
+// also closes kv.
+func New(kv keyvalue.DB) *DB { return &DB{kv: kv} }
+
+// Open opens (creating if necessary) a LevelDB-backed compilation database at
+// the given path.
+func Open(path string, opts *leveldb.Options) (*DB, error) {
+	kv, err := leveldb.Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return New(kv), nil
+}
+
+// Close releases the underlying keyvalue.DB.
+func (db *DB) Close(ctx context.Context) error { return db.kv.Close(ctx) }
+
+// Revisions implements a method of kcd.Reader.
+func (db *DB) Revisions(ctx context.Context, want *kcd.RevisionsFilter, f func(kcd.Revision) error) error {
+	revisionMatches, err := want.Compile()
+	if err != nil {
+		return err
+	}
+	// Revisions are keyed by corpus, so a corpus filter restricts the scan.
+	prefix := revPrefix
+	if want != nil && want.Corpus != "" {
+		prefix += want.Corpus + "\x00"
+	}
+	var revs []kcd.Revision
+	if err := db.scan(ctx, prefix, func(key, _ []byte) error {
+		rev, err := decodeRevision(key)
+		if err != nil {
+			return err
+		} else if revisionMatches(rev) {
+			revs = append(revs, rev)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		if err := f(rev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find implements a method of kcd.Reader.  Each field of the filter selects
+// the units having a matching index term for that field, found by scanning
+// only the terms for that field; the result is the intersection of these.
+func (db *DB) Find(ctx context.Context, filter *kcd.FindFilter, f func(string) error) error {
+	cf, err := filter.Compile()
+	if err != nil {
+		return err
+	} else if cf == nil {
+		return nil
+	}
+
+	var digests stringset.Set
+	narrowed := false
+	narrow := func(key string, prefixes []string, matches func(...string) bool) error {
+		if len(prefixes) == 0 || (narrowed && digests.Empty()) {
+			return nil // no constraint, or nothing left to constrain
+		}
+		found := stringset.New()
+		for _, prefix := range prefixes {
+			if err := db.scan(ctx, termPrefix(key)+prefix, func(tkey, _ []byte) error {
+				value, digest, err := decodeTerm(key, tkey)
+				if err != nil {
+					return err
+				} else if matches(value) {
+					found.Add(digest)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		if narrowed {
+			digests = digests.Intersect(found)
+		} else {
+			digests, narrowed = found, true
+		}
+		return nil
+	}
+	for _, field := range []struct {
+		key      string
+		prefixes []string
+		matches  func(...string) bool
+	}{
+		{RevisionKey, exactPrefixes(filter.Revisions), cf.RevisionMatches},
+		{CorpusKey, exactPrefixes(filter.Corpus), cf.CorpusMatches},
+		{LanguageKey, exactPrefixes(filter.Languages), cf.LanguageMatches},
+		{TargetKey, literalPrefixes(filter.Targets), cf.TargetMatches},
+		{OutputKey, literalPrefixes(filter.Outputs), cf.OutputMatches},
+		{SourceKey, literalPrefixes(filter.Sources), cf.SourcesMatch},
+	} {
+		if err := narrow(field.key, field.prefixes, field.matches); err != nil {
+			return err
+		}
+	}
+	for _, digest := range digests.Elements() {
+		if err := f(digest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exactPrefixes returns the index term prefixes that match exactly the given
+// values.
+func exactPrefixes(values []string) []string {
+	prefixes := make([]string, len(values))
+	for i, value := range values {
+		prefixes[i] = value + "\x00"
+	}
+	return prefixes
+}
+
+// literalPrefixes returns index term prefixes that include every value matched
+// by the given expressions, which are implicitly anchored at both ends.
+func literalPrefixes(res []*regexp.Regexp) []string {
+	prefixes := make([]string, len(res))
+	for i, re := range res {
+		prefixes[i], _ = re.LiteralPrefix()
+	}
+	return prefixes
+}
+
+// Units implements a method of kcd.Reader.
+func (db *DB) Units(ctx context.Context, unitDigests []string, f func(digest, key string, data []byte) error) error {
+	for _, ud := range unitDigests {
+		val, err := db.get(ctx, unitPrefix+ud)
+		if err != nil {
+			return err
+		} else if val == nil {
+			continue
+		}
+		formatKey, data, err := decodeUnit(val)
+		if err != nil {
+			return fmt.Errorf("decoding unit %q: %v", ud, err)
+		}
+		if err := f(ud, formatKey, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files implements a method of kcd.Reader.
+func (db *DB) Files(ctx context.Context, fileDigests []string, f func(string, []byte) error) error {
+	for _, fd := range fileDigests {
+		data, err := db.get(ctx, filePrefix+fd)
+		if err != nil {
+			return err
+		} else if data == nil {
+			continue
+		}
+		if err := f(fd, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilesExist implements a method of kcd.Reader.
+func (db *DB) FilesExist(ctx context.Context, fileDigests []string, f func(string) error) error {
+	for _, fd := range fileDigests {
+		data, err := db.get(ctx, filePrefix+fd)
+		if err != nil {
+			return err
+		} else if data == nil {
+			continue
+		}
+		if err := f(fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRevision implements a method of kcd.Writer.
+func (db *DB) WriteRevision(ctx context.Context, rev kcd.Revision, replace bool) error {
+	if rev.Revision == "" {
+		return errors.New("missing revision marker")
+	} else if rev.Corpus == "" {
+		return errors.New("missing corpus label")
+	}
+	if rev.Timestamp.IsZero() {
+		rev.Timestamp = time.Now()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var stale [][]byte
+	if replace {
+		old, err := db.keys(ctx, revisionPrefix(rev.Revision, rev.Corpus))
+		if err != nil {
+			return err
+		}
+		stale = old
+	}
+	return db.update(ctx, func(wr keyvalue.Writer) error {
+		if err := deleteKeys(wr, stale); err != nil {
+			return err
+		}
+		return wr.Write(encodeRevision(rev), []byte{})
+	})
+}
+
+// WriteUnit implements a method of kcd.Writer.  On success, the returned
+// digest is the kcd.HexDigest of whatever unit.MarshalBinary returned.
+func (db *DB) WriteUnit(ctx context.Context, revision, corpus, formatKey string, unit kcd.Unit) (string, error) {
+	if revision == "" {
+		return "", errors.New("empty revision marker")
+	}
+	unit.Canonicalize()
+	bits, err := unit.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	digest := kcd.UnitDigest(unit)
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	// Merge the new index terms with any already recorded for this unit, and
+	// record each term separately for Find.
+	index := make(Index)
+	if old, err := db.get(ctx, idxPrefix+digest); err != nil {
+		return "", err
+	} else if old != nil {
+		if err := json.Unmarshal(old, &index); err != nil {
+			return "", fmt.Errorf("decoding index for %q: %v", digest, err)
+		}
+	}
+	index.add(RevisionKey, revision)
+	if corpus != "" {
+		index.add(CorpusKey, corpus)
+	}
+	idx := unit.Index()
+	if idx.Language != "" {
+		index.add(LanguageKey, idx.Language)
+	}
+	if idx.Output != "" {
+		index.add(OutputKey, idx.Output)
+	}
+	for _, src := range idx.Sources {
+		index.add(SourceKey, src)
+	}
+	if idx.Target != "" {
+		index.add(TargetKey, idx.Target)
+	}
+	ibits, err := json.Marshal(index)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.update(ctx, func(wr keyvalue.Writer) error {
+		if err := wr.Write([]byte(unitPrefix+digest), encodeUnit(formatKey, bits)); err != nil {
+			return err
+		}
+		if err := wr.Write([]byte(idxPrefix+digest), ibits); err != nil {
+			return err
+		}
+		for _, key := range index.termKeys(digest) {
+			if err := wr.Write(key, []byte{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+// WriteFile implements a method of kcd.Writer.
+func (db *DB) WriteFile(ctx context.Context, r io.Reader) (string, error) {
+	bits, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	digest := kcd.HexDigest(bits)
+	if err := db.update(ctx, func(wr keyvalue.Writer) error {
+		return wr.Write([]byte(filePrefix+digest), bits)
+	}); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
+
+// DeleteUnit implements a method of kcd.Deleter.
+func (db *DB) DeleteUnit(ctx context.Context, unitDigest string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if val, err := db.get(ctx, unitPrefix+unitDigest); err != nil {
+		return err
+	} else if val == nil {
+		return os.ErrNotExist
+	}
+	keys := [][]byte{
+		[]byte(unitPrefix + unitDigest),
+		[]byte(idxPrefix + unitDigest),
+	}
+	if val, err := db.get(ctx, idxPrefix+unitDigest); err != nil {
+		return err
+	} else if val != nil {
+		var index Index
+		if err := json.Unmarshal(val, &index); err != nil {
+			return fmt.Errorf("decoding index for %q: %v", unitDigest, err)
+		}
+		keys = append(keys, index.termKeys(unitDigest)...)
+	}
+	return db.update(ctx, func(wr keyvalue.Writer) error { return deleteKeys(wr, keys) })
+}
+
+// DeleteFile implements a method of kcd.Deleter.
+func (db *DB) DeleteFile(ctx context.Context, fileDigest string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if val, err := db.get(ctx, filePrefix+fileDigest); err != nil {
+		return err
+	} else if val == nil {
+		return os.ErrNotExist
+	}
+	return db.update(ctx, func(wr keyvalue.Writer) error {
+		return deleteKeys(wr, [][]byte{[]byte(filePrefix + fileDigest)})
+	})
+}
+
+// DeleteRevision implements a method of kcd.Deleter.
+func (db *DB) DeleteRevision(ctx context.Context, revision, corpus string) error {
+	rev := kcd.Revision{Revision: revision, Corpus: corpus}
+	if err := rev.IsValid(); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	keys, err := db.keys(ctx, revisionPrefix(revision, corpus))
+	if err != nil {
+		return err
+	} else if len(keys) == 0 {
+		return os.ErrNotExist
+	}
+	return db.update(ctx, func(wr keyvalue.Writer) error { return deleteKeys(wr, keys) })
+}
+
+// get returns the value stored for key, or nil if key is not present.
+func (db *DB) get(ctx context.Context, key string) ([]byte, error) {
+	val, err := db.kv.Get(ctx, []byte(key), nil)
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if val == nil {
+		val = []byte{}
+	}
+	return val, nil
+}
+
+// scan calls f for each key-value entry whose key has the given prefix.  The
+// underlying iterator is held open while f runs, so f must not write to db.
+func (db *DB) scan(ctx context.Context, prefix string, f func(key, val []byte) error) error {
+	it, err := db.kv.ScanPrefix(ctx, []byte(prefix), nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for {
+		key, val, err := it.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(key, val); err != nil {
+			return err
+		}
+	}
+}
+
+// keys returns all the keys having the given prefix.
+func (db *DB) keys(ctx context.Context, prefix string) ([][]byte, error) {
+	var keys [][]byte
+	err := db.scan(ctx, prefix, func(key, _ []byte) error {
+		keys = append(keys, append([]byte(nil), key...))
+		return nil
+	})
+	return keys, err
+}
+
+// update calls f with a new writer for db, and closes the writer when f
+// returns.
+func (db *DB) update(ctx context.Context, f func(keyvalue.Writer) error) error {
+	wr, err := db.kv.Writer(ctx)
+	if err != nil {
+		return err
+	}
+	if err := f(wr); err != nil {
+		wr.Close()
+		return err
+	}
+	return wr.Close()
+}
+
+// deleteKeys removes the given keys using wr, which must be a keyvalue.Deleter.
+func deleteKeys(wr keyvalue.Writer, keys [][]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	del, ok := wr.(keyvalue.Deleter)
+	if !ok {
+		return errors.New("keyvalue writer does not support deletion")
+	}
+	for _, key := range keys {
+		if err := del.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revisionPrefix returns the key prefix shared by all timestamps of the given
+// revision and corpus.
+func revisionPrefix(revision, corpus string) string {
+	return revPrefix + corpus + "\x00" + revision + "\x00"
+}
+
+// termPrefix returns the key prefix shared by all index terms for the given
+// index key.
+func termPrefix(key string) string { return idxPrefix + key + ":" }
+
+// decodeTerm decodes an index term key for the given index key.
+func decodeTerm(key string, tkey []byte) (value, digest string, err error) {
+	rest := bytes.TrimPrefix(tkey, []byte(termPrefix(key)))
+	i := bytes.LastIndexByte(rest, 0)
+	if i < 0 {
+		return "", "", fmt.Errorf("invalid index term key %q", tkey)
+	}
+	return string(rest[:i]), string(rest[i+1:]), nil
+}
+
+func encodeRevision(rev kcd.Revision) []byte {
+	key := []byte(revisionPrefix(rev.Revision, rev.Corpus))
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(rev.Timestamp.UnixNano()))
+	return append(key, ts[:]...)
+}
+
+func decodeRevision(key []byte) (kcd.Revision, error) {
+	rest := bytes.TrimPrefix(key, []byte(revPrefix))
+	if len(rest) < 8 {
+		return kcd.Revision{}, fmt.Errorf("invalid revision key %q", key)
+	}
+	ts := int64(binary.BigEndian.Uint64(rest[len(rest)-8:]))
+	parts := bytes.SplitN(rest[:len(rest)-8], []byte("\x00"), 3)
+	if len(parts) != 3 || len(parts[2]) != 0 {
+		return kcd.Revision{}, fmt.Errorf("invalid revision key %q", key)
+	}
+	return kcd.Revision{
+		Corpus:    string(parts[0]),
+		Revision:  string(parts[1]),
+		Timestamp: time.Unix(0, ts).In(time.UTC),
+	}, nil
+}
+
+func encodeUnit(formatKey string, data []byte) []byte {
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(formatKey)+len(data))
+	n := binary.PutUvarint(buf, uint64(len(formatKey)))
+	buf = append(buf[:n], formatKey...)
+	return append(buf, data...)
+}
+
+func decodeUnit(val []byte) (formatKey string, data []byte, err error) {
+	n, w := binary.Uvarint(val)
+	if w <= 0 || uint64(len(val)-w) < n {
+		return "", nil, errors.New("invalid unit record")
+	}
+	return string(val[w : w+int(n)]), val[w+int(n):], nil
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kvdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+	"kythe.io/kythe/go/platform/kcd/testutil"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/keyvalue"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+func TestInMemory(t *testing.T) {
+	db := New(inmemory.NewKeyValueDB())
+	for _, err := range testutil.Run(context.Background(), db) {
+		t.Error(err)
+	}
+}
+
+func TestLevelDB(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "kvdb")
+	if err != nil {
+		t.Fatalf("Creating temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(dir, nil)
+	if err != nil {
+		t.Fatalf("Open(%q): %v", dir, err)
+	}
+	for _, err := range testutil.Run(ctx, db) {
+		t.Error(err)
+	}
+
+	// Write some fresh data, then reopen the database and verify that it is
+	// still present.
+	const data = "persistent"
+	fileDigest, err := db.WriteFile(ctx, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	rev := kcd.Revision{Revision: "r1", Corpus: "c1"}
+	if err := db.WriteRevision(ctx, rev, true); err != nil {
+		t.Fatalf("WriteRevision: %v", err)
+	}
+	if err := db.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	db, err = Open(dir, nil)
+	if err != nil {
+		t.Fatalf("Reopening %q: %v", dir, err)
+	}
+	defer db.Close(ctx)
+
+	var got string
+	if err := db.Files(ctx, []string{fileDigest}, func(_ string, data []byte) error {
+		got = string(data)
+		return nil
+	}); err != nil {
+		t.Errorf("Files: %v", err)
+	} else if got != data {
+		t.Errorf("Files: got %q, want %q", got, data)
+	}
+
+	var numRevs int
+	if err := db.Revisions(ctx, &kcd.RevisionsFilter{Corpus: rev.Corpus}, func(got kcd.Revision) error {
+		numRevs++
+		if got.Revision != rev.Revision {
+			t.Errorf("Revisions: got %q, want %q", got.Revision, rev.Revision)
+		}
+		return nil
+	}); err != nil {
+		t.Errorf("Revisions: %v", err)
+	} else if numRevs != 1 {
+		t.Errorf("Revisions: got %d results, want 1", numRevs)
+	}
+}
+
+func TestIndexTerms(t *testing.T) {
+	ctx := context.Background()
+	db := New(inmemory.NewKeyValueDB())
+	write := func(revision, corpus, lang, output string) string {
+		t.Helper()
+		digest, err := db.WriteUnit(ctx, revision, corpus, kythe.Format, kythe.Unit{Proto: &apb.CompilationUnit{
+			VName:     &spb.VName{Language: lang},
+			OutputKey: output,
+		}})
+		if err != nil {
+			t.Fatalf("WriteUnit: %v", err)
+		}
+		return digest
+	}
+	find := func(filter *kcd.FindFilter) []string {
+		t.Helper()
+		var digests []string
+		if err := db.Find(ctx, filter, func(digest string) error {
+			digests = append(digests, digest)
+			return nil
+		}); err != nil {
+			t.Fatalf("Find %+v: %v", filter, err)
+		}
+		return digests
+	}
+	check := func(filter *kcd.FindFilter, want ...string) {
+		t.Helper()
+		if got := find(filter); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Find %+v: got %q, want %q", filter, got, want)
+		}
+	}
+
+	u1 := write("r1", "c1", "go", "out/a.a")
+	u2 := write("r1", "c2", "go", "out/b.a")
+	u3 := write("r2", "c1", "java", "out/a.jar")
+
+	check(&kcd.FindFilter{Corpus: []string{"c1"}, Languages: []string{"go"}}, u1)
+	check(&kcd.FindFilter{Revisions: []string{"r1"}, Corpus: []string{"c1", "c2"}}, sorted(u1, u2)...)
+	check(&kcd.FindFilter{Outputs: []*regexp.Regexp{regexp.MustCompile(`out/a\..*`)}}, sorted(u1, u3)...)
+	check(&kcd.FindFilter{Languages: []string{"c++"}, Corpus: []string{"c1"}})
+
+	// Find must not consult the JSON-encoded index records.
+	for _, digest := range []string{u1, u2, u3} {
+		if err := db.update(ctx, func(wr keyvalue.Writer) error {
+			return wr.Write([]byte(idxPrefix+digest), []byte("bogus"))
+		}); err != nil {
+			t.Fatalf("Overwriting index for %q: %v", digest, err)
+		}
+	}
+	check(&kcd.FindFilter{Languages: []string{"java"}}, u3)
+
+	// Deleting a unit removes its index terms.
+	u4 := write("r3", "c3", "go", "out/c.a")
+	if err := db.DeleteUnit(ctx, u4); err != nil {
+		t.Fatalf("DeleteUnit: %v", err)
+	}
+	for _, key := range []string{RevisionKey, CorpusKey, LanguageKey, OutputKey} {
+		if keys, err := db.keys(ctx, termPrefix(key)); err != nil {
+			t.Errorf("Scanning %q terms: %v", key, err)
+		} else {
+			for _, tkey := range keys {
+				if _, digest, _ := decodeTerm(key, tkey); digest == u4 {
+					t.Errorf("Index term %q remains after DeleteUnit", tkey)
+				}
+			}
+		}
+	}
+}
+
+func sorted(ss ...string) []string {
+	sort.Strings(ss)
+	return ss
+}
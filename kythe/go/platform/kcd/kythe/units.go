@@ -135,6 +135,16 @@ func ConvertUnit(v interface{}) (kcd.Unit, bool) {
 	return nil, false
 }
 
+// DecodeUnit decodes a Kythe compilation from its binary encoding, as produced
+// by the MarshalBinary method of Unit.
+func DecodeUnit(data []byte) (kcd.Unit, error) {
+	var pb apb.CompilationUnit
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return nil, err
+	}
+	return Unit{&pb}, nil
+}
+
 type byDigest []*apb.CompilationUnit_FileInput
 
 func (b byDigest) Len() int           { return len(b) }
@@ -0,0 +1,518 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rpcdb exposes a Kythe compilation database as a CompilationDatabase
+// gRPC service, and implements the kcd interfaces as a client of that service.
+//
+// Addresses of the form "unix:/path/to/socket" denote Unix-domain sockets for
+// both Listen and Dial; any other address is treated as a TCP host:port.
+package rpcdb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	kpb "kythe.io/kythe/proto/kcd_go_proto"
+)
+
+// maxMessageSize is the maximum size of a message accepted by the client.
+// Units and files may easily exceed gRPC's default limit of 4MB.
+const maxMessageSize = 256 * 1024 * 1024
+
+const unixPrefix = "unix:"
+
+// Listen returns a listener for the given address, which may denote a
+// Unix-domain socket.
+func Listen(addr string) (net.Listener, error) {
+	if path := strings.TrimPrefix(addr, unixPrefix); path != addr {
+		return net.Listen("unix", path)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// Dial returns a connection to the CompilationDatabase service at the given
+// address, which may denote a Unix-domain socket.
+func Dial(addr string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMessageSize),
+			grpc.MaxCallSendMsgSize(maxMessageSize)),
+	}, opts...)
+	if path := strings.TrimPrefix(addr, unixPrefix); path != addr {
+		opts = append(opts, grpc.WithDialer(func(_ string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", path, timeout)
+		}))
+		addr = path
+	}
+	return grpc.Dial(addr, opts...)
+}
+
+// ServerOptions returns the options that should be passed to grpc.NewServer
+// for a server hosting a CompilationDatabase service, so that it accepts
+// messages as large as those sent by a Client.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(maxMessageSize),
+		grpc.MaxSendMsgSize(maxMessageSize),
+	}
+}
+
+// Server implements the CompilationDatabase service using an underlying
+// compilation database.  The database must be safe for concurrent use; see
+// package kythe.io/kythe/go/platform/kcd/locked.
+type Server struct {
+	rd      kcd.Reader
+	wr      kcd.Writer
+	del     kcd.Deleter
+	formats map[string]UnitDecoder
+}
+
+// A UnitDecoder decodes a compilation unit from its binary encoding.
+type UnitDecoder func(data []byte) (kcd.Unit, error)
+
+// NewServer returns a Server that delegates to db.  If db does not implement
+// kcd.Writer or kcd.Deleter, the corresponding methods of the server report
+// an Unimplemented error.  The server accepts units in the kythe.Format
+// format; use RegisterFormat to accept others.
+func NewServer(db kcd.Reader) *Server {
+	s := &Server{
+		rd:      db,
+		formats: map[string]UnitDecoder{kythe.Format: kythe.DecodeUnit},
+	}
+	if wr, ok := db.(kcd.Writer); ok {
+		s.wr = wr
+	}
+	if del, ok := db.(kcd.Deleter); ok {
+		s.del = del
+	}
+	return s
+}
+
+// RegisterFormat causes s to accept units with the given format key, decoding
+// them with dec.  It must be called before s begins serving requests.
+func (s *Server) RegisterFormat(formatKey string, dec UnitDecoder) { s.formats[formatKey] = dec }
+
+// Revisions implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) Revisions(req *kpb.RevisionsRequest, stream kpb.CompilationDatabase_RevisionsServer) error {
+	filter := &kcd.RevisionsFilter{
+		Revision: req.Revision,
+		Corpus:   req.Corpus,
+		Until:    fromNanos(req.UntilNanos),
+		Since:    fromNanos(req.SinceNanos),
+	}
+	return statusError(s.rd.Revisions(stream.Context(), filter, func(rev kcd.Revision) error {
+		return stream.Send(&kpb.Revision{
+			Revision:       rev.Revision,
+			Corpus:         rev.Corpus,
+			TimestampNanos: toNanos(rev.Timestamp),
+		})
+	}))
+}
+
+// Find implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) Find(req *kpb.FindRequest, stream kpb.CompilationDatabase_FindServer) error {
+	filter := &kcd.FindFilter{
+		Revisions: req.Revisions,
+		Languages: req.Languages,
+		Corpus:    req.Corpus,
+	}
+	var err error
+	if filter.Targets, err = compileAll(req.Targets); err != nil {
+		return err
+	} else if filter.Sources, err = compileAll(req.Sources); err != nil {
+		return err
+	} else if filter.Outputs, err = compileAll(req.Outputs); err != nil {
+		return err
+	}
+	return statusError(s.rd.Find(stream.Context(), filter, func(digest string) error {
+		return stream.Send(&kpb.FindReply{Digest: digest})
+	}))
+}
+
+// Units implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) Units(req *kpb.UnitsRequest, stream kpb.CompilationDatabase_UnitsServer) error {
+	return statusError(s.rd.Units(stream.Context(), req.Digests, func(digest, key string, data []byte) error {
+		return stream.Send(&kpb.Unit{Digest: digest, FormatKey: key, Data: data})
+	}))
+}
+
+// Files implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) Files(req *kpb.FilesRequest, stream kpb.CompilationDatabase_FilesServer) error {
+	return statusError(s.rd.Files(stream.Context(), req.Digests, func(digest string, data []byte) error {
+		return stream.Send(&kpb.File{Digest: digest, Data: data})
+	}))
+}
+
+// FilesExist implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) FilesExist(req *kpb.FilesRequest, stream kpb.CompilationDatabase_FilesExistServer) error {
+	return statusError(s.rd.FilesExist(stream.Context(), req.Digests, func(digest string) error {
+		return stream.Send(&kpb.File{Digest: digest})
+	}))
+}
+
+// WriteRevision implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) WriteRevision(ctx context.Context, req *kpb.WriteRevisionRequest) (*kpb.WriteReply, error) {
+	if s.wr == nil {
+		return nil, status.Error(codes.Unimplemented, "database is not writable")
+	} else if req.Revision == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing revision")
+	}
+	rev := kcd.Revision{
+		Revision:  req.Revision.Revision,
+		Corpus:    req.Revision.Corpus,
+		Timestamp: fromNanos(req.Revision.TimestampNanos),
+	}
+	if err := s.wr.WriteRevision(ctx, rev, req.Replace); err != nil {
+		return nil, statusError(err)
+	}
+	return &kpb.WriteReply{}, nil
+}
+
+// WriteUnit implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) WriteUnit(ctx context.Context, req *kpb.WriteUnitRequest) (*kpb.WriteReply, error) {
+	if s.wr == nil {
+		return nil, status.Error(codes.Unimplemented, "database is not writable")
+	}
+	dec, ok := s.formats[req.FormatKey]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown unit format %q", req.FormatKey)
+	}
+
+	// Decode the unit rather than storing the encoding supplied by the
+	// client, and check that the client agrees on its digest.
+	unit, err := dec(req.Data)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid unit: %v", err)
+	}
+	unit.Canonicalize()
+	if want, got := kcd.UnitDigest(unit), kcd.HexDigest(req.DigestData); got != want {
+		return nil, status.Errorf(codes.InvalidArgument, "unit digest mismatch: got %q, want %q", got, want)
+	}
+	digest, err := s.wr.WriteUnit(ctx, req.Revision, req.Corpus, req.FormatKey, unit)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &kpb.WriteReply{Digest: digest}, nil
+}
+
+// WriteFile implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) WriteFile(ctx context.Context, req *kpb.File) (*kpb.WriteReply, error) {
+	if s.wr == nil {
+		return nil, status.Error(codes.Unimplemented, "database is not writable")
+	}
+	digest, err := s.wr.WriteFile(ctx, bytes.NewReader(req.Data))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &kpb.WriteReply{Digest: digest}, nil
+}
+
+// DeleteUnit implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) DeleteUnit(ctx context.Context, req *kpb.DeleteRequest) (*kpb.DeleteReply, error) {
+	if s.del == nil {
+		return nil, status.Error(codes.Unimplemented, "database does not support deletion")
+	} else if err := s.del.DeleteUnit(ctx, req.Digest); err != nil {
+		return nil, statusError(err)
+	}
+	return &kpb.DeleteReply{}, nil
+}
+
+// DeleteFile implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) DeleteFile(ctx context.Context, req *kpb.DeleteRequest) (*kpb.DeleteReply, error) {
+	if s.del == nil {
+		return nil, status.Error(codes.Unimplemented, "database does not support deletion")
+	} else if err := s.del.DeleteFile(ctx, req.Digest); err != nil {
+		return nil, statusError(err)
+	}
+	return &kpb.DeleteReply{}, nil
+}
+
+// DeleteRevision implements part of the kpb.CompilationDatabaseServer interface.
+func (s *Server) DeleteRevision(ctx context.Context, req *kpb.DeleteRequest) (*kpb.DeleteReply, error) {
+	if s.del == nil {
+		return nil, status.Error(codes.Unimplemented, "database does not support deletion")
+	} else if err := s.del.DeleteRevision(ctx, req.Revision, req.Corpus); err != nil {
+		return nil, statusError(err)
+	}
+	return &kpb.DeleteReply{}, nil
+}
+
+// Client implements kcd.ReadWriteDeleter as a client of a CompilationDatabase
+// service.
+type Client struct{ c kpb.CompilationDatabaseClient }
+
+// NewClient returns a Client that delegates to the given service client.
+func NewClient(c kpb.CompilationDatabaseClient) *Client { return &Client{c} }
+
+// Revisions implements a method of kcd.Reader.
+func (c *Client) Revisions(ctx context.Context, want *kcd.RevisionsFilter, f func(kcd.Revision) error) error {
+	req := new(kpb.RevisionsRequest)
+	if want != nil {
+		req.Revision = want.Revision
+		req.Corpus = want.Corpus
+		req.UntilNanos = toNanos(want.Until)
+		req.SinceNanos = toNanos(want.Since)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.Revisions(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		rev, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(kcd.Revision{
+			Revision:  rev.Revision,
+			Corpus:    rev.Corpus,
+			Timestamp: fromNanos(rev.TimestampNanos),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// Find implements a method of kcd.Reader.
+func (c *Client) Find(ctx context.Context, filter *kcd.FindFilter, f func(string) error) error {
+	if filter.IsEmpty() {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.Find(ctx, &kpb.FindRequest{
+		Revisions: filter.Revisions,
+		Languages: filter.Languages,
+		Corpus:    filter.Corpus,
+		Targets:   exprs(filter.Targets),
+		Sources:   exprs(filter.Sources),
+		Outputs:   exprs(filter.Outputs),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		rep, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(rep.Digest); err != nil {
+			return err
+		}
+	}
+}
+
+// Units implements a method of kcd.Reader.
+func (c *Client) Units(ctx context.Context, unitDigests []string, f func(digest, key string, data []byte) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.Units(ctx, &kpb.UnitsRequest{Digests: unitDigests})
+	if err != nil {
+		return err
+	}
+	for {
+		unit, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(unit.Digest, unit.FormatKey, unit.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// Files implements a method of kcd.Reader.
+func (c *Client) Files(ctx context.Context, fileDigests []string, f func(string, []byte) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.Files(ctx, &kpb.FilesRequest{Digests: fileDigests})
+	if err != nil {
+		return err
+	}
+	for {
+		file, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(file.Digest, file.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// FilesExist implements a method of kcd.Reader.
+func (c *Client) FilesExist(ctx context.Context, fileDigests []string, f func(string) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.c.FilesExist(ctx, &kpb.FilesRequest{Digests: fileDigests})
+	if err != nil {
+		return err
+	}
+	for {
+		file, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(file.Digest); err != nil {
+			return err
+		}
+	}
+}
+
+// WriteRevision implements a method of kcd.Writer.
+func (c *Client) WriteRevision(ctx context.Context, rev kcd.Revision, replace bool) error {
+	_, err := c.c.WriteRevision(ctx, &kpb.WriteRevisionRequest{
+		Revision: &kpb.Revision{
+			Revision:       rev.Revision,
+			Corpus:         rev.Corpus,
+			TimestampNanos: toNanos(rev.Timestamp),
+		},
+		Replace: replace,
+	})
+	return err
+}
+
+// WriteUnit implements a method of kcd.Writer.  The unit is canonicalized and
+// encoded locally; the server decodes it and verifies its digest, so its
+// format must be known to the server.
+func (c *Client) WriteUnit(ctx context.Context, revision, corpus, formatKey string, unit kcd.Unit) (string, error) {
+	unit.Canonicalize()
+	data, err := unit.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	var digest bytes.Buffer
+	unit.Digest(&digest)
+	rep, err := c.c.WriteUnit(ctx, &kpb.WriteUnitRequest{
+		Revision:   revision,
+		Corpus:     corpus,
+		FormatKey:  formatKey,
+		Data:       data,
+		DigestData: digest.Bytes(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return rep.Digest, nil
+}
+
+// WriteFile implements a method of kcd.Writer.
+func (c *Client) WriteFile(ctx context.Context, r io.Reader) (string, error) {
+	var data bytes.Buffer
+	if _, err := io.Copy(&data, r); err != nil {
+		return "", err
+	}
+	rep, err := c.c.WriteFile(ctx, &kpb.File{Data: data.Bytes()})
+	if err != nil {
+		return "", err
+	}
+	return rep.Digest, nil
+}
+
+// DeleteUnit implements a method of kcd.Deleter.
+func (c *Client) DeleteUnit(ctx context.Context, unitDigest string) error {
+	_, err := c.c.DeleteUnit(ctx, &kpb.DeleteRequest{Digest: unitDigest})
+	return notExist(err)
+}
+
+// DeleteFile implements a method of kcd.Deleter.
+func (c *Client) DeleteFile(ctx context.Context, fileDigest string) error {
+	_, err := c.c.DeleteFile(ctx, &kpb.DeleteRequest{Digest: fileDigest})
+	return notExist(err)
+}
+
+// DeleteRevision implements a method of kcd.Deleter.
+func (c *Client) DeleteRevision(ctx context.Context, revision, corpus string) error {
+	_, err := c.c.DeleteRevision(ctx, &kpb.DeleteRequest{Revision: revision, Corpus: corpus})
+	return notExist(err)
+}
+
+// statusError converts errors satisfying os.IsNotExist into NotFound errors;
+// other errors are returned unchanged.
+func statusError(err error) error {
+	if os.IsNotExist(err) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+// notExist converts NotFound errors into os.ErrNotExist; other errors are
+// returned unchanged.
+func notExist(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return os.ErrNotExist
+	}
+	return err
+}
+
+func compileAll(exprs []string) ([]*regexp.Regexp, error) {
+	var res []*regexp.Regexp
+	for _, expr := range exprs {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid regexp %q: %v", expr, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+func exprs(res []*regexp.Regexp) []string {
+	var exprs []string
+	for _, re := range res {
+		exprs = append(exprs, re.String())
+	}
+	return exprs
+}
+
+func toNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromNanos(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos).In(time.UTC)
+}
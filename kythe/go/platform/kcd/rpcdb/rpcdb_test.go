@@ -0,0 +1,166 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpcdb
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/kythe"
+	"kythe.io/kythe/go/platform/kcd/locked"
+	"kythe.io/kythe/go/platform/kcd/memdb"
+	"kythe.io/kythe/go/platform/kcd/testutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apb "kythe.io/kythe/proto/analysis_go_proto"
+	kpb "kythe.io/kythe/proto/kcd_go_proto"
+)
+
+// serve starts a CompilationDatabase service for db on a Unix-domain socket
+// and returns a client connected to it, along with a function to shut both
+// down.
+func serve(t *testing.T, db kcd.Reader) (*Client, func()) {
+	dir, err := ioutil.TempDir("", "rpcdb")
+	if err != nil {
+		t.Fatalf("Unable to create temp directory: %v", err)
+	}
+	addr := unixPrefix + filepath.Join(dir, "kcd.sock")
+	l, err := Listen(addr)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("Listen(%q) failed: %v", addr, err)
+	}
+	srv := grpc.NewServer(ServerOptions()...)
+	s := NewServer(db)
+	s.RegisterFormat(testutil.FormatKey, kythe.DecodeUnit)
+	kpb.RegisterCompilationDatabaseServer(srv, s)
+	go srv.Serve(l)
+
+	conn, err := Dial(addr)
+	if err != nil {
+		srv.Stop()
+		os.RemoveAll(dir)
+		t.Fatalf("Dial(%q) failed: %v", addr, err)
+	}
+	return NewClient(kpb.NewCompilationDatabaseClient(conn)), func() {
+		conn.Close()
+		srv.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestClient(t *testing.T) {
+	client, stop := serve(t, locked.ReadWriteDeleter(new(memdb.DB)))
+	defer stop()
+
+	for _, err := range testutil.Run(context.Background(), client) {
+		t.Error(err)
+	}
+}
+
+func TestCallbackError(t *testing.T) {
+	client, stop := serve(t, locked.ReadWriteDeleter(new(memdb.DB)))
+	defer stop()
+
+	ctx := context.Background()
+	for _, rev := range []string{"1", "2", "3"} {
+		if err := client.WriteRevision(ctx, kcd.Revision{Revision: rev, Corpus: "c"}, false); err != nil {
+			t.Fatalf("WriteRevision(%q) failed: %v", rev, err)
+		}
+	}
+
+	stop1 := errors.New("stop")
+	var n int
+	if err := client.Revisions(ctx, nil, func(kcd.Revision) error {
+		n++
+		return stop1
+	}); err != stop1 {
+		t.Errorf("Revisions: got error %v, want %v", err, stop1)
+	}
+	if n != 1 {
+		t.Errorf("Revisions: got %d callbacks, want 1", n)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	// Hide the write and delete methods of the underlying database.
+	client, stop := serve(t, struct{ kcd.Reader }{locked.Reader(new(memdb.DB))})
+	defer stop()
+
+	ctx := context.Background()
+	if _, err := client.WriteFile(ctx, strings.NewReader("abc")); status.Code(err) != codes.Unimplemented {
+		t.Errorf("WriteFile: got error %v, want unimplemented", err)
+	}
+	if err := client.DeleteFile(ctx, "none such"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("DeleteFile: got error %v, want unimplemented", err)
+	}
+}
+
+func TestWriteUnitChecked(t *testing.T) {
+	client, stop := serve(t, locked.ReadWriteDeleter(new(memdb.DB)))
+	defer stop()
+
+	ctx := context.Background()
+	unit := kythe.Unit{Proto: &apb.CompilationUnit{OutputKey: "out"}}
+	data, err := unit.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Marshaling unit failed: %v", err)
+	}
+
+	// The digest data must match the digest of the unit encoded by data.
+	if _, err := client.c.WriteUnit(ctx, &kpb.WriteUnitRequest{
+		Revision:   "1",
+		Corpus:     "c",
+		FormatKey:  kythe.Format,
+		Data:       data,
+		DigestData: []byte("not the digest of data"),
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("WriteUnit with mismatched digest: got error %v, want invalid argument", err)
+	}
+
+	// The server must be able to decode the format.
+	if _, err := client.WriteUnit(ctx, "1", "c", "bogus", unit); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("WriteUnit with unknown format: got error %v, want invalid argument", err)
+	}
+
+	// The index is computed by the server, not supplied by the client.
+	digest, err := client.WriteUnit(ctx, "1", "c", kythe.Format, unit)
+	if err != nil {
+		t.Fatalf("WriteUnit failed: %v", err)
+	} else if want := kcd.UnitDigest(unit); digest != want {
+		t.Errorf("WriteUnit: got digest %q, want %q", digest, want)
+	}
+	var found []string
+	if err := client.Find(ctx, &kcd.FindFilter{Outputs: []*regexp.Regexp{regexp.MustCompile("^out$")}}, func(digest string) error {
+		found = append(found, digest)
+		return nil
+	}); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	} else if len(found) != 1 || found[0] != digest {
+		t.Errorf("Find: got %q, want [%q]", found, digest)
+	}
+}
@@ -51,6 +51,10 @@
 //   w, err := kzip.NewWriter(file)
 //   ...
 //
+//   // Or, to store compilation records as binary protobuf messages.
+//   w, err := kzip.NewWriter(file, kzip.WithEncoding(kzip.EncodingProto))
+//   ...
+//
 //   // Add a compilation record and (optional) index data.
 //   udigest, err := w.AddUnit(unit, nil)
 //   ...
@@ -82,6 +86,7 @@ import (
 
 	"bitbucket.org/creachadair/stringset"
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"golang.org/x/sync/errgroup"
 
 	apb "kythe.io/kythe/proto/analysis_go_proto"
@@ -95,6 +100,64 @@ import (
 	_ "kythe.io/kythe/proto/java_go_proto"
 )
 
+// Encoding describes how compilation records are encoded in a kzip archive.
+type Encoding int
+
+const (
+	// EncodingJSON stores compilation records as JSON in the "units" directory.
+	EncodingJSON Encoding = 1
+
+	// EncodingProto stores compilation records as binary protobuf messages in
+	// the "pbunits" directory.
+	EncodingProto Encoding = 2
+
+	// EncodingAll stores compilation records in every known encoding.
+	EncodingAll = EncodingJSON | EncodingProto
+
+	prefixJSON  = "units"
+	prefixProto = "pbunits"
+)
+
+// DefaultEncoding is the encoding used by a Writer when none is specified.
+const DefaultEncoding = EncodingJSON
+
+// EncodingFor returns the Encoding named by s, which is one of "JSON",
+// "PROTO", or "ALL" (case-insensitive).
+func EncodingFor(s string) (Encoding, error) {
+	switch strings.ToUpper(s) {
+	case "JSON":
+		return EncodingJSON, nil
+	case "PROTO":
+		return EncodingProto, nil
+	case "ALL":
+		return EncodingAll, nil
+	}
+	return 0, fmt.Errorf("unknown kzip encoding %q", s)
+}
+
+// String returns the name of e, as accepted by EncodingFor.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingJSON:
+		return "JSON"
+	case EncodingProto:
+		return "PROTO"
+	case EncodingAll:
+		return "ALL"
+	}
+	return fmt.Sprintf("Encoding(%d)", int(e))
+}
+
+// Set implements the flag.Value interface, so that an Encoding can be used
+// directly as a command-line flag.
+func (e *Encoding) Set(s string) error {
+	v, err := EncodingFor(s)
+	if err == nil {
+		*e = v
+	}
+	return err
+}
+
 // A Reader permits reading and scanning compilation records and file contents
 // stored in a .kzip archive. The Lookup and Scan methods are mutually safe for
 // concurrent use by multiple goroutines.
@@ -105,6 +168,11 @@ type Reader struct {
 	// directory, but it's not required by the spec. Use whatever name the
 	// archive actually specifies in the leading directory.
 	root string
+
+	// The directory from which compilation records are read, either
+	// prefixJSON or prefixProto. If both are present, the binary protobuf
+	// encoding is preferred since it is cheaper to decode.
+	unitsPrefix string
 }
 
 // NewReader constructs a new Reader that consumes zip data from r, whose total
@@ -125,13 +193,18 @@ func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
 		return nil, errors.New("archive root is not a directory")
 	}
 
-	return &Reader{
-		zip:  archive,
-		root: archive.File[0].Name,
-	}, nil
+	kr := &Reader{
+		zip:         archive,
+		root:        archive.File[0].Name,
+		unitsPrefix: prefixJSON,
+	}
+	if pbdir := path.Join(kr.root, prefixProto) + "/"; kr.hasPrefix(pbdir) {
+		kr.unitsPrefix = prefixProto
+	}
+	return kr, nil
 }
 
-func (r *Reader) unitPath(digest string) string { return path.Join(r.root, "units", digest) }
+func (r *Reader) unitPath(digest string) string { return path.Join(r.root, r.unitsPrefix, digest) }
 func (r *Reader) filePath(digest string) string { return path.Join(r.root, "files", digest) }
 
 // ErrDigestNotFound is returned when a requested compilation unit or file
@@ -142,7 +215,7 @@ var ErrDigestNotFound = errors.New("digest not found")
 // multiple times.
 var ErrUnitExists = errors.New("unit already exists")
 
-func readUnit(digest string, f *zip.File) (*Unit, error) {
+func (r *Reader) readUnit(digest string, f *zip.File) (*Unit, error) {
 	rc, err := f.Open()
 	if err != nil {
 		return nil, err
@@ -150,7 +223,14 @@ func readUnit(digest string, f *zip.File) (*Unit, error) {
 	defer rc.Close()
 
 	var msg apb.IndexedCompilation
-	if err := jsonpb.Unmarshal(rc, &msg); err != nil {
+	if r.unitsPrefix == prefixProto {
+		bits, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		} else if err := proto.Unmarshal(bits, &msg); err != nil {
+			return nil, err
+		}
+	} else if err := jsonpb.Unmarshal(rc, &msg); err != nil {
 		return nil, err
 	}
 	return &Unit{
@@ -173,13 +253,27 @@ func (r *Reader) firstIndex(prefix string) int {
 	return n
 }
 
+// hasPrefix reports whether any path in the archive begins with prefix.
+func (r *Reader) hasPrefix(prefix string) bool {
+	pos := r.firstIndex(prefix)
+	return pos >= 0 && strings.HasPrefix(r.zip.File[pos].Name, prefix)
+}
+
+// Encoding reports the encoding of the compilation records read by r.
+func (r *Reader) Encoding() Encoding {
+	if r.unitsPrefix == prefixProto {
+		return EncodingProto
+	}
+	return EncodingJSON
+}
+
 // Lookup returns the specified compilation from the archive, if it exists.  If
 // the requested digest is not in the archive, ErrDigestNotFound is returned.
 func (r *Reader) Lookup(unitDigest string) (*Unit, error) {
 	needle := r.unitPath(unitDigest)
 	if pos := r.firstIndex(needle); pos >= 0 {
 		if f := r.zip.File[pos]; f.Name == needle {
-			return readUnit(unitDigest, f)
+			return r.readUnit(unitDigest, f)
 		}
 	}
 	return nil, ErrDigestNotFound
@@ -251,7 +345,7 @@ func (r *Reader) Scan(f func(*Unit) error, opts ...ScanOption) error {
 			defer wg.Done()
 			for file := range files {
 				digest := strings.TrimPrefix(file.Name, prefix)
-				unit, err := readUnit(digest, file)
+				unit, err := r.readUnit(digest, file)
 				if err != nil {
 					return err
 				}
@@ -316,11 +410,22 @@ type Writer struct {
 	fd  stringset.Set // file digests already written
 	ud  stringset.Set // unit digests already written
 	c   io.Closer     // a closer for the underlying writer (may be nil)
+
+	encoding Encoding // how compilation records are stored
+}
+
+// A WriterOption configures optional behavior of a Writer.
+type WriterOption func(*Writer)
+
+// WithEncoding returns a WriterOption that selects how compilation records are
+// encoded in the archive. If e is zero, DefaultEncoding is used.
+func WithEncoding(e Encoding) WriterOption {
+	return func(w *Writer) { w.encoding = e }
 }
 
 // NewWriter constructs a new empty Writer that delivers output to w.  The
 // AddUnit and AddFile methods are safe for use by concurrent goroutines.
-func NewWriter(w io.Writer) (*Writer, error) {
+func NewWriter(w io.Writer, opts ...WriterOption) (*Writer, error) {
 	archive := zip.NewWriter(w)
 	// Create an entry for the root directory, which must be first.
 	root := &zip.FileHeader{
@@ -334,17 +439,27 @@ func NewWriter(w io.Writer) (*Writer, error) {
 	}
 	archive.SetComment("Kythe kzip archive")
 
-	return &Writer{
-		zip: archive,
-		fd:  stringset.New(),
-		ud:  stringset.New(),
-	}, nil
+	kw := &Writer{
+		zip:      archive,
+		fd:       stringset.New(),
+		ud:       stringset.New(),
+		encoding: DefaultEncoding,
+	}
+	for _, opt := range opts {
+		opt(kw)
+	}
+	if kw.encoding == 0 {
+		kw.encoding = DefaultEncoding
+	} else if kw.encoding&^EncodingAll != 0 {
+		return nil, fmt.Errorf("invalid kzip encoding %v", kw.encoding)
+	}
+	return kw, nil
 }
 
 // NewWriteCloser behaves as NewWriter, but arranges that when the *Writer is
 // closed it also closes wc.
-func NewWriteCloser(wc io.WriteCloser) (*Writer, error) {
-	w, err := NewWriter(wc)
+func NewWriteCloser(wc io.WriteCloser, opts ...WriterOption) (*Writer, error) {
+	w, err := NewWriter(wc, opts...)
 	if err == nil {
 		w.c = wc
 	}
@@ -374,15 +489,31 @@ func (w *Writer) AddUnit(cu *apb.CompilationUnit, index *apb.IndexedCompilation_
 		return digest, ErrUnitExists
 	}
 
-	f, err := w.zip.CreateHeader(newFileHeader("root", "units", digest))
-	if err != nil {
-		return "", err
-	}
-	if err := toJSON.Marshal(f, &apb.IndexedCompilation{
+	msg := &apb.IndexedCompilation{
 		Unit:  unit.Proto,
 		Index: index,
-	}); err != nil {
-		return "", err
+	}
+	if w.encoding&EncodingJSON != 0 {
+		f, err := w.zip.CreateHeader(newFileHeader("root", prefixJSON, digest))
+		if err != nil {
+			return "", err
+		}
+		if err := toJSON.Marshal(f, msg); err != nil {
+			return "", err
+		}
+	}
+	if w.encoding&EncodingProto != 0 {
+		bits, err := proto.Marshal(msg)
+		if err != nil {
+			return "", err
+		}
+		f, err := w.zip.CreateHeader(newFileHeader("root", prefixProto, digest))
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.Write(bits); err != nil {
+			return "", err
+		}
 	}
 	w.ud.Add(digest)
 	return digest, nil
@@ -356,3 +356,91 @@ func TestScanConcurrency(t *testing.T) {
 		t.Errorf("Scan found %d units, want %d", numUnits, N)
 	}
 }
+
+func TestEncodings(t *testing.T) {
+	unitIn := &apb.CompilationUnit{
+		VName:      &spb.VName{Corpus: "foo", Language: "bar"},
+		SourceFile: []string{"blodgit"},
+	}
+	indexIn := &apb.IndexedCompilation_Index{
+		Revisions: []string{"a", "b", "c"},
+	}
+	tests := []struct {
+		encoding kzip.Encoding
+		dirs     []string // unit directories expected in the archive
+		read     kzip.Encoding
+	}{
+		{kzip.EncodingJSON, []string{"units"}, kzip.EncodingJSON},
+		{kzip.EncodingProto, []string{"pbunits"}, kzip.EncodingProto},
+		{kzip.EncodingAll, []string{"units", "pbunits"}, kzip.EncodingProto},
+	}
+	for _, test := range tests {
+		t.Run(test.encoding.String(), func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			w, err := kzip.NewWriter(buf, kzip.WithEncoding(test.encoding))
+			if err != nil {
+				t.Fatalf("NewWriter: unexpected error: %v", err)
+			}
+			udigest, err := w.AddUnit(unitIn, indexIn)
+			if err != nil {
+				t.Fatalf("AddUnit: unexpected error: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Writer.Close: unexpected error: %v", err)
+			}
+
+			// Check which unit directories were written.
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("zip.NewReader: unexpected error: %v", err)
+			}
+			var dirs []string
+			for _, f := range zr.File {
+				if strings.HasSuffix(f.Name, "/"+udigest) {
+					dirs = append(dirs, strings.Split(f.Name, "/")[1])
+				}
+			}
+			if got, want := strings.Join(dirs, ","), strings.Join(test.dirs, ","); got != want {
+				t.Errorf("Unit directories: got %q, want %q", got, want)
+			}
+
+			r, err := kzip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("NewReader: unexpected error: %v", err)
+			}
+			if got := r.Encoding(); got != test.read {
+				t.Errorf("Reader encoding: got %v, want %v", got, test.read)
+			}
+			if u, err := r.Lookup(udigest); err != nil {
+				t.Errorf("Lookup %q: unexpected error: %v", udigest, err)
+			} else if !proto.Equal(u.Proto, unitIn) || !proto.Equal(u.Index, indexIn) {
+				t.Errorf("Lookup: got %+v, want unit %+v with index %+v", u, unitIn, indexIn)
+			}
+			var n int
+			if err := r.Scan(func(u *kzip.Unit) error {
+				n++
+				if u.Digest != udigest {
+					return fmt.Errorf("unexpected unit digest %q", u.Digest)
+				}
+				return nil
+			}); err != nil {
+				t.Errorf("Scan failed: %v", err)
+			} else if n != 1 {
+				t.Errorf("Scan found %d units, want 1", n)
+			}
+		})
+	}
+}
+
+func TestEncodingFor(t *testing.T) {
+	for _, e := range []kzip.Encoding{kzip.EncodingJSON, kzip.EncodingProto, kzip.EncodingAll} {
+		for _, s := range []string{e.String(), strings.ToLower(e.String())} {
+			if got, err := kzip.EncodingFor(s); err != nil || got != e {
+				t.Errorf("EncodingFor(%q): got (%v, %v), want (%v, nil)", s, got, err, e)
+			}
+		}
+	}
+	if got, err := kzip.EncodingFor("bogus"); err == nil {
+		t.Errorf("EncodingFor(bogus): got %v, want error", got)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Binary kcd_gc expires old revisions from a compilation database and deletes
+// the units and files that are no longer reachable from a retained revision.
+//
+// Usage:
+//   kcd_gc -db <leveldb-path> -keep_latest 10 [-dry_run]
+//   kcd_gc -server <addr> -max_age 720h [-dry_run]
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"kythe.io/kythe/go/platform/kcd"
+	"kythe.io/kythe/go/platform/kcd/gc"
+	"kythe.io/kythe/go/platform/kcd/kvdb"
+	"kythe.io/kythe/go/platform/kcd/rpcdb"
+
+	kpb "kythe.io/kythe/proto/kcd_go_proto"
+)
+
+var (
+	dbPath     = flag.String("db", "", "Path of a LevelDB compilation database")
+	serverAddr = flag.String("server", "", "Address of a compilation database server")
+	maxAge     = flag.Duration("max_age", 0, "If positive, expire revisions older than this")
+	keepLatest = flag.Int("keep_latest", 0, "If positive, retain only this many of the most recent revisions per corpus")
+	dryRun     = flag.Bool("dry_run", false, "Report what would be deleted without deleting it")
+)
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+
+	var db kcd.ReadWriteDeleter
+	switch {
+	case *dbPath != "" && *serverAddr != "":
+		log.Fatal("Only one of --db and --server may be given")
+	case *dbPath != "":
+		kv, err := kvdb.Open(*dbPath, nil)
+		if err != nil {
+			log.Fatalf("Opening database %q: %v", *dbPath, err)
+		}
+		defer kv.Close(ctx)
+		db = kv
+	case *serverAddr != "":
+		conn, err := rpcdb.Dial(*serverAddr)
+		if err != nil {
+			log.Fatalf("Dialing %q: %v", *serverAddr, err)
+		}
+		defer conn.Close()
+		db = rpcdb.NewClient(kpb.NewCompilationDatabaseClient(conn))
+	default:
+		log.Fatal("One of --db or --server must be given")
+	}
+
+	rep, err := gc.Collect(ctx, db, &gc.Options{
+		MaxAge:     *maxAge,
+		KeepLatest: *keepLatest,
+		DryRun:     *dryRun,
+	})
+	if rep != nil {
+		if _, werr := rep.WriteTo(os.Stdout); werr != nil {
+			log.Printf("Writing report: %v", werr)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Collection failed: %v", err)
+	}
+}
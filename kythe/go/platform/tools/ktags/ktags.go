@@ -27,7 +27,7 @@ import (
 	"strings"
 
 	"kythe.io/kythe/go/services/graph"
-	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/api"
 	"kythe.io/kythe/go/util/flagutil"
 	"kythe.io/kythe/go/util/kytheuri"
 	"kythe.io/kythe/go/util/schema/edges"
@@ -44,12 +44,12 @@ var (
 	ctx = context.Background()
 
 	corpus    = flag.String("corpus", "", "Corpus of the given files")
-	remoteAPI = flag.String("api", "https://xrefs-dot-kythe-repo.appspot.com", "Remote api server")
+	remoteAPI = api.Flag("api", api.CommonDefault, api.CommonFlagUsage)
 )
 
 func init() {
 	flag.Usage = flagutil.SimpleUsage("Emit ctags-formatted lines for the definitions in the given files",
-		"[--api address] <file>...")
+		"[--api spec] <file>...")
 }
 
 // TODO(schroederc): use cross-language facts to determine a node's tag name.
@@ -62,12 +62,12 @@ func main() {
 		flagutil.UsageError("not given any files")
 	}
 
-	xs := xrefs.WebClient(*remoteAPI)
-	gs := graph.WebClient(*remoteAPI)
+	kapi := *remoteAPI
+	defer kapi.Close(ctx)
 
 	for _, file := range flag.Args() {
 		ticket := (&kytheuri.URI{Corpus: *corpus, Path: file}).String()
-		decor, err := xs.Decorations(ctx, &xpb.DecorationsRequest{
+		decor, err := kapi.Decorations(ctx, &xpb.DecorationsRequest{
 			Location:   &xpb.Location{Ticket: ticket},
 			SourceText: true,
 			References: true,
@@ -89,7 +89,7 @@ func main() {
 				continue
 			}
 
-			fields, err := getTagFields(gs, r.TargetTicket)
+			fields, err := getTagFields(kapi, r.TargetTicket)
 			if err != nil {
 				log.Printf("Failed to get tagfields for %q: %v", r.TargetTicket, err)
 			}
@@ -37,8 +37,9 @@ import (
 type createCommand struct {
 	cmdutil.Info
 
-	output string
-	rules  vnameRules
+	output   string
+	rules    vnameRules
+	encoding kzip.Encoding
 
 	uri          kytheURI
 	source       flagutil.StringSet
@@ -63,6 +64,7 @@ occurrence of the flag being appended to the corresponding field in the compilat
 
 Any additional positional arguments are included as arguments in the compilation unit.
 `),
+		encoding: kzip.DefaultEncoding,
 	}
 }
 
@@ -71,6 +73,7 @@ Any additional positional arguments are included as arguments in the compilation
 func (c *createCommand) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.output, "output", "", "Path for output kzip file (required)")
 	fs.Var(&c.rules, "rules", "Path to vnames.json file (optional)")
+	fs.Var(&c.encoding, "encoding", "Encoding of compilation units in the output file: JSON, PROTO, or ALL (optional)")
 
 	fs.Var(&c.uri, "uri", "A Kythe URI naming the compilation unit VName (required)")
 	fs.Var(&c.source, "source_file", "Repeated paths for input source files (required)")
@@ -95,7 +98,7 @@ func (c *createCommand) Execute(ctx context.Context, fs *flag.FlagSet, _ ...inte
 		return c.Fail("missing required -source_file")
 	}
 
-	out, err := openWriter(ctx, c.output)
+	out, err := openWriter(ctx, c.output, c.encoding)
 	if err != nil {
 		return c.Fail("error opening -output: %v", err)
 	}
@@ -130,12 +133,12 @@ func (c *createCommand) Execute(ctx context.Context, fs *flag.FlagSet, _ ...inte
 	return subcommands.ExitSuccess
 }
 
-func openWriter(ctx context.Context, path string) (*kzip.Writer, error) {
+func openWriter(ctx context.Context, path string, encoding kzip.Encoding) (*kzip.Writer, error) {
 	out, err := vfs.Create(ctx, path)
 	if err != nil {
 		return nil, err
 	}
-	return kzip.NewWriteCloser(out)
+	return kzip.NewWriteCloser(out, kzip.WithEncoding(encoding))
 }
 
 type compilationBuilder struct {
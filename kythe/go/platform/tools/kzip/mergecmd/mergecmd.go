@@ -38,14 +38,16 @@ import (
 type mergeCommand struct {
 	cmdutil.Info
 
-	output string
-	append bool
+	output   string
+	append   bool
+	encoding kzip.Encoding
 }
 
 // New creates a new subcommand for merging kzip files.
 func New() subcommands.Command {
 	return &mergeCommand{
-		Info: cmdutil.NewInfo("merge", "merge kzip files", "--output path kzip-file*"),
+		Info:     cmdutil.NewInfo("merge", "merge kzip files", "--output path kzip-file*"),
+		encoding: kzip.DefaultEncoding,
 	}
 }
 
@@ -54,6 +56,7 @@ func New() subcommands.Command {
 func (c *mergeCommand) SetFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.output, "output", "", "Path to output kzip file")
 	fs.BoolVar(&c.append, "append", false, "Whether to additionally merge the contents of the existing output file, if it exists")
+	fs.Var(&c.encoding, "encoding", "Encoding of compilation units in the output file (JSON, PROTO, or ALL)")
 }
 
 // Execute implements the subcommands interface and merges the provided files.
@@ -86,7 +89,7 @@ func (c *mergeCommand) Execute(ctx context.Context, fs *flag.FlagSet, _ ...inter
 			}
 		}
 	}
-	if err := mergeArchives(ctx, tmpOut, archives); err != nil {
+	if err := mergeArchives(ctx, tmpOut, archives, kzip.WithEncoding(c.encoding)); err != nil {
 		return c.Fail("Error merging archives: %v", err)
 	}
 	if err := vfs.Rename(ctx, tmpName, c.output); err != nil {
@@ -95,8 +98,8 @@ func (c *mergeCommand) Execute(ctx context.Context, fs *flag.FlagSet, _ ...inter
 	return subcommands.ExitSuccess
 }
 
-func mergeArchives(ctx context.Context, out io.WriteCloser, archives []string) error {
-	wr, err := kzip.NewWriteCloser(out)
+func mergeArchives(ctx context.Context, out io.WriteCloser, archives []string, opts ...kzip.WriterOption) error {
+	wr, err := kzip.NewWriteCloser(out, opts...)
 	if err != nil {
 		out.Close()
 		return fmt.Errorf("error creating writer: %v", err)
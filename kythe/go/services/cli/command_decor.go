@@ -22,6 +22,9 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -30,6 +33,7 @@ import (
 	"kythe.io/kythe/go/util/flagutil"
 	"kythe.io/kythe/go/util/kytheuri"
 	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/span"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
 	xpb "kythe.io/kythe/proto/xref_go_proto"
@@ -119,6 +123,7 @@ type decorCommand struct {
 	baseDecorCommand
 	targetDefs       bool
 	dirtyFile        string
+	dirtyRoot        string
 	refFormat        string
 	extendsOverrides bool
 	semanticScopes   bool
@@ -129,8 +134,10 @@ func (decorCommand) Synopsis() string { return "list a file's decorations" }
 func (decorCommand) Usage() string    { return "" }
 func (c *decorCommand) SetFlags(flag *flag.FlagSet) {
 	c.baseDecorCommand.SetFlags(flag)
-	// TODO(schroederc): add option to look for dirty files based on file-ticket path and a directory root
 	flag.StringVar(&c.dirtyFile, "dirty", "", "Send the given file as the dirty buffer for patching references")
+	flag.StringVar(&c.dirtyRoot, "dirty_root", "",
+		`Send the file at the requested file ticket's path, relative to this directory, as the dirty buffer for patching references.
+      If the file does not exist, no dirty buffer is sent.`)
 	flag.StringVar(&c.refFormat, "format", "@edgeKind@\t@^line@:@^col@-@$line@:@$col@\t@targetKind@\t@target@\t@targetDef@",
 		`Format for each decoration result.
       Format Markers:
@@ -163,17 +170,12 @@ func (c decorCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 		facts.NodeKind,
 		facts.Subkind,
 	}
-	if c.dirtyFile != "" {
-		f, err := vfs.Open(ctx, c.dirtyFile)
+	if c.dirtyFile != "" && c.dirtyRoot != "" {
+		return errors.New("--dirty and --dirty_root are mutually exclusive")
+	} else if c.dirtyFile != "" || c.dirtyRoot != "" {
+		buf, err := c.dirtyBuffer(ctx, req.Location.Ticket)
 		if err != nil {
-			return fmt.Errorf("error opening dirty buffer file at %q: %v", c.dirtyFile, err)
-		}
-		buf, err := ioutil.ReadAll(f)
-		if err != nil {
-			f.Close()
-			return fmt.Errorf("error reading dirty buffer file: %v", err)
-		} else if err := f.Close(); err != nil {
-			return fmt.Errorf("error closing dirty buffer file: %v", err)
+			return err
 		}
 		req.DirtyBuffer = buf
 	}
@@ -184,7 +186,87 @@ func (c decorCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 		return err
 	}
 
-	return c.displayDecorations(reply)
+	if err := c.displayDecorations(reply); err != nil {
+		return err
+	}
+	if len(req.DirtyBuffer) > 0 {
+		return c.reportPatching(ctx, api, req)
+	}
+	return nil
+}
+
+// dirtyBuffer returns the contents of the dirty buffer to send for the given
+// file ticket.  With --dirty_root, the file is found by joining the root with
+// the ticket's path; if it doesn't exist, nil is returned.
+func (c decorCommand) dirtyBuffer(ctx context.Context, ticket string) ([]byte, error) {
+	path := c.dirtyFile
+	if c.dirtyRoot != "" {
+		uri, err := kytheuri.Parse(ticket)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file ticket %q: %v", ticket, err)
+		}
+		path = filepath.Join(c.dirtyRoot, filepath.FromSlash(uri.Path))
+	}
+
+	f, err := vfs.Open(ctx, path)
+	if c.dirtyRoot != "" && os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error opening dirty buffer file at %q: %v", path, err)
+	}
+	buf, err := ioutil.ReadAll(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("error reading dirty buffer file: %v", err)
+	} else if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("error closing dirty buffer file: %v", err)
+	}
+	return buf, nil
+}
+
+// reportPatching logs a report of each reference in the file's
+// unpatched decorations that was dropped or shifted by patching it into the
+// request's dirty buffer.
+func (c decorCommand) reportPatching(ctx context.Context, api API, dirty *xpb.DecorationsRequest) error {
+	req := &xpb.DecorationsRequest{
+		Location:    &xpb.Location{Ticket: dirty.Location.Ticket},
+		References:  true,
+		SourceText:  true,
+		BuildConfig: dirty.BuildConfig,
+		Filter:      []string{facts.NodeKind},
+	}
+	LogRequest(req)
+	reply, err := api.XRefService.Decorations(ctx, req)
+	if err != nil {
+		return fmt.Errorf("error requesting unpatched decorations: %v", err)
+	}
+
+	patcher := span.NewPatcher(reply.SourceText, dirty.DirtyBuffer)
+	norm := span.NewNormalizer(dirty.DirtyBuffer)
+	var unchanged, shifted, dropped int
+	for _, ref := range reply.Reference {
+		start, end, exists := patcher.PatchSpan(ref.Span)
+		switch {
+		case !exists:
+			dropped++
+			log.Printf("Dropped\t%s\t%s\t%s", ref.Kind, spanString(ref.Span), ref.TargetTicket)
+		case start != ref.Span.GetStart().GetByteOffset() || end != ref.Span.GetEnd().GetByteOffset():
+			shifted++
+			log.Printf("Shifted\t%s\t%s -> %s\t%s", ref.Kind,
+				spanString(ref.Span), spanString(norm.SpanOffsets(start, end)), ref.TargetTicket)
+		default:
+			unchanged++
+		}
+	}
+	log.Printf("Dirty buffer: %d references unchanged, %d shifted, %d dropped", unchanged, shifted, dropped)
+	return nil
+}
+
+// spanString returns the given span formatted as "line:col-line:col".
+func spanString(s *cpb.Span) string {
+	return fmt.Sprintf("%d:%d-%d:%d",
+		s.GetStart().GetLineNumber(), s.GetStart().GetColumnOffset(),
+		s.GetEnd().GetLineNumber(), s.GetEnd().GetColumnOffset())
 }
 
 func (c decorCommand) displayDecorations(decor *xpb.DecorationsReply) error {
@@ -27,7 +27,10 @@ import (
 )
 
 type identCommand struct {
-	corpora, languages string
+	corpora, languages, kinds string
+	match                     string
+	ignoreCase                bool
+	maxMatches                int
 }
 
 func (identCommand) Name() string     { return "identifier" }
@@ -36,6 +39,10 @@ func (identCommand) Usage() string    { return "<identifier>" }
 func (c *identCommand) SetFlags(flag *flag.FlagSet) {
 	flag.StringVar(&c.corpora, "corpora", "", "Comma-separated list of corpora with which to restrict matches")
 	flag.StringVar(&c.languages, "languages", "", "Comma-separated list of languages with which to restrict matches")
+	flag.StringVar(&c.kinds, "kinds", "", "Comma-separated list of node kinds with which to restrict matches")
+	flag.StringVar(&c.match, "match", "exact", "How to match the identifier (modes: exact, prefix, substring, or camel)")
+	flag.BoolVar(&c.ignoreCase, "ignore_case", false, "Whether to ignore letter case when matching the identifier")
+	flag.IntVar(&c.maxMatches, "max_matches", 0, "Maximum number of matches returned (0 lets the service use a sensible default)")
 }
 func (c identCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) error {
 	if flag.NArg() == 0 {
@@ -45,7 +52,9 @@ func (c identCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 	}
 
 	req := &ipb.FindRequest{
-		Identifier: flag.Arg(0),
+		Identifier:      flag.Arg(0),
+		CaseInsensitive: c.ignoreCase,
+		MaxMatches:      int32(c.maxMatches),
 	}
 	if c.corpora != "" {
 		req.Corpus = strings.Split(c.corpora, ",")
@@ -53,6 +62,21 @@ func (c identCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 	if c.languages != "" {
 		req.Languages = strings.Split(c.languages, ",")
 	}
+	if c.kinds != "" {
+		req.NodeKind = strings.Split(c.kinds, ",")
+	}
+	switch c.match {
+	case "exact":
+		req.MatchMode = ipb.FindRequest_EXACT
+	case "prefix":
+		req.MatchMode = ipb.FindRequest_PREFIX
+	case "substring":
+		req.MatchMode = ipb.FindRequest_SUBSTRING
+	case "camel":
+		req.MatchMode = ipb.FindRequest_CAMEL_HUMP
+	default:
+		return fmt.Errorf("unknown match mode: %q", c.match)
+	}
 
 	LogRequest(req)
 	reply, err := api.IdentifierService.Find(ctx, req)
@@ -73,7 +97,7 @@ func (c identCommand) displayMatches(reply *ipb.FindReply) error {
 		if m.NodeSubkind != "" {
 			kind += "/" + m.NodeSubkind
 		}
-		fmt.Printf("%s [kind: %s]\n", m.Ticket, kind)
+		fmt.Printf("%s [kind: %s] %s (%d refs)\n", m.Ticket, kind, m.QualifiedName, m.RefCount)
 	}
 	return nil
 }
@@ -19,6 +19,14 @@ package explore
 
 import (
 	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"kythe.io/kythe/go/services/web"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	epb "kythe.io/kythe/proto/explore_go_proto"
 )
@@ -64,3 +72,144 @@ type BoundedRequests struct {
 	MaxTickets int
 	Service
 }
+
+// Callers implements part of the Service interface.
+func (b BoundedRequests) Callers(ctx context.Context, req *epb.CallersRequest) (*epb.CallersReply, error) {
+	if len(req.Tickets) > b.MaxTickets {
+		return nil, status.Errorf(codes.InvalidArgument, "too many tickets requested: %d (max %d)", len(req.Tickets), b.MaxTickets)
+	}
+	return b.Service.Callers(ctx, req)
+}
+
+// Callees implements part of the Service interface.
+func (b BoundedRequests) Callees(ctx context.Context, req *epb.CalleesRequest) (*epb.CalleesReply, error) {
+	if len(req.Tickets) > b.MaxTickets {
+		return nil, status.Errorf(codes.InvalidArgument, "too many tickets requested: %d (max %d)", len(req.Tickets), b.MaxTickets)
+	}
+	return b.Service.Callees(ctx, req)
+}
+
+// Parameters implements part of the Service interface.
+func (b BoundedRequests) Parameters(ctx context.Context, req *epb.ParametersRequest) (*epb.ParametersReply, error) {
+	if len(req.FunctionTickets) > b.MaxTickets {
+		return nil, status.Errorf(codes.InvalidArgument, "too many tickets requested: %d (max %d)", len(req.FunctionTickets), b.MaxTickets)
+	}
+	return b.Service.Parameters(ctx, req)
+}
+
+// Parents implements part of the Service interface.
+func (b BoundedRequests) Parents(ctx context.Context, req *epb.ParentsRequest) (*epb.ParentsReply, error) {
+	if len(req.Tickets) > b.MaxTickets {
+		return nil, status.Errorf(codes.InvalidArgument, "too many tickets requested: %d (max %d)", len(req.Tickets), b.MaxTickets)
+	}
+	return b.Service.Parents(ctx, req)
+}
+
+// Children implements part of the Service interface.
+func (b BoundedRequests) Children(ctx context.Context, req *epb.ChildrenRequest) (*epb.ChildrenReply, error) {
+	if len(req.Tickets) > b.MaxTickets {
+		return nil, status.Errorf(codes.InvalidArgument, "too many tickets requested: %d (max %d)", len(req.Tickets), b.MaxTickets)
+	}
+	return b.Service.Children(ctx, req)
+}
+
+// RegisterHTTPHandlers registers JSON HTTP handlers with mux using the given
+// explore Service.  The following methods with be exposed:
+//
+//   GET /callers
+//     Request: JSON encoded explore.CallersRequest
+//     Response: JSON encoded explore.CallersReply
+//   GET /callees
+//     Request: JSON encoded explore.CalleesRequest
+//     Response: JSON encoded explore.CalleesReply
+//   GET /parents
+//     Request: JSON encoded explore.ParentsRequest
+//     Response: JSON encoded explore.ParentsReply
+//   GET /children
+//     Request: JSON encoded explore.ChildrenRequest
+//     Response: JSON encoded explore.ChildrenReply
+//
+// Note: each method will return its response as a serialized protobuf if the
+// "proto" query parameter is set.
+func RegisterHTTPHandlers(ctx context.Context, es Service, mux *http.ServeMux) {
+	mux.HandleFunc("/callers", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("explore.Callers:\t%s", time.Since(start))
+		}()
+		var req epb.CallersRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := es.Callers(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+	mux.HandleFunc("/callees", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("explore.Callees:\t%s", time.Since(start))
+		}()
+		var req epb.CalleesRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := es.Callees(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+	mux.HandleFunc("/parents", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("explore.Parents:\t%s", time.Since(start))
+		}()
+		var req epb.ParentsRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := es.Parents(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+	mux.HandleFunc("/children", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("explore.Children:\t%s", time.Since(start))
+		}()
+		var req epb.ChildrenRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := es.Children(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+}
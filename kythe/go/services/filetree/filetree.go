@@ -202,6 +202,21 @@ func (w *webClient) Directory(ctx context.Context, req *ftpb.DirectoryRequest) (
 // WebClient returns an filetree Service based on a remote web server.
 func WebClient(addr string) Service { return &webClient{addr} }
 
+type grpcClient struct{ ftpb.FileTreeServiceClient }
+
+// CorpusRoots implements part of the Service interface.
+func (c grpcClient) CorpusRoots(ctx context.Context, req *ftpb.CorpusRootsRequest) (*ftpb.CorpusRootsReply, error) {
+	return c.FileTreeServiceClient.CorpusRoots(ctx, req)
+}
+
+// Directory implements part of the Service interface.
+func (c grpcClient) Directory(ctx context.Context, req *ftpb.DirectoryRequest) (*ftpb.DirectoryReply, error) {
+	return c.FileTreeServiceClient.Directory(ctx, req)
+}
+
+// GRPC returns a filetree Service backed by the given gRPC client.
+func GRPC(c ftpb.FileTreeServiceClient) Service { return grpcClient{c} }
+
 // RegisterHTTPHandlers registers JSON HTTP handlers with mux using the given
 // filetree Service.  The following methods with be exposed:
 //
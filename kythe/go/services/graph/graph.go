@@ -144,6 +144,21 @@ func WebClient(addr string) Service {
 	return &webClient{addr}
 }
 
+type grpcClient struct{ gpb.GraphServiceClient }
+
+// Nodes implements part of the Service interface.
+func (c grpcClient) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	return c.GraphServiceClient.Nodes(ctx, req)
+}
+
+// Edges implements part of the Service interface.
+func (c grpcClient) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	return c.GraphServiceClient.Edges(ctx, req)
+}
+
+// GRPC returns a graph Service backed by the given gRPC client.
+func GRPC(c gpb.GraphServiceClient) Service { return grpcClient{c} }
+
 // RegisterHTTPHandlers registers JSON HTTP handlers with mux using the given
 // graph Service.  The following methods with be exposed:
 //
@@ -21,10 +21,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"regexp"
 	"sort"
 	"time"
 
+	"kythe.io/kythe/go/services/web"
 	"kythe.io/kythe/go/util/kytheuri"
 	"kythe.io/kythe/go/util/schema/edges"
 	"kythe.io/kythe/go/util/schema/facts"
@@ -241,6 +243,45 @@ func (s *Resolver) Resolve(ctx context.Context, req *linkpb.LinkRequest) (*linkp
 	return rsp, nil
 }
 
+// RegisterHTTPHandlers registers a JSON HTTP handler with mux using the given
+// Resolver.  The following method with be exposed:
+//
+//   GET /resolve_link
+//     Request: JSON encoded link.LinkRequest
+//     Response: JSON encoded link.LinkReply
+//
+// Note: /resolve_link will return its response as a serialized protobuf if the
+// "proto" query parameter is set.
+func RegisterHTTPHandlers(ctx context.Context, s *Resolver, mux *http.ServeMux) {
+	mux.HandleFunc("/resolve_link", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("link.Resolve:\t%s", time.Since(start))
+		}()
+		var req linkpb.LinkRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := s.Resolve(ctx, &req)
+		if err != nil {
+			code := http.StatusInternalServerError
+			switch status.Code(err) {
+			case codes.InvalidArgument:
+				code = http.StatusBadRequest
+			case codes.NotFound:
+				code = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+}
+
 // kindMatches reports whether the kind and subkind of m match any of the
 // entries in kinds, which have the form "kind" or "kind/subkind".
 func kindMatches(m *ipb.FindReply_Match, kinds []string) bool {
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package search defines the SearchService interface.
+package search
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"kythe.io/kythe/go/services/web"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spb "kythe.io/kythe/proto/search_go_proto"
+)
+
+// Service defines the interface for the SearchService defined in kythe/proto/search.proto.
+type Service interface {
+	// Search returns the locations in file contents matching the given query.
+	Search(context.Context, *spb.SearchRequest) (*spb.SearchReply, error)
+}
+
+// BoundedRequests guards against requests that ask for so many hits or lines
+// of context that they threaten the functioning of the service (in the absence
+// of server-side throttling of individual requests).
+type BoundedRequests struct {
+	MaxPageSize     int
+	MaxContextLines int
+	Service
+}
+
+// Search implements part of the Service interface.
+func (b BoundedRequests) Search(ctx context.Context, req *spb.SearchRequest) (*spb.SearchReply, error) {
+	if int(req.PageSize) > b.MaxPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size too large: %d (max %d)", req.PageSize, b.MaxPageSize)
+	} else if int(req.ContextLines) > b.MaxContextLines {
+		return nil, status.Errorf(codes.InvalidArgument, "too many context_lines requested: %d (max %d)", req.ContextLines, b.MaxContextLines)
+	}
+	return b.Service.Search(ctx, req)
+}
+
+// RegisterHTTPHandlers registers a JSON HTTP handler with mux using the given
+// search Service.  The following method with be exposed:
+//
+//   GET /search
+//     Request: JSON encoded search.SearchRequest
+//     Response: JSON encoded search.SearchReply
+//
+// Note: /search will return its response as a serialized protobuf if the
+// "proto" query parameter is set.
+func RegisterHTTPHandlers(ctx context.Context, s Service, mux *http.ServeMux) {
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Printf("search.Search:\t%s", time.Since(start))
+		}()
+		var req spb.SearchRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := s.Search(ctx, &req)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if status.Code(err) == codes.InvalidArgument {
+				code = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+}
+
+type webClient struct{ addr string }
+
+// Search implements part of the Service interface.
+func (w *webClient) Search(ctx context.Context, q *spb.SearchRequest) (*spb.SearchReply, error) {
+	var reply spb.SearchReply
+	return &reply, web.Call(w.addr, "search", q, &reply)
+}
+
+// WebClient returns a search Service based on a remote web server.
+func WebClient(addr string) Service {
+	return &webClient{addr}
+}
+
+type grpcClient struct{ spb.SearchServiceClient }
+
+// Search implements part of the Service interface.
+func (c grpcClient) Search(ctx context.Context, req *spb.SearchRequest) (*spb.SearchReply, error) {
+	return c.SearchServiceClient.Search(ctx, req)
+}
+
+// GRPC returns a search Service backed by the given gRPC client.
+func GRPC(c spb.SearchServiceClient) Service { return grpcClient{c} }
@@ -279,6 +279,26 @@ func WebClient(addr string) Service {
 	return &webClient{addr}
 }
 
+type grpcClient struct{ xpb.XRefServiceClient }
+
+// Decorations implements part of the Service interface.
+func (c grpcClient) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	return c.XRefServiceClient.Decorations(ctx, req)
+}
+
+// CrossReferences implements part of the Service interface.
+func (c grpcClient) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	return c.XRefServiceClient.CrossReferences(ctx, req)
+}
+
+// Documentation implements part of the Service interface.
+func (c grpcClient) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	return c.XRefServiceClient.Documentation(ctx, req)
+}
+
+// GRPC returns an xrefs Service backed by the given gRPC client.
+func GRPC(c xpb.XRefServiceClient) Service { return grpcClient{c} }
+
 // RegisterHTTPHandlers registers JSON HTTP handlers with mux using the given
 // xrefs Service.  The following methods with be exposed:
 //
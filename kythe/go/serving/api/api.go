@@ -35,6 +35,8 @@ import (
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/table"
 
+	"google.golang.org/grpc"
+
 	ftpb "kythe.io/kythe/proto/filetree_go_proto"
 	gpb "kythe.io/kythe/proto/graph_go_proto"
 	ipb "kythe.io/kythe/proto/identifier_go_proto"
@@ -57,7 +59,12 @@ const (
 	CommonDefault = "https://xrefs-dot-kythe-repo.appspot.com"
 
 	// CommonFlagUsage is the common Kythe usage description used for Flag
-	CommonFlagUsage = "Backing API specification (e.g. JSON HTTP server: https://xrefs-dot-kythe-repo.appspot.com or local serving table path: /var/kythe_serving)"
+	CommonFlagUsage = "Backing API specification (e.g. JSON HTTP server: https://xrefs-dot-kythe-repo.appspot.com, gRPC server: grpc://localhost:9090, or local serving table path: /var/kythe_serving)"
+
+	// maxGRPCMessageSize is the maximum size of a reply accepted from a gRPC
+	// server.  Decorations replies with source text can easily exceed gRPC's
+	// default limit of 4MB.
+	maxGRPCMessageSize = 256 * 1024 * 1024
 )
 
 // Flag defines an api Interface flag with specified name, default value, and
@@ -74,6 +81,7 @@ func Flag(name, value, usage string) *Interface {
 // API Interface.  The following formats are currently supported:
 //   - http:// URL pointed at a JSON web API
 //   - https:// URL pointed at a JSON web API
+//   - grpc://host:port pointed at a gRPC server (e.g. http_server --grpc_listen)
 //   - local path to a LevelDB serving table
 func ParseSpec(apiSpec string) (Interface, error) {
 	api := &apiCloser{}
@@ -82,6 +90,19 @@ func ParseSpec(apiSpec string) (Interface, error) {
 		api.gs = graph.WebClient(apiSpec)
 		api.ft = filetree.WebClient(apiSpec)
 		api.id = identifiers.WebClient(apiSpec)
+	} else if strings.HasPrefix(apiSpec, "grpc://") {
+		addr := strings.TrimPrefix(apiSpec, "grpc://")
+		conn, err := grpc.Dial(addr, grpc.WithInsecure(),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)))
+		if err != nil {
+			return nil, fmt.Errorf("error dialing gRPC server at %q: %v", addr, err)
+		}
+		api.closer = func(context.Context) error { return conn.Close() }
+
+		api.xs = xrefs.GRPC(xpb.NewXRefServiceClient(conn))
+		api.gs = graph.GRPC(gpb.NewGraphServiceClient(conn))
+		api.ft = filetree.GRPC(ftpb.NewFileTreeServiceClient(conn))
+		api.id = identifiers.GRPC(ipb.NewIdentifierServiceClient(conn))
 	} else if _, err := os.Stat(apiSpec); err == nil {
 		db, err := leveldb.Open(apiSpec, nil)
 		if err != nil {
@@ -94,7 +115,7 @@ func ParseSpec(apiSpec string) (Interface, error) {
 		api.gs = gsrv.NewService(ctx, db)
 		tbl := &table.KVProto{db}
 		api.ft = &ftsrv.Table{tbl, true}
-		api.id = &identifiers.Table{tbl}
+		api.id = &identifiers.Table{tbl, true}
 	} else {
 		return nil, fmt.Errorf("unknown API spec format: %q", apiSpec)
 	}
@@ -22,6 +22,9 @@
 //   <child ticket>    -> srvpb.Relatives (parents)
 //   <called ticket>   -> srvpb.Callgraph (callers)
 //   <calling ticket>  -> srvpb.Callgraph (callees)
+//   <subtype ticket>  -> srvpb.TypeHierarchy (supertypes)
+//   <supertype ticket> -> srvpb.TypeHierarchy (subtypes)
+//   <function ticket> -> srvpb.FunctionParameters
 package explore
 
 import (
@@ -29,13 +32,54 @@ import (
 	"fmt"
 
 	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
 
 	"bitbucket.org/creachadair/stringset"
+	"github.com/golang/protobuf/proto"
 
 	epb "kythe.io/kythe/proto/explore_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
 )
 
+// Key prefixes for the explore tables within a combined serving table.
+const (
+	parentsTablePrefix    = "parents:"
+	childrenTablePrefix   = "children:"
+	callersTablePrefix    = "callers:"
+	calleesTablePrefix    = "callees:"
+	supertypesTablePrefix = "superTypes:"
+	subtypesTablePrefix   = "subTypes:"
+	parametersTablePrefix = "params:"
+)
+
+// ParentsKey returns the combined table key for the parents of the given node
+// ticket.
+func ParentsKey(ticket string) []byte { return []byte(parentsTablePrefix + ticket) }
+
+// ChildrenKey returns the combined table key for the children of the given
+// node ticket.
+func ChildrenKey(ticket string) []byte { return []byte(childrenTablePrefix + ticket) }
+
+// CallersKey returns the combined table key for the callers of the given
+// function ticket.
+func CallersKey(ticket string) []byte { return []byte(callersTablePrefix + ticket) }
+
+// CalleesKey returns the combined table key for the callees of the given
+// function ticket.
+func CalleesKey(ticket string) []byte { return []byte(calleesTablePrefix + ticket) }
+
+// SupertypesKey returns the combined table key for the direct supertypes of
+// the given type ticket.
+func SupertypesKey(ticket string) []byte { return []byte(supertypesTablePrefix + ticket) }
+
+// SubtypesKey returns the combined table key for the direct subtypes of the
+// given type ticket.
+func SubtypesKey(ticket string) []byte { return []byte(subtypesTablePrefix + ticket) }
+
+// ParametersKey returns the combined table key for the parameters of the given
+// function ticket.
+func ParametersKey(ticket string) []byte { return []byte(parametersTablePrefix + ticket) }
+
 // Tables implements the explore.Service interface using separate static lookup tables
 // for each API component.
 type Tables struct {
@@ -52,13 +96,181 @@ type Tables struct {
 	// FunctionToCallees is a table of srvpb.Callgraph keyed by function ticket
 	// that points to the callees of the specified function.
 	FunctionToCallees table.ProtoLookup
+
+	// TypeToSupertypes is a table of srvpb.TypeHierarchy keyed by type ticket
+	// that points to the direct supertypes of the specified type.
+	TypeToSupertypes table.ProtoLookup
+
+	// TypeToSubtypes is a table of srvpb.TypeHierarchy keyed by type ticket
+	// that points to the direct subtypes of the specified type.
+	TypeToSubtypes table.ProtoLookup
+
+	// FunctionToParameters is a table of srvpb.FunctionParameters keyed by
+	// function ticket.
+	FunctionToParameters table.ProtoLookup
+}
+
+// NewCombinedTable returns a Tables reading each explore table from t, a
+// combined serving table, using the key prefixes of the table's *Key
+// functions.
+func NewCombinedTable(t table.ProtoLookup) *Tables {
+	return &Tables{
+		ParentToChildren:     prefixedLookup{t, childrenTablePrefix},
+		ChildToParents:       prefixedLookup{t, parentsTablePrefix},
+		FunctionToCallers:    prefixedLookup{t, callersTablePrefix},
+		FunctionToCallees:    prefixedLookup{t, calleesTablePrefix},
+		TypeToSupertypes:     prefixedLookup{t, supertypesTablePrefix},
+		TypeToSubtypes:       prefixedLookup{t, subtypesTablePrefix},
+		FunctionToParameters: prefixedLookup{t, parametersTablePrefix},
+	}
+}
+
+// prefixedLookup is a table.ProtoLookup that prepends a prefix to each key.
+type prefixedLookup struct {
+	table.ProtoLookup
+	prefix string
+}
+
+// Lookup implements the table.ProtoLookup interface.
+func (p prefixedLookup) Lookup(ctx context.Context, key []byte, msg proto.Message) error {
+	return p.ProtoLookup.Lookup(ctx, append([]byte(p.prefix), key...), msg)
 }
 
 // TypeHierarchy returns the hierarchy (supertypes and subtypes, including implementations)
-// of a specified type, as a directed acyclic graph.
-// TODO: not yet implemented
+// of a specified type, as a directed acyclic graph.  Each edge in the graph
+// points from a subtype to one of its direct supertypes.
 func (t *Tables) TypeHierarchy(ctx context.Context, req *epb.TypeHierarchyRequest) (*epb.TypeHierarchyReply, error) {
-	return nil, nil
+	ticket := req.TypeTicket
+	if ticket == "" {
+		return nil, fmt.Errorf("missing input ticket: %v", req)
+	}
+
+	// succMap maps subtypes onto sets of their direct supertypes
+	succMap := make(map[string]stringset.Set)
+	addEdge := func(sub, super string) bool {
+		if reaches(succMap, super, sub) {
+			return false // the edge would introduce a cycle
+		}
+		if _, ok := succMap[sub]; !ok {
+			succMap[sub] = stringset.New()
+		}
+		set := succMap[sub]
+		return set.Add(super) // false if the edge was already present
+	}
+
+	if err := t.walkTypeHierarchy(ctx, req, t.TypeToSupertypes, srvpb.TypeHierarchy_SUPERTYPES, addEdge); err != nil {
+		return nil, err
+	}
+	if err := t.walkTypeHierarchy(ctx, req, t.TypeToSubtypes, srvpb.TypeHierarchy_SUBTYPES, func(super, sub string) bool {
+		return addEdge(sub, super)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &epb.TypeHierarchyReply{
+		TypeTicket: ticket,
+		Graph:      convertSuccMapToGraph(succMap),
+	}, nil
+}
+
+// walkTypeHierarchy performs a breadth-first traversal of the given type
+// hierarchy table starting at req.TypeTicket, respecting the request's depth,
+// fan-out, and node filter restrictions.  addEdge is called for each traversed
+// relation and should return false if the relation is to be discarded.
+func (t *Tables) walkTypeHierarchy(ctx context.Context, req *epb.TypeHierarchyRequest, tbl table.ProtoLookup, typ srvpb.TypeHierarchy_Type, addEdge func(from, to string) bool) error {
+	depth := map[string]int{req.TypeTicket: 0}
+	queue := []string{req.TypeTicket}
+
+	// At the moment, this is our policy for missing data: if a ticket has no
+	// record in the table, the traversal stops at that ticket.  Other table
+	// access errors result in returning an error.
+	for len(queue) > 0 {
+		ticket := queue[0]
+		queue = queue[1:]
+		if req.MaxDepth > 0 && depth[ticket] >= int(req.MaxDepth) {
+			continue
+		}
+
+		var hierarchy srvpb.TypeHierarchy
+		if err := tbl.Lookup(ctx, []byte(ticket), &hierarchy); err == table.ErrNoSuchKey {
+			continue // skip tickets with no mappings
+		} else if err != nil {
+			return fmt.Errorf("error looking up type hierarchy with ticket %q: %v", ticket, err)
+		}
+
+		// This can only happen in the context of a postprocessor bug.
+		if hierarchy.Type != typ {
+			return fmt.Errorf("type of type hierarchy is not '%s': %v", typ, hierarchy)
+		}
+
+		var followed int
+		for _, related := range hierarchy.Tickets {
+			if req.MaxFanOut > 0 && followed >= int(req.MaxFanOut) {
+				break
+			} else if !matchesNodeFilter(related, req.NodeFilter) {
+				continue
+			}
+			if !addEdge(ticket, related) {
+				continue // discarded relations do not count toward the fan-out
+			}
+			followed++
+			if _, ok := depth[related]; !ok {
+				depth[related] = depth[ticket] + 1
+				queue = append(queue, related)
+			}
+		}
+	}
+	return nil
+}
+
+// reaches reports whether the node to is reachable from the node from in the
+// graph represented by succMap.
+func reaches(succMap map[string]stringset.Set, from, to string) bool {
+	visited := stringset.New()
+	stack := []string{from}
+	for len(stack) > 0 {
+		ticket := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if ticket == to {
+			return true
+		} else if visited.Contains(ticket) {
+			continue
+		}
+		visited.Add(ticket)
+		for succ := range succMap[ticket] {
+			stack = append(stack, succ)
+		}
+	}
+	return false
+}
+
+// matchesNodeFilter reports whether the node with the given ticket satisfies
+// the restrictions of f.  A nil filter matches all nodes.
+func matchesNodeFilter(ticket string, f *epb.NodeFilter) bool {
+	if len(f.GetIncludedLanguages()) == 0 && len(f.GetIncludedFiles()) == 0 {
+		return true
+	}
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil {
+		return false
+	}
+
+	if langs := f.GetIncludedLanguages(); len(langs) != 0 && !stringset.New(langs...).Contains(uri.Language) {
+		return false
+	}
+
+	files := f.GetIncludedFiles()
+	if len(files) == 0 {
+		return true
+	}
+	for _, file := range files {
+		if (file.Corpus == "" || file.Corpus == uri.Corpus) &&
+			(file.Root == "" || file.Root == uri.Root) &&
+			(file.Path == "" || file.Path == uri.Path) {
+			return true
+		}
+	}
+	return false
 }
 
 // Callers returns the callers of a specified function, as a directed graph.
@@ -166,10 +378,58 @@ func (t *Tables) Callees(ctx context.Context, req *epb.CalleesRequest) (*epb.Cal
 	return &epb.CalleesReply{Graph: convertSuccMapToGraph(succMap)}, nil
 }
 
-// Parameters returns the parameters of a specified function.
-// TODO: not yet implemented
+// Parameters returns the parameters of each specified function, along with the
+// node data for the functions and parameters, the type of each parameter, and
+// the return type of each function.
 func (t *Tables) Parameters(ctx context.Context, req *epb.ParametersRequest) (*epb.ParametersReply, error) {
-	return nil, nil
+	tickets := req.FunctionTickets
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("missing input tickets: %v", req)
+	}
+
+	reply := &epb.ParametersReply{
+		FunctionToParameters:  make(map[string]*epb.Tickets),
+		NodeData:              make(map[string]*epb.NodeData),
+		ParameterToType:       make(map[string]string),
+		FunctionToReturnValue: make(map[string]string),
+	}
+
+	// At the moment, this is our policy for missing data: if an input ticket has
+	// no record in the table, we don't include data for that ticket in the response.
+	// Other table access errors result in returning an error.
+	for _, ticket := range stringset.New(tickets...).Elements() {
+		var params srvpb.FunctionParameters
+		if err := t.FunctionToParameters.Lookup(ctx, []byte(ticket), &params); err == table.ErrNoSuchKey {
+			continue // skip tickets with no mappings
+		} else if err != nil {
+			return nil, fmt.Errorf("error looking up parameters with ticket %q: %v", ticket, err)
+		}
+
+		reply.NodeData[ticket] = &epb.NodeData{
+			Kind:    params.Kind,
+			Subkind: params.Subkind,
+			Code:    params.MarkedSource,
+		}
+
+		paramTickets := &epb.Tickets{}
+		for _, p := range params.Parameter {
+			paramTickets.Tickets = append(paramTickets.Tickets, p.Ticket)
+			reply.NodeData[p.Ticket] = &epb.NodeData{
+				Kind:    p.Kind,
+				Subkind: p.Subkind,
+				Code:    p.MarkedSource,
+			}
+			if p.TypeTicket != "" {
+				reply.ParameterToType[p.Ticket] = p.TypeTicket
+			}
+		}
+		reply.FunctionToParameters[ticket] = paramTickets
+		if params.ReturnTypeTicket != "" {
+			reply.FunctionToReturnValue[ticket] = params.ReturnTypeTicket
+		}
+	}
+
+	return reply, nil
 }
 
 // Parents returns the parents of a specified node
@@ -27,6 +27,7 @@ import (
 	"bitbucket.org/creachadair/stringset"
 	"github.com/golang/protobuf/proto"
 
+	cpb "kythe.io/kythe/proto/common_go_proto"
 	epb "kythe.io/kythe/proto/explore_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
 )
@@ -45,6 +46,26 @@ const (
 	f2r1     = "kythe:#f2caller1"
 	f3       = "kythe:#function3_recursive"
 	dne      = "kythe:#does_not_exist"
+
+	// Type hierarchy:
+	//       iface
+	//      /     \
+	//    base   goIface
+	//    /  \
+	//  sub1 sub2 <-> cyc (cyclic data)
+	iface   = "kythe:?lang=java#iface"
+	goIface = "kythe:?lang=go#iface"
+	base    = "kythe:?lang=java#base"
+	sub1    = "kythe:?lang=java#sub1"
+	sub2    = "kythe:?lang=java#sub2"
+	cyc     = "kythe:?lang=java#cyclic"
+
+	// Function parameters
+	f1p1   = "kythe:#function1param1"
+	f1p2   = "kythe:#function1param2"
+	f2p1   = "kythe:#function2param1"
+	intTy  = "kythe:#int"
+	boolTy = "kythe:#bool"
 )
 
 var (
@@ -128,6 +149,87 @@ var (
 			Type:    srvpb.Callgraph_CALLER,
 		},
 	}
+
+	typeToSupertypes = &protoTable{
+		base: &srvpb.TypeHierarchy{
+			Tickets: []string{iface},
+			Type:    srvpb.TypeHierarchy_SUPERTYPES,
+		},
+		goIface: &srvpb.TypeHierarchy{
+			Tickets: []string{iface},
+			Type:    srvpb.TypeHierarchy_SUPERTYPES,
+		},
+		sub1: &srvpb.TypeHierarchy{
+			Tickets: []string{base},
+			Type:    srvpb.TypeHierarchy_SUPERTYPES,
+		},
+		sub2: &srvpb.TypeHierarchy{
+			Tickets: []string{base, cyc},
+			Type:    srvpb.TypeHierarchy_SUPERTYPES,
+		},
+		cyc: &srvpb.TypeHierarchy{
+			Tickets: []string{sub2},
+			Type:    srvpb.TypeHierarchy_SUPERTYPES,
+		},
+		badType: &srvpb.TypeHierarchy{
+			Tickets: []string{dontcare},
+			Type:    srvpb.TypeHierarchy_SUBTYPES,
+		},
+	}
+
+	typeToSubtypes = &protoTable{
+		iface: &srvpb.TypeHierarchy{
+			Tickets: []string{base, goIface},
+			Type:    srvpb.TypeHierarchy_SUBTYPES,
+		},
+		base: &srvpb.TypeHierarchy{
+			Tickets: []string{sub1, sub2},
+			Type:    srvpb.TypeHierarchy_SUBTYPES,
+		},
+		sub2: &srvpb.TypeHierarchy{
+			Tickets: []string{cyc},
+			Type:    srvpb.TypeHierarchy_SUBTYPES,
+		},
+		cyc: &srvpb.TypeHierarchy{
+			Tickets: []string{sub2},
+			Type:    srvpb.TypeHierarchy_SUBTYPES,
+		},
+		badType: &srvpb.TypeHierarchy{
+			Tickets: []string{dontcare},
+			Type:    srvpb.TypeHierarchy_SUPERTYPES,
+		},
+	}
+
+	functionToParameters = &protoTable{
+		f1: &srvpb.FunctionParameters{
+			Ticket:       f1,
+			Kind:         "function",
+			MarkedSource: &cpb.MarkedSource{PreText: "function1"},
+			Parameter: []*srvpb.FunctionParameters_Parameter{{
+				Ticket:       f1p1,
+				Ordinal:      0,
+				Kind:         "variable",
+				MarkedSource: &cpb.MarkedSource{PreText: "param1"},
+				TypeTicket:   intTy,
+			}, {
+				Ticket:       f1p2,
+				Ordinal:      1,
+				Kind:         "variable",
+				MarkedSource: &cpb.MarkedSource{PreText: "param2"},
+				TypeTicket:   boolTy,
+			}},
+			ReturnTypeTicket: intTy,
+		},
+		f2: &srvpb.FunctionParameters{
+			Ticket: f2,
+			Kind:   "function",
+			Parameter: []*srvpb.FunctionParameters_Parameter{{
+				Ticket:  f2p1,
+				Ordinal: 1,
+				Kind:    "variable",
+			}},
+		},
+	}
 )
 
 func TestChildren_badData(t *testing.T) {
@@ -407,6 +509,195 @@ func TestCallees_multipleInputs(t *testing.T) {
 	checkEqualGraphs(t, expectedGraph, reply.Graph)
 }
 
+func TestTypeHierarchy_badData(t *testing.T) {
+	svc := construct(t)
+
+	reply, err := svc.TypeHierarchy(ctx, &epb.TypeHierarchyRequest{
+		TypeTicket: badType,
+	})
+	if err == nil {
+		t.Errorf("Expected TypeHierarchy error for bad data, got: %v", reply)
+	}
+}
+
+func TestTypeHierarchy_noData(t *testing.T) {
+	svc := construct(t)
+
+	reply, err := svc.TypeHierarchy(ctx, &epb.TypeHierarchyRequest{
+		TypeTicket: dne,
+	})
+	testutil.FatalOnErrT(t, "TypeHierarchy error: %v", err)
+	if len(reply.Graph.Nodes) != 0 {
+		t.Errorf("Expected empty response for missing key, got: %v", reply)
+	}
+}
+
+func TestTypeHierarchy(t *testing.T) {
+	svc := construct(t)
+	request := &epb.TypeHierarchyRequest{
+		TypeTicket: base,
+	}
+
+	reply, err := svc.TypeHierarchy(ctx, request)
+	testutil.FatalOnErrT(t, "TypeHierarchy error: %v", err)
+
+	if reply.TypeTicket != base {
+		t.Errorf("Expected type ticket %q; found %q", base, reply.TypeTicket)
+	}
+
+	// goIface is neither a supertype nor a subtype of base.  The edge
+	// sub2->cyc is dropped because it would introduce a cycle.
+	expectedGraph := &epb.Graph{
+		Nodes: map[string]*epb.GraphNode{
+			iface: {
+				Predecessors: []string{base},
+			},
+			base: {
+				Predecessors: []string{sub1, sub2},
+				Successors:   []string{iface},
+			},
+			sub1: {
+				Successors: []string{base},
+			},
+			sub2: {
+				Predecessors: []string{cyc},
+				Successors:   []string{base},
+			},
+			cyc: {
+				Successors: []string{sub2},
+			},
+		},
+	}
+
+	checkEqualGraphs(t, expectedGraph, reply.Graph)
+}
+
+func TestTypeHierarchy_maxDepth(t *testing.T) {
+	svc := construct(t)
+	request := &epb.TypeHierarchyRequest{
+		TypeTicket: iface,
+		MaxDepth:   1,
+	}
+
+	reply, err := svc.TypeHierarchy(ctx, request)
+	testutil.FatalOnErrT(t, "TypeHierarchy error: %v", err)
+
+	expectedGraph := &epb.Graph{
+		Nodes: map[string]*epb.GraphNode{
+			iface: {
+				Predecessors: []string{base, goIface},
+			},
+			base: {
+				Successors: []string{iface},
+			},
+			goIface: {
+				Successors: []string{iface},
+			},
+		},
+	}
+
+	checkEqualGraphs(t, expectedGraph, reply.Graph)
+}
+
+func TestTypeHierarchy_maxFanOut(t *testing.T) {
+	svc := construct(t)
+	request := &epb.TypeHierarchyRequest{
+		TypeTicket: base,
+		MaxDepth:   1,
+		MaxFanOut:  1,
+	}
+
+	reply, err := svc.TypeHierarchy(ctx, request)
+	testutil.FatalOnErrT(t, "TypeHierarchy error: %v", err)
+
+	expectedGraph := &epb.Graph{
+		Nodes: map[string]*epb.GraphNode{
+			iface: {
+				Predecessors: []string{base},
+			},
+			base: {
+				Predecessors: []string{sub1},
+				Successors:   []string{iface},
+			},
+			sub1: {
+				Successors: []string{base},
+			},
+		},
+	}
+
+	checkEqualGraphs(t, expectedGraph, reply.Graph)
+}
+
+func TestTypeHierarchy_maxFanOutSkipsDiscarded(t *testing.T) {
+	const (
+		top = "kythe:#top"
+		mid = "kythe:#mid"
+		bot = "kythe:#bot"
+	)
+	// The relation mid->bot is discarded because it would introduce a cycle,
+	// and must not prevent mid->top from being followed.
+	svc := &Tables{
+		TypeToSupertypes: &protoTable{
+			bot: &srvpb.TypeHierarchy{
+				Tickets: []string{mid},
+				Type:    srvpb.TypeHierarchy_SUPERTYPES,
+			},
+			mid: &srvpb.TypeHierarchy{
+				Tickets: []string{bot, top},
+				Type:    srvpb.TypeHierarchy_SUPERTYPES,
+			},
+		},
+		TypeToSubtypes: &protoTable{},
+	}
+	reply, err := svc.TypeHierarchy(ctx, &epb.TypeHierarchyRequest{
+		TypeTicket: bot,
+		MaxFanOut:  1,
+	})
+	testutil.FatalOnErrT(t, "TypeHierarchy error: %v", err)
+
+	expectedGraph := &epb.Graph{
+		Nodes: map[string]*epb.GraphNode{
+			bot: {
+				Successors: []string{mid},
+			},
+			mid: {
+				Predecessors: []string{bot},
+				Successors:   []string{top},
+			},
+			top: {
+				Predecessors: []string{mid},
+			},
+		},
+	}
+
+	checkEqualGraphs(t, expectedGraph, reply.Graph)
+}
+
+func TestTypeHierarchy_nodeFilter(t *testing.T) {
+	svc := construct(t)
+	request := &epb.TypeHierarchyRequest{
+		TypeTicket: iface,
+		MaxDepth:   1,
+		NodeFilter: &epb.NodeFilter{IncludedLanguages: []string{"go"}},
+	}
+
+	reply, err := svc.TypeHierarchy(ctx, request)
+	testutil.FatalOnErrT(t, "TypeHierarchy error: %v", err)
+
+	expectedGraph := &epb.Graph{
+		Nodes: map[string]*epb.GraphNode{
+			iface: {
+				Predecessors: []string{goIface},
+			},
+			goIface: {
+				Successors: []string{iface},
+			},
+		},
+	}
+
+	checkEqualGraphs(t, expectedGraph, reply.Graph)
+}
+
 func checkEqualGraphs(t *testing.T, expected, actual *epb.Graph) {
 	if len(expected.Nodes) != len(actual.Nodes) {
 		t.Errorf("Mismatch in graph node counts: expected: %d, actual: %d",
@@ -430,6 +721,97 @@ func checkEqualGraphs(t *testing.T, expected, actual *epb.Graph) {
 }
 
 // Checks whether the lists are equivalent.
+func TestParameters_noTickets(t *testing.T) {
+	svc := construct(t)
+
+	reply, err := svc.Parameters(ctx, &epb.ParametersRequest{})
+	if err == nil {
+		t.Errorf("Expected Parameters error for missing tickets, got: %v", reply)
+	}
+}
+
+func TestParameters_noData(t *testing.T) {
+	svc := construct(t)
+
+	reply, err := svc.Parameters(ctx, &epb.ParametersRequest{
+		FunctionTickets: []string{dne},
+	})
+	testutil.FatalOnErrT(t, "Parameters error: %v", err)
+	if len(reply.FunctionToParameters) != 0 || len(reply.NodeData) != 0 {
+		t.Errorf("Expected empty response for missing key, got: %v", reply)
+	}
+}
+
+func TestParameters(t *testing.T) {
+	svc := construct(t)
+	request := &epb.ParametersRequest{
+		FunctionTickets: []string{f1, f2, dne, f1},
+	}
+
+	reply, err := svc.Parameters(ctx, request)
+	testutil.FatalOnErrT(t, "Parameters error: %v", err)
+
+	expected := &epb.ParametersReply{
+		FunctionToParameters: map[string]*epb.Tickets{
+			f1: {Tickets: []string{f1p1, f1p2}},
+			f2: {Tickets: []string{f2p1}},
+		},
+		NodeData: map[string]*epb.NodeData{
+			f1: {
+				Kind: "function",
+				Code: &cpb.MarkedSource{PreText: "function1"},
+			},
+			f1p1: {
+				Kind: "variable",
+				Code: &cpb.MarkedSource{PreText: "param1"},
+			},
+			f1p2: {
+				Kind: "variable",
+				Code: &cpb.MarkedSource{PreText: "param2"},
+			},
+			f2:   {Kind: "function"},
+			f2p1: {Kind: "variable"},
+		},
+		ParameterToType: map[string]string{
+			f1p1: intTy,
+			f1p2: boolTy,
+		},
+		FunctionToReturnValue: map[string]string{
+			f1: intTy,
+		},
+	}
+	if !proto.Equal(expected, reply) {
+		t.Errorf("Unexpected Parameters reply;\n expected: %v\n   actual: %v", expected, reply)
+	}
+}
+
+func TestNewCombinedTable(t *testing.T) {
+	combined := make(protoTable)
+	for key, msg := range *childToParents {
+		combined[string(ParentsKey(key))] = msg
+	}
+	for key, msg := range *functionToCallees {
+		combined[string(CalleesKey(key))] = msg
+	}
+	svc := NewCombinedTable(combined)
+
+	parents, err := svc.Parents(ctx, &epb.ParentsRequest{Tickets: []string{p1c1}})
+	testutil.FatalOnErrT(t, "Parents error: %v", err)
+	checkEquivalentLists(t, []string{p1}, parents.InputToParents[p1c1].GetTickets(), "parents of "+p1c1)
+
+	children, err := svc.Children(ctx, &epb.ChildrenRequest{Tickets: []string{p1}})
+	testutil.FatalOnErrT(t, "Children error: %v", err)
+	if len(children.InputToChildren) != 0 {
+		t.Errorf("Expected no children from parents table, got: %v", children)
+	}
+
+	callees, err := svc.Callees(ctx, &epb.CalleesRequest{Tickets: []string{f1}})
+	testutil.FatalOnErrT(t, "Callees error: %v", err)
+	if len(callees.Graph.Nodes) == 0 {
+		t.Errorf("Expected callees of %s, got: %v", f1, callees)
+	}
+}
+
 func checkEquivalentLists(t *testing.T, expected, actual []string, tag string) {
 	if len(expected) != len(actual) {
 		t.Errorf("Mismatch in counts for %s; expected:\n%v actual:\n%v",
@@ -459,5 +841,9 @@ func construct(t *testing.T) *Tables {
 		ChildToParents:    childToParents,
 		FunctionToCallers: functionToCallers,
 		FunctionToCallees: functionToCallees,
+		TypeToSupertypes:  typeToSupertypes,
+		TypeToSubtypes:    typeToSubtypes,
+
+		FunctionToParameters: functionToParameters,
 	}
 }
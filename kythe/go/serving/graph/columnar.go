@@ -112,12 +112,17 @@ func (c *ColumnarTable) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.
 
 // Edges implements part of the graph.Service interface.
 func (c *ColumnarTable) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
-	// TODO(schroederc): implement edge paging
+	stats, err := newFilterStats(int(req.PageSize), req.PageToken, false)
+	if err != nil {
+		return nil, err
+	}
+	pageToken := stats.skip
+
 	reply := &gpb.EdgesReply{
 		EdgeSets: make(map[string]*gpb.EdgeSet, len(req.Ticket)),
 		Nodes:    make(map[string]*cpb.NodeInfo),
 
-		// TODO(schroederc): TotalEdgesByKind: make(map[string]int64),
+		TotalEdgesByKind: make(map[string]int64),
 	}
 	patterns := xrefs.ConvertFilters(req.Filter)
 	allowedKinds := stringset.New(req.Kind...)
@@ -193,6 +198,17 @@ func (c *ColumnarTable) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.
 					continue
 				}
 
+				// Count every matching edge, but only return those within the
+				// requested page.
+				reply.TotalEdgesByKind[kind]++
+				if stats.skip > 0 {
+					stats.skip--
+					continue
+				} else if stats.total == stats.max {
+					continue
+				}
+				stats.total++
+
 				target := kytheuri.ToString(edge.Target)
 				targets.Add(target)
 
@@ -233,6 +249,17 @@ func (c *ColumnarTable) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.
 	if len(reply.Nodes) == 0 {
 		reply.Nodes = nil
 	}
+	if len(reply.TotalEdgesByKind) == 0 {
+		reply.TotalEdgesByKind = nil
+	}
+
+	totalEdgesPossible := int(sumEdgeKinds(reply.TotalEdgesByKind))
+	if pageToken+stats.total != totalEdgesPossible && stats.total != 0 {
+		reply.NextPageToken, err = encodePageToken(pageToken + stats.total)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return reply, nil
 }
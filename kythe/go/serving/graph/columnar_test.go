@@ -187,6 +187,9 @@ func TestServingEdges(t *testing.T) {
 				},
 			},
 		},
+		TotalEdgesByKind: map[string]int64{
+			edges.Param: 2,
+		},
 	}))
 
 	t.Run("reverse", makeEdgesTestCase(ctx, gs, &gpb.EdgesRequest{
@@ -206,6 +209,9 @@ func TestServingEdges(t *testing.T) {
 				},
 			},
 		},
+		TotalEdgesByKind: map[string]int64{
+			"%" + edges.ChildOf: 2,
+		},
 	}))
 
 	t.Run("filtered_targets", makeEdgesTestCase(ctx, gs, &gpb.EdgesRequest{
@@ -226,6 +232,9 @@ func TestServingEdges(t *testing.T) {
 				},
 			},
 		},
+		TotalEdgesByKind: map[string]int64{
+			"%" + edges.ChildOf: 2,
+		},
 		Nodes: map[string]*cpb.NodeInfo{
 			srcTicket: &cpb.NodeInfo{
 				Facts: map[string][]byte{
@@ -273,6 +282,10 @@ func TestServingEdges(t *testing.T) {
 				},
 			},
 		},
+		TotalEdgesByKind: map[string]int64{
+			edges.Param:         2,
+			"%" + edges.ChildOf: 2,
+		},
 		Nodes: map[string]*cpb.NodeInfo{
 			srcTicket:       &cpb.NodeInfo{Facts: map[string][]byte{facts.NodeKind: []byte(kinds.Record)}},
 			"kythe:#child1": &cpb.NodeInfo{Facts: map[string][]byte{facts.NodeKind: []byte(kinds.Function)}},
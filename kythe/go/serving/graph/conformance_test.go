@@ -0,0 +1,286 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+
+	"github.com/google/go-cmp/cmp"
+
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	gspb "kythe.io/kythe/proto/graph_serving_go_proto"
+	scpb "kythe.io/kythe/proto/schema_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// A testEdge is a single edge written to both graph table formats.
+type testEdge struct {
+	source  string
+	kind    scpb.EdgeKind
+	generic string
+	reverse bool
+	ordinal int32
+	target  string
+}
+
+func (e testEdge) kindString() string {
+	kind := e.generic
+	if kind == "" {
+		switch e.kind {
+		case scpb.EdgeKind_PARAM:
+			kind = edges.Param
+		case scpb.EdgeKind_CHILD_OF:
+			kind = edges.ChildOf
+		}
+	}
+	if e.reverse {
+		kind = "%" + kind
+	}
+	return kind
+}
+
+var conformanceEdges = []testEdge{
+	{source: "a", kind: scpb.EdgeKind_PARAM, ordinal: 0, target: "p0"},
+	{source: "a", kind: scpb.EdgeKind_PARAM, ordinal: 1, target: "p1"},
+	{source: "a", kind: scpb.EdgeKind_PARAM, ordinal: 2, target: "p2"},
+	{source: "a", kind: scpb.EdgeKind_CHILD_OF, reverse: true, target: "c1"},
+	{source: "a", kind: scpb.EdgeKind_CHILD_OF, reverse: true, target: "c2"},
+	{source: "a", generic: "/some/edge", target: "x"},
+	{source: "b", kind: scpb.EdgeKind_CHILD_OF, target: "a"},
+	{source: "b", generic: "/some/edge", target: "y"},
+	{source: "b", generic: "/some/edge", target: "z"},
+}
+
+func ticketFor(sig string) string {
+	return kytheuri.ToString(&spb.VName{Corpus: "corpus", Signature: sig})
+}
+
+// conformanceServices returns a combined and a columnar graph.Service, each
+// populated with conformanceEdges.
+func conformanceServices(t *testing.T) map[string]graph.Service {
+	ctx := context.Background()
+
+	// Build the combined (non-columnar) table.
+	combined := &table.KVProto{inmemory.NewKeyValueDB()}
+	sets := make(map[string]*srvpb.PagedEdgeSet)
+	var sources []string
+	for _, e := range conformanceEdges {
+		pes := sets[e.source]
+		if pes == nil {
+			pes = &srvpb.PagedEdgeSet{Source: &srvpb.Node{Ticket: ticketFor(e.source)}}
+			sets[e.source] = pes
+			sources = append(sources, e.source)
+		}
+		kind := e.kindString()
+		var grp *srvpb.EdgeGroup
+		for _, g := range pes.Group {
+			if g.Kind == kind {
+				grp = g
+			}
+		}
+		if grp == nil {
+			grp = &srvpb.EdgeGroup{Kind: kind}
+			pes.Group = append(pes.Group, grp)
+		}
+		grp.Edge = append(grp.Edge, &srvpb.EdgeGroup_Edge{
+			Target:  &srvpb.Node{Ticket: ticketFor(e.target)},
+			Ordinal: e.ordinal,
+		})
+	}
+	for _, src := range sources {
+		pes := sets[src]
+		if err := combined.Put(ctx, EdgeSetKey(pes.Source.Ticket), pes); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Build the columnar table.
+	db := inmemory.NewKeyValueDB()
+	w, err := db.Writer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustWrite(t, w, []byte(ColumnarTableKeyMarker), []byte{})
+	for _, src := range sources {
+		mustWriteEdges(t, w, &gspb.Edges{
+			Source: &spb.VName{Corpus: "corpus", Signature: src},
+			Entry:  &gspb.Edges_Index_{&gspb.Edges_Index{Node: &scpb.Node{}}},
+		})
+	}
+	for _, e := range conformanceEdges {
+		edge := &gspb.Edges_Edge{
+			Reverse: e.reverse,
+			Ordinal: e.ordinal,
+			Target:  &spb.VName{Corpus: "corpus", Signature: e.target},
+		}
+		if e.generic != "" {
+			edge.Kind = &gspb.Edges_Edge_GenericKind{e.generic}
+		} else {
+			edge.Kind = &gspb.Edges_Edge_KytheKind{e.kind}
+		}
+		mustWriteEdges(t, w, &gspb.Edges{
+			Source: &spb.VName{Corpus: "corpus", Signature: e.source},
+			Entry:  &gspb.Edges_Edge_{edge},
+		})
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]graph.Service{
+		"combined": NewCombinedTable(combined),
+		"columnar": NewService(ctx, db),
+	}
+}
+
+// pagedEdges reads every page of the given request, returning a sorted
+// description of each edge returned, the number of pages, and the totals
+// reported with the first page.
+func pagedEdges(ctx context.Context, gs graph.Service, req *gpb.EdgesRequest) ([]string, int, map[string]int64, error) {
+	var (
+		found  []string
+		pages  int
+		totals map[string]int64
+	)
+	req.PageToken = ""
+	for {
+		reply, err := gs.Edges(ctx, req)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		pages++
+		if totals == nil {
+			totals = reply.TotalEdgesByKind
+		}
+
+		var n int
+		for src, es := range reply.EdgeSets {
+			for kind, g := range es.Groups {
+				for _, e := range g.Edge {
+					found = append(found, fmt.Sprintf("%s %s %s.%d", src, kind, e.TargetTicket, e.Ordinal))
+					n++
+				}
+			}
+		}
+		if req.PageSize > 0 && n > int(req.PageSize) {
+			return nil, 0, nil, fmt.Errorf("page %d has %d edges; page_size is %d", pages, n, req.PageSize)
+		}
+
+		if reply.NextPageToken == "" {
+			break
+		} else if n == 0 {
+			return nil, 0, nil, fmt.Errorf("page %d is empty but has a next_page_token", pages)
+		}
+		req.PageToken = reply.NextPageToken
+	}
+	sort.Strings(found)
+	if len(totals) == 0 {
+		totals = nil
+	}
+	return found, pages, totals, nil
+}
+
+func TestEdgesConformance(t *testing.T) {
+	ctx := context.Background()
+	services := conformanceServices(t)
+
+	allTickets := []string{ticketFor("a"), ticketFor("missing"), ticketFor("b")}
+	tests := []struct {
+		Name   string
+		Kind   []string
+		Total  int
+		Totals map[string]int64
+	}{{
+		Name:  "all",
+		Total: len(conformanceEdges),
+		Totals: map[string]int64{
+			edges.Param:         3,
+			"%" + edges.ChildOf: 2,
+			edges.ChildOf:       1,
+			"/some/edge":        3,
+		},
+	}, {
+		Name:  "kinds",
+		Kind:  []string{edges.Param, "/some/edge"},
+		Total: 6,
+		Totals: map[string]int64{
+			edges.Param:  3,
+			"/some/edge": 3,
+		},
+	}, {
+		Name: "no_matches",
+		Kind: []string{"non_existent_kind"},
+	}}
+
+	for _, test := range tests {
+		for pageSize := 0; pageSize <= len(conformanceEdges)+1; pageSize++ {
+			name := fmt.Sprintf("%s/page_size=%d", test.Name, pageSize)
+			t.Run(name, func(t *testing.T) {
+				wantPages := 1
+				if pageSize > 0 && test.Total > 0 {
+					wantPages = (test.Total + pageSize - 1) / pageSize
+				}
+
+				results := make(map[string][]string)
+				for impl, gs := range services {
+					found, pages, totals, err := pagedEdges(ctx, gs, &gpb.EdgesRequest{
+						Ticket:   allTickets,
+						Kind:     test.Kind,
+						PageSize: int32(pageSize),
+					})
+					if err != nil {
+						t.Fatalf("%s: Edges error: %v", impl, err)
+					}
+					if len(found) != test.Total {
+						t.Errorf("%s: found %d edges; want %d: %v", impl, len(found), test.Total, found)
+					}
+					if pages != wantPages {
+						t.Errorf("%s: read %d pages; want %d", impl, pages, wantPages)
+					}
+					if diff := cmp.Diff(test.Totals, totals); diff != "" {
+						t.Errorf("%s: TotalEdgesByKind differences: (- expected; + found)\n%s", impl, diff)
+					}
+					results[impl] = found
+				}
+				if diff := cmp.Diff(results["combined"], results["columnar"]); diff != "" {
+					t.Errorf("Edges differ between implementations: (- combined; + columnar)\n%s", diff)
+				}
+			})
+		}
+	}
+
+	t.Run("invalid_page_token", func(t *testing.T) {
+		for impl, gs := range services {
+			if reply, err := gs.Edges(ctx, &gpb.EdgesRequest{
+				Ticket:    allTickets,
+				PageToken: "!invalid!",
+			}); err == nil {
+				t.Errorf("%s: Edges unexpectedly succeeded: %v", impl, reply)
+			}
+		}
+	})
+}
@@ -210,29 +210,9 @@ type edgesRequest struct {
 }
 
 func (t *Table) edges(ctx context.Context, req edgesRequest) (*gpb.EdgesReply, error) {
-	stats := filterStats{
-		max: int(req.PageSize),
-	}
-	if req.TotalOnly {
-		stats.max = 0
-	} else if stats.max < 0 {
-		return nil, fmt.Errorf("invalid page_size: %d", req.PageSize)
-	} else if stats.max == 0 {
-		stats.max = defaultPageSize
-	} else if stats.max > maxPageSize {
-		stats.max = maxPageSize
-	}
-
-	if req.PageToken != "" {
-		rec, err := base64.StdEncoding.DecodeString(req.PageToken)
-		if err != nil {
-			return nil, fmt.Errorf("invalid page_token: %q", req.PageToken)
-		}
-		var t ipb.PageToken
-		if err := proto.Unmarshal(rec, &t); err != nil || t.Index < 0 {
-			return nil, fmt.Errorf("invalid page_token: %q", req.PageToken)
-		}
-		stats.skip = int(t.Index)
+	stats, err := newFilterStats(req.PageSize, req.PageToken, req.TotalOnly)
+	if err != nil {
+		return nil, err
 	}
 	pageToken := stats.skip
 
@@ -348,16 +328,53 @@ func (t *Table) edges(ctx context.Context, req edgesRequest) (*gpb.EdgesReply, e
 	}
 
 	if pageToken+stats.total != totalEdgesPossible && stats.total != 0 {
-		rec, err := proto.Marshal(&ipb.PageToken{Index: int32(pageToken + stats.total)})
+		reply.NextPageToken, err = encodePageToken(pageToken + stats.total)
 		if err != nil {
-			return nil, fmt.Errorf("internal error: error marshalling page token: %v", err)
+			return nil, err
 		}
-		reply.NextPageToken = base64.StdEncoding.EncodeToString(rec)
 	}
 
 	return reply, nil
 }
 
+// newFilterStats returns the filterStats for a request with the given page
+// size and page token.  If totalOnly is true, no edges will be selected.
+func newFilterStats(pageSize int, pageToken string, totalOnly bool) (filterStats, error) {
+	stats := filterStats{max: pageSize}
+	if totalOnly {
+		stats.max = 0
+	} else if stats.max < 0 {
+		return stats, fmt.Errorf("invalid page_size: %d", pageSize)
+	} else if stats.max == 0 {
+		stats.max = defaultPageSize
+	} else if stats.max > maxPageSize {
+		stats.max = maxPageSize
+	}
+
+	if pageToken != "" {
+		rec, err := base64.StdEncoding.DecodeString(pageToken)
+		if err != nil {
+			return stats, fmt.Errorf("invalid page_token: %q", pageToken)
+		}
+		var t ipb.PageToken
+		if err := proto.Unmarshal(rec, &t); err != nil || t.Index < 0 {
+			return stats, fmt.Errorf("invalid page_token: %q", pageToken)
+		}
+		stats.skip = int(t.Index)
+	}
+	return stats, nil
+}
+
+// encodePageToken returns the page token for the page starting at the given
+// edge index.
+func encodePageToken(index int) (string, error) {
+	rec, err := proto.Marshal(&ipb.PageToken{Index: int32(index)})
+	if err != nil {
+		return "", fmt.Errorf("internal error: error marshalling page token: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(rec), nil
+}
+
 func countEdgeKinds(pes *srvpb.PagedEdgeSet, kindFilter func(string) bool, totals map[string]int64) {
 	for _, grp := range pes.Group {
 		if kindFilter == nil || kindFilter(grp.Kind) {
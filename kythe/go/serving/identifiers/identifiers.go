@@ -18,22 +18,132 @@
 // identifiers.Service.
 // The table is structured as:
 // 		qualifed_name -> IdentifierMatch
+//
+// Within a combined serving table, the matches are keyed by IdentifierKey and
+// indexed for inexact Find requests by:
+//   identIndex:tri:<trigram>      -> IdentifierPostings
+//   identIndex:pre:<prefix>       -> IdentifierPostings
+//   identIndex:hump:<initials>    -> IdentifierPostings
+// where each trigram and prefix (up to 2 characters) is of a lowercased
+// qualified or simple name and the initials (up to 3) are those of the leading
+// camel humps of a simple name.  Longer prefixes are found by their trigrams.
+//
+// The postings of the short prefix and initials keys are limited to
+// MaxShortPostings names (see LimitPostings), so the queries using them return
+// only the matches among the shortest names.
 package identifiers
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"kythe.io/kythe/go/services/web"
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/util/kytheuri"
 
+	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	ipb "kythe.io/kythe/proto/identifier_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
 )
 
+const (
+	// IdentifierTablePrefix is used as the prefix of the keys of a combined
+	// serving table.  IdentifierKey uses this prefix to construct its keys.
+	// Table uses this prefix when PrefixedKeys is true.
+	IdentifierTablePrefix = "idents:"
+
+	postingsTablePrefix = "identIndex:"
+
+	// Index key prefixes within the postings table.
+	trigramIndexPrefix = "tri:"
+	prefixIndexPrefix  = "pre:"
+	humpIndexPrefix    = "hump:"
+
+	// maxIndexedPrefix is the number of leading characters of each name that
+	// are indexed.  Queries of at least 3 bytes use the trigram index instead.
+	maxIndexedPrefix = 2
+
+	// maxIndexedHumps is the number of leading camel humps of each simple
+	// name whose initials are indexed.
+	maxIndexedHumps = 3
+
+	// rankedMatchesFactor bounds the number of verified matches that an
+	// inexact Find request ranks, as a multiple of its maximum matches.
+	rankedMatchesFactor = 10
+)
+
+// DefaultMaxMatches is the default maximum number of matches returned for
+// inexact Find requests.
+const DefaultMaxMatches = 100
+
+// MaxShortPostings is the maximum number of qualified names kept in the
+// postings of a prefix or of fewer than maxIndexedHumps initials.
+const MaxShortPostings = 1000
+
+// IdentifierKey returns the combined table key for the IdentifierMatch of the
+// given qualified name.
+func IdentifierKey(qname string) []byte { return []byte(IdentifierTablePrefix + qname) }
+
+// PostingsKey returns the combined table key for the IdentifierPostings of the
+// given index key.
+func PostingsKey(key string) []byte { return []byte(postingsTablePrefix + key) }
+
+// IndexKeys returns the sorted, distinct index keys of an identifier with the
+// given qualified and simple names.  The IdentifierPostings of each key should
+// contain the identifier's qualified name.
+func IndexKeys(qname, baseName string) []string {
+	keys := stringset.New()
+	for _, name := range []string{qname, baseName} {
+		lower := strings.ToLower(name)
+		for _, tri := range trigrams(lower) {
+			keys.Add(trigramIndexPrefix + tri)
+		}
+		for _, pre := range prefixes(lower, maxIndexedPrefix) {
+			keys.Add(prefixIndexPrefix + pre)
+		}
+	}
+	initials := humpInitials(camelHumps(baseName))
+	for _, pre := range prefixes(initials, maxIndexedHumps) {
+		keys.Add(humpIndexPrefix + pre)
+	}
+	return keys.Elements()
+}
+
+// LimitPostings trims the sorted postings p of a short index key to the
+// MaxShortPostings shortest qualified names, keeping them in sorted order.
+// The postings of other keys are unchanged.  Writers of the postings table
+// should call LimitPostings on each IdentifierPostings before writing it.
+func LimitPostings(p *srvpb.IdentifierPostings) {
+	if len(p.QualifiedName) <= MaxShortPostings || !isShortKey(p.Key) {
+		return
+	}
+	qnames := append([]string(nil), p.QualifiedName...)
+	sort.SliceStable(qnames, func(i, j int) bool { return len(qnames[i]) < len(qnames[j]) })
+	qnames = qnames[:MaxShortPostings]
+	sort.Strings(qnames)
+	p.QualifiedName = qnames
+}
+
+// isShortKey reports whether key is an index key whose postings are limited.
+func isShortKey(key string) bool {
+	if strings.HasPrefix(key, prefixIndexPrefix) {
+		return true
+	} else if initials := strings.TrimPrefix(key, humpIndexPrefix); initials != key {
+		return utf8.RuneCountInString(initials) < maxIndexedHumps
+	}
+	return false
+}
+
 // Service describes the interface for the identifier service which provides
 // lookups from fully qualified identifiers to any matching semantic nodes
 type Service interface {
@@ -44,24 +154,82 @@ type Service interface {
 // Table wraps around a table.Proto to provide the Service interface
 type Table struct {
 	table.Proto
+
+	// PrefixedKeys indicates whether the identifier matches are keyed by
+	// IdentifierKey (i.e. when using a combined serving table).  Inexact Find
+	// requests are only supported with PrefixedKeys.
+	PrefixedKeys bool
 }
 
 // Find implements the Service interface for Table
 func (it *Table) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	if req.GetMatchMode() == ipb.FindRequest_EXACT && !req.GetCaseInsensitive() {
+		return it.findExact(ctx, req)
+	} else if !it.PrefixedKeys {
+		return nil, status.Error(codes.Unimplemented, "inexact identifier matching requires an indexed serving table")
+	}
+
+	m, err := newMatcher(req)
+	if err != nil {
+		return nil, err
+	}
+	qnames, err := it.candidates(ctx, m.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	maxMatches := int(req.GetMaxMatches())
+	if maxMatches <= 0 {
+		maxMatches = DefaultMaxMatches
+	}
+
+	// Candidates are looked up in name order until enough matches have been
+	// found to rank; the remainder are not considered.
+	var reply ipb.FindReply
+	for _, qname := range qnames {
+		if len(reply.Matches) >= rankedMatchesFactor*maxMatches {
+			break
+		}
+		var match srvpb.IdentifierMatch
+		if err := it.Lookup(ctx, IdentifierKey(qname), &match); err == table.ErrNoSuchKey {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error looking up identifier %q: %v", qname, err)
+		}
+		if m.matches(&match) {
+			reply.Matches = append(reply.Matches, filterMatches(req, &match)...)
+		}
+	}
+	return rankMatches(&reply, maxMatches), nil
+}
+
+// findExact returns the matches of the exact qualified name of req.
+func (it *Table) findExact(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
 	var (
-		qname     = req.GetIdentifier()
-		corpora   = req.GetCorpus()
-		languages = req.GetLanguages()
-		match     srvpb.IdentifierMatch
-		reply     ipb.FindReply
+		qname = req.GetIdentifier()
+		match srvpb.IdentifierMatch
+		reply ipb.FindReply
 	)
 
-	if err := it.Lookup(ctx, []byte(qname), &match); err != nil {
+	key := []byte(qname)
+	if it.PrefixedKeys {
+		key = IdentifierKey(qname)
+	}
+	if err := it.Lookup(ctx, key, &match); err != nil {
 		return &reply, nil
 	}
+	reply.Matches = filterMatches(req, &match)
+	return rankMatches(&reply, int(req.GetMaxMatches())), nil
+}
 
+// filterMatches returns the Matches for the nodes of match satisfying the
+// corpus, language, and node kind restrictions of req.
+func filterMatches(req *ipb.FindRequest, match *srvpb.IdentifierMatch) []*ipb.FindReply_Match {
+	var matches []*ipb.FindReply_Match
 	for _, node := range match.GetNode() {
-		if !validCorpusAndLang(corpora, languages, node) {
+		if !validCorpusAndLang(req.GetCorpus(), req.GetLanguages(), node) {
+			continue
+		} else if kinds := req.GetNodeKind(); len(kinds) > 0 && !contains(kinds, node.GetNodeKind()) {
 			continue
 		}
 
@@ -71,12 +239,257 @@ func (it *Table) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply
 			NodeSubkind:   node.GetNodeSubkind(),
 			BaseName:      match.GetBaseName(),
 			QualifiedName: match.GetQualifiedName(),
+			RefCount:      node.GetRefCount(),
+		}
+
+		matches = append(matches, &matchNode)
+	}
+	return matches
+}
+
+// rankMatches sorts the matches of reply by descending reference count,
+// otherwise preserving their order, and keeps at most maxMatches of them (if
+// maxMatches > 0).
+func rankMatches(reply *ipb.FindReply, maxMatches int) *ipb.FindReply {
+	sort.SliceStable(reply.Matches, func(i, j int) bool {
+		return reply.Matches[i].RefCount > reply.Matches[j].RefCount
+	})
+	if maxMatches > 0 && len(reply.Matches) > maxMatches {
+		reply.Matches = reply.Matches[:maxMatches]
+	}
+	return reply
+}
+
+// candidates returns the sorted qualified names in the postings of each of the
+// given index keys.
+func (it *Table) candidates(ctx context.Context, keys []string) ([]string, error) {
+	var postings [][]string
+	for _, key := range keys {
+		var p srvpb.IdentifierPostings
+		if err := it.Lookup(ctx, PostingsKey(key), &p); err == table.ErrNoSuchKey {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error looking up identifier postings for %q: %v", key, err)
+		}
+		postings = append(postings, p.QualifiedName)
+	}
+	sort.Slice(postings, func(i, j int) bool { return len(postings[i]) < len(postings[j]) })
+
+	qnames := postings[0]
+	for _, p := range postings[1:] {
+		qnames = intersect(qnames, p)
+	}
+	return qnames, nil
+}
+
+// intersect returns the elements common to the sorted slices a and b.
+func intersect(a, b []string) []string {
+	var res []string
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			res = append(res, a[i])
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+// A matcher determines the candidate index keys for an inexact FindRequest
+// and verifies which candidates match.
+type matcher struct {
+	keys    []string
+	matches func(*srvpb.IdentifierMatch) bool
+}
+
+func newMatcher(req *ipb.FindRequest) (*matcher, error) {
+	ident := req.GetIdentifier()
+	if ident == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing identifier")
+	}
+	fold := func(s string) string { return s }
+	if req.GetCaseInsensitive() {
+		fold = strings.ToLower
+	}
+	query, lower := fold(ident), strings.ToLower(ident)
+	names := func(f func(name string) bool) func(*srvpb.IdentifierMatch) bool {
+		return func(m *srvpb.IdentifierMatch) bool {
+			return f(fold(m.GetQualifiedName())) || f(fold(m.GetBaseName()))
+		}
+	}
+
+	// Names with a given prefix are found by the trigrams of the prefix, if
+	// it has any, and otherwise by the (limited) postings of the prefix.
+	prefixKeys := []string{prefixIndexPrefix + lower}
+	if tris := trigrams(lower); len(tris) > 0 {
+		prefixKeys = prefixKeys[:0]
+		for _, tri := range tris {
+			prefixKeys = append(prefixKeys, trigramIndexPrefix+tri)
+		}
+	}
+
+	switch mode := req.GetMatchMode(); mode {
+	case ipb.FindRequest_EXACT:
+		return &matcher{
+			keys: prefixKeys,
+			matches: func(m *srvpb.IdentifierMatch) bool {
+				return fold(m.GetQualifiedName()) == query
+			},
+		}, nil
+	case ipb.FindRequest_PREFIX:
+		return &matcher{
+			keys: prefixKeys,
+			matches: names(func(name string) bool {
+				return strings.HasPrefix(name, query)
+			}),
+		}, nil
+	case ipb.FindRequest_SUBSTRING:
+		tris := trigrams(lower)
+		if len(tris) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "substring identifier must be at least 3 bytes: %q", ident)
+		}
+		m := &matcher{matches: names(func(name string) bool {
+			return strings.Contains(name, query)
+		})}
+		for _, tri := range tris {
+			m.keys = append(m.keys, trigramIndexPrefix+tri)
+		}
+		return m, nil
+	case ipb.FindRequest_CAMEL_HUMP:
+		humps := camelHumps(ident)
+		if len(humps) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "identifier has no camel humps: %q", ident)
+		}
+		return &matcher{
+			keys: []string{humpIndexPrefix + longestPrefix(humpInitials(humps), maxIndexedHumps)},
+			matches: func(m *srvpb.IdentifierMatch) bool {
+				return camelMatch(humps, camelHumps(m.GetBaseName()))
+			},
+		}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown match_mode: %v", mode)
+	}
+}
+
+// trigrams returns the distinct trigrams of s in sorted order.
+func trigrams(s string) []string {
+	set := stringset.New()
+	for i := 0; i+3 <= len(s); i++ {
+		set.Add(s[i : i+3])
+	}
+	return set.Elements()
+}
+
+// prefixes returns the non-empty prefixes of s up to max characters long.
+func prefixes(s string, max int) []string {
+	var res []string
+	var n int
+	for i := range s {
+		if i > 0 {
+			res = append(res, s[:i])
+			if n++; n == max {
+				return res
+			}
+		}
+	}
+	if s != "" {
+		res = append(res, s)
+	}
+	return res
+}
+
+// longestPrefix returns the longest prefix of s up to max characters long.
+func longestPrefix(s string, max int) string {
+	pres := prefixes(s, max)
+	if len(pres) == 0 {
+		return ""
+	}
+	return pres[len(pres)-1]
+}
+
+// camelHumps splits name into its camel humps.  A hump starts at an
+// upper-case letter following a lower-case letter or digit, at the last
+// upper-case letter of an acronym followed by a lower-case letter (e.g.
+// "HTTPServer" has the humps "HTTP" and "Server"), and after any character
+// other than a letter or digit, which is dropped.
+func camelHumps(name string) []string {
+	var (
+		humps []string
+		rs    = []rune(name)
+		start = -1
+	)
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				humps = append(humps, string(rs[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			acronymEnd := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || acronymEnd {
+				humps = append(humps, string(rs[start:i]))
+				start = i
+			}
 		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		humps = append(humps, string(rs[start:]))
+	}
+	return humps
+}
+
+// humpInitials returns the lowercased first letters of the given humps.
+func humpInitials(humps []string) string {
+	var initials []rune
+	for _, h := range humps {
+		for _, r := range h {
+			initials = append(initials, unicode.ToLower(r))
+			break
+		}
+	}
+	return string(initials)
+}
 
-		reply.Matches = append(reply.GetMatches(), &matchNode)
+// camelMatch reports whether each of the query humps matches the
+// corresponding leading hump of name.
+func camelMatch(query, name []string) bool {
+	if len(query) > len(name) {
+		return false
+	}
+	for i, q := range query {
+		if !humpMatch(q, name[i]) {
+			return false
+		}
 	}
+	return true
+}
 
-	return &reply, nil
+// humpMatch reports whether the query hump q starts with the same letter as
+// the hump h and the rest of its letters appear, in order, within h, ignoring
+// case.
+func humpMatch(q, h string) bool {
+	qs, hs := []rune(strings.ToLower(q)), []rune(strings.ToLower(h))
+	if len(qs) == 0 || len(hs) == 0 || qs[0] != hs[0] {
+		return false
+	}
+	i := 1
+	for _, r := range hs[1:] {
+		if i < len(qs) && qs[i] == r {
+			i++
+		}
+	}
+	return i == len(qs)
 }
 
 func validCorpusAndLang(corpora, langs []string, node *srvpb.IdentifierMatch_Node) bool {
@@ -127,7 +540,11 @@ func RegisterHTTPHandlers(ctx context.Context, id Service, mux *http.ServeMux) {
 		}
 		reply, err := id.Find(ctx, &req)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			code := http.StatusInternalServerError
+			if status.Code(err) == codes.InvalidArgument {
+				code = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), code)
 			return
 		}
 
@@ -149,3 +566,13 @@ func (w *webClient) Find(ctx context.Context, q *ipb.FindRequest) (*ipb.FindRepl
 func WebClient(addr string) Service {
 	return &webClient{addr}
 }
+
+type grpcClient struct{ ipb.IdentifierServiceClient }
+
+// Find implements part of the Service interface.
+func (c grpcClient) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	return c.IdentifierServiceClient.Find(ctx, req)
+}
+
+// GRPC returns an identifiers Service backed by the given gRPC client.
+func GRPC(c ipb.IdentifierServiceClient) Service { return grpcClient{c} }
@@ -18,9 +18,14 @@ package identifiers
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/test/testutil"
@@ -29,7 +34,7 @@ import (
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
 )
 
-var matchTable = Table{testProtoTable{
+var matchTable = Table{Proto: testProtoTable{
 	"foo::bar": &srvpb.IdentifierMatch{
 		Node: []*srvpb.IdentifierMatch_Node{
 			node("kythe://corpus?lang=c++", "record", "class"),
@@ -87,6 +92,229 @@ func TestFind(t *testing.T) {
 	}
 }
 
+var indexedTable = newIndexedTable(
+	&srvpb.IdentifierMatch{
+		Node: []*srvpb.IdentifierMatch_Node{
+			refNode("kythe://go?lang=go#Server", "record", "struct", 10),
+		},
+		BaseName:      "Server",
+		QualifiedName: "net/http.Server",
+	},
+	&srvpb.IdentifierMatch{
+		Node: []*srvpb.IdentifierMatch_Node{
+			refNode("kythe://go?lang=go#HTTPServer", "record", "struct", 3),
+		},
+		BaseName:      "HTTPServer",
+		QualifiedName: "net/http.HTTPServer",
+	},
+	&srvpb.IdentifierMatch{
+		Node: []*srvpb.IdentifierMatch_Node{
+			refNode("kythe://example?lang=go#HttpServe", "function", "", 20),
+		},
+		BaseName:      "HttpServe",
+		QualifiedName: "example.HttpServe",
+	},
+	&srvpb.IdentifierMatch{
+		Node: []*srvpb.IdentifierMatch_Node{
+			refNode("kythe://corpus?lang=c++#bar", "record", "class", 1),
+			refNode("kythe://corpus?lang=rust#bar", "record", "struct", 2),
+		},
+		BaseName:      "bar",
+		QualifiedName: "foo::bar",
+	},
+)
+
+func TestFindInexact(t *testing.T) {
+	var (
+		server     = refMatch("kythe://go?lang=go#Server", "record", "struct", "Server", "net/http.Server", 10)
+		httpServer = refMatch("kythe://go?lang=go#HTTPServer", "record", "struct", "HTTPServer", "net/http.HTTPServer", 3)
+		httpServe  = refMatch("kythe://example?lang=go#HttpServe", "function", "", "HttpServe", "example.HttpServe", 20)
+		cppBar     = refMatch("kythe://corpus?lang=c++#bar", "record", "class", "bar", "foo::bar", 1)
+		rustBar    = refMatch("kythe://corpus?lang=rust#bar", "record", "struct", "bar", "foo::bar", 2)
+	)
+
+	tests := []struct {
+		req     *ipb.FindRequest
+		matches []*ipb.FindReply_Match
+	}{{
+		req:     &ipb.FindRequest{Identifier: "foo::bar"},
+		matches: []*ipb.FindReply_Match{rustBar, cppBar},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "NET/HTTP.SERVER", CaseInsensitive: true},
+		matches: []*ipb.FindReply_Match{server},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "net/http.", MatchMode: ipb.FindRequest_PREFIX},
+		matches: []*ipb.FindReply_Match{server, httpServer},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "Se", MatchMode: ipb.FindRequest_PREFIX},
+		matches: []*ipb.FindReply_Match{server},
+	}, {
+		req: &ipb.FindRequest{Identifier: "http", MatchMode: ipb.FindRequest_PREFIX},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "http", MatchMode: ipb.FindRequest_PREFIX, CaseInsensitive: true},
+		matches: []*ipb.FindReply_Match{httpServe, httpServer},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "Serve", MatchMode: ipb.FindRequest_SUBSTRING},
+		matches: []*ipb.FindReply_Match{httpServe, server, httpServer},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "Serve", MatchMode: ipb.FindRequest_SUBSTRING, MaxMatches: 2},
+		matches: []*ipb.FindReply_Match{httpServe, server},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "Serve", MatchMode: ipb.FindRequest_SUBSTRING, NodeKind: []string{"record"}},
+		matches: []*ipb.FindReply_Match{server, httpServer},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "Serve", MatchMode: ipb.FindRequest_SUBSTRING, Corpus: []string{"example"}},
+		matches: []*ipb.FindReply_Match{httpServe},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "BAR", MatchMode: ipb.FindRequest_SUBSTRING, CaseInsensitive: true, Languages: []string{"c++"}},
+		matches: []*ipb.FindReply_Match{cppBar},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "HTTPSrv", MatchMode: ipb.FindRequest_CAMEL_HUMP},
+		matches: []*ipb.FindReply_Match{httpServe, httpServer},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "HSrvr", MatchMode: ipb.FindRequest_CAMEL_HUMP},
+		matches: []*ipb.FindReply_Match{httpServer},
+	}, {
+		req:     &ipb.FindRequest{Identifier: "srv", MatchMode: ipb.FindRequest_CAMEL_HUMP},
+		matches: []*ipb.FindReply_Match{server},
+	}, {
+		req: &ipb.FindRequest{Identifier: "Client", MatchMode: ipb.FindRequest_SUBSTRING},
+	}}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		reply, err := indexedTable.Find(ctx, test.req)
+		if err != nil {
+			t.Errorf("Find(%v) error: %v", test.req, err)
+			continue
+		}
+		if err := testutil.DeepEqual(test.matches, reply.Matches); err != nil {
+			t.Errorf("Find(%v): %v", test.req, err)
+		}
+	}
+}
+
+func TestFindInvalid(t *testing.T) {
+	ctx := context.Background()
+	for _, req := range []*ipb.FindRequest{
+		{MatchMode: ipb.FindRequest_PREFIX},
+		{Identifier: "ab", MatchMode: ipb.FindRequest_SUBSTRING},
+		{Identifier: "::", MatchMode: ipb.FindRequest_CAMEL_HUMP},
+		{Identifier: "foo", MatchMode: 42},
+	} {
+		if reply, err := indexedTable.Find(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Find(%v): expected InvalidArgument; found %v, %v", req, reply, err)
+		}
+	}
+}
+
+func TestCamelHumps(t *testing.T) {
+	tests := []struct {
+		name  string
+		humps []string
+	}{
+		{"", nil},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"getHTTPServer2Go", []string{"get", "HTTP", "Server2", "Go"}},
+		{"snake_case_name", []string{"snake", "case", "name"}},
+		{"::std::vector", []string{"std", "vector"}},
+	}
+	for _, test := range tests {
+		if err := testutil.DeepEqual(test.humps, camelHumps(test.name)); err != nil {
+			t.Errorf("camelHumps(%q): %v", test.name, err)
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	expected := []string{
+		"hump:h", "hump:hs",
+		"pre:h", "pre:ht", "pre:p", "pre:p.",
+		"tri:.ht", "tri:erv", "tri:htt", "tri:p.h", "tri:pse", "tri:rve", "tri:ser", "tri:tps", "tri:ttp", "tri:ver",
+	}
+	if err := testutil.DeepEqual(expected, IndexKeys("p.HTTPServer", "HTTPServer")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFindBounded(t *testing.T) {
+	const numIdents = 3 * MaxShortPostings
+	var matches []*srvpb.IdentifierMatch
+	for i := 0; i < numIdents; i++ {
+		name := fmt.Sprintf("a%05d", i)
+		matches = append(matches, &srvpb.IdentifierMatch{
+			QualifiedName: name,
+			BaseName:      name,
+			Node:          []*srvpb.IdentifierMatch_Node{node("kythe://corpus#"+name, "function", "")},
+		})
+	}
+	indexed := newIndexedTable(matches...)
+	tbl := &countingTable{testProtoTable: indexed.Proto.(testProtoTable)}
+	indexed.Proto = tbl
+
+	var p srvpb.IdentifierPostings
+	if err := tbl.Lookup(context.Background(), PostingsKey(prefixIndexPrefix+"a"), &p); err != nil {
+		t.Fatalf("Lookup error: %v", err)
+	} else if len(p.QualifiedName) != MaxShortPostings {
+		t.Errorf("Prefix postings: got %d names, want %d", len(p.QualifiedName), MaxShortPostings)
+	}
+
+	const maxMatches = 5
+	for _, ident := range []string{"a", "a0", "a00", "a01"} {
+		tbl.lookups = 0
+		reply, err := indexed.Find(context.Background(), &ipb.FindRequest{
+			Identifier: ident,
+			MatchMode:  ipb.FindRequest_PREFIX,
+			MaxMatches: maxMatches,
+		})
+		if err != nil {
+			t.Errorf("Find(%q) error: %v", ident, err)
+			continue
+		}
+		if len(reply.Matches) != maxMatches {
+			t.Errorf("Find(%q): got %d matches, want %d", ident, len(reply.Matches), maxMatches)
+		}
+		if max := rankedMatchesFactor * maxMatches; tbl.lookups > max {
+			t.Errorf("Find(%q): looked up %d identifiers, want at most %d", ident, tbl.lookups, max)
+		}
+	}
+}
+
+// countingTable counts the identifier matches looked up in a testProtoTable.
+type countingTable struct {
+	testProtoTable
+	lookups int
+}
+
+func (t *countingTable) Lookup(ctx context.Context, key []byte, msg proto.Message) error {
+	if strings.HasPrefix(string(key), IdentifierTablePrefix) {
+		t.lookups++
+	}
+	return t.testProtoTable.Lookup(ctx, key, msg)
+}
+
+func newIndexedTable(matches ...*srvpb.IdentifierMatch) *Table {
+	tbl := testProtoTable{}
+	postings := make(map[string]*srvpb.IdentifierPostings)
+	for _, m := range matches {
+		tbl[string(IdentifierKey(m.QualifiedName))] = m
+		for _, key := range IndexKeys(m.QualifiedName, m.BaseName) {
+			p, ok := postings[key]
+			if !ok {
+				p = &srvpb.IdentifierPostings{Key: key}
+				postings[key] = p
+				tbl[string(PostingsKey(key))] = p
+			}
+			p.QualifiedName = append(p.QualifiedName, m.QualifiedName)
+		}
+	}
+	for _, p := range postings {
+		sort.Strings(p.QualifiedName)
+		LimitPostings(p)
+	}
+	return &Table{Proto: tbl, PrefixedKeys: true}
+}
+
 func findRequest(qname string, corpora, langs []string) ipb.FindRequest {
 	return ipb.FindRequest{
 		Identifier: qname,
@@ -113,6 +341,18 @@ func match(ticket, kind, subkind, bname, qname string) *ipb.FindReply_Match {
 	}
 }
 
+func refNode(ticket, kind, subkind string, refs int32) *srvpb.IdentifierMatch_Node {
+	n := node(ticket, kind, subkind)
+	n.RefCount = refs
+	return n
+}
+
+func refMatch(ticket, kind, subkind, bname, qname string, refs int32) *ipb.FindReply_Match {
+	m := match(ticket, kind, subkind, bname, qname)
+	m.RefCount = refs
+	return m
+}
+
 type testCase struct {
 	ipb.FindRequest
 	Matches []*ipb.FindReply_Match
@@ -23,7 +23,10 @@ import (
 	"sort"
 	"strconv"
 
+	"kythe.io/kythe/go/serving/explore"
+	"kythe.io/kythe/go/serving/identifiers"
 	"kythe.io/kythe/go/serving/pipeline/nodes"
+	"kythe.io/kythe/go/serving/search"
 	"kythe.io/kythe/go/serving/xrefs/assemble"
 	"kythe.io/kythe/go/util/compare"
 	"kythe.io/kythe/go/util/kytheuri"
@@ -33,6 +36,7 @@ import (
 	kinds "kythe.io/kythe/go/util/schema/nodes"
 	"kythe.io/kythe/go/util/span"
 
+	"bitbucket.org/creachadair/stringset"
 	"github.com/apache/beam/sdks/go/pkg/beam"
 	"github.com/apache/beam/sdks/go/pkg/beam/transforms/filter"
 	"github.com/golang/protobuf/proto"
@@ -47,10 +51,15 @@ import (
 )
 
 func init() {
+	beam.RegisterFunction(anchorToCallEdges)
+	beam.RegisterFunction(anchorToFileLanguage)
+	beam.RegisterFunction(anchorToRefTargets)
 	beam.RegisterFunction(bareRevEdge)
 	beam.RegisterFunction(callEdge)
 	beam.RegisterFunction(combineEdgesIndex)
 	beam.RegisterFunction(completeDocument)
+	beam.RegisterFunction(completeFunctionParameters)
+	beam.RegisterFunction(composeOverrides)
 	beam.RegisterFunction(constructCaller)
 	beam.RegisterFunction(defToDecorPiece)
 	beam.RegisterFunction(diagToDecor)
@@ -59,33 +68,57 @@ func init() {
 	beam.RegisterFunction(emitRelatedDefs)
 	beam.RegisterFunction(fileToDecorPiece)
 	beam.RegisterFunction(fileToTags)
+	beam.RegisterFunction(fileToTrigrams)
 	beam.RegisterFunction(filterAnchorNodes)
-	beam.RegisterFunction(groupCrossRefs)
 	beam.RegisterFunction(groupEdges)
+	beam.RegisterFunction(groupIdentifierMatch)
+	beam.RegisterFunction(groupIdentifierPostings)
+	beam.RegisterFunction(identifierToIndexKeys)
+	beam.RegisterFunction(groupPostings)
+	beam.RegisterFunction(keyByEdgeTargets)
 	beam.RegisterFunction(keyByPath)
 	beam.RegisterFunction(keyCrossRef)
+	beam.RegisterFunction(keyEdgeBySource)
+	beam.RegisterFunction(keyEdgeByTarget)
 	beam.RegisterFunction(keyNode)
 	beam.RegisterFunction(keyRef)
+	beam.RegisterFunction(keyReturnType)
 	beam.RegisterFunction(moveSourceToKey)
+	beam.RegisterFunction(nodeToChildOfEdges)
 	beam.RegisterFunction(nodeToChildren)
 	beam.RegisterFunction(nodeToDecorPiece)
 	beam.RegisterFunction(nodeToDiagnostic)
 	beam.RegisterFunction(nodeToDocs)
 	beam.RegisterFunction(nodeToEdges)
+	beam.RegisterFunction(nodeToOverrideEdges)
+	beam.RegisterFunction(nodeToParamEdges)
 	beam.RegisterFunction(nodeToReverseEdges)
+	beam.RegisterFunction(nodeToTypeRelations)
+	beam.RegisterFunction(overrideToDecorPieces)
 	beam.RegisterFunction(parseMarkedSource)
 	beam.RegisterFunction(refToCallsite)
 	beam.RegisterFunction(refToCrossRef)
 	beam.RegisterFunction(refToDecorPiece)
+	beam.RegisterFunction(refToDefFile)
 	beam.RegisterFunction(refToTag)
 	beam.RegisterFunction(reverseEdge)
+	beam.RegisterFunction(reverseSplitEdge)
 	beam.RegisterFunction(splitEdge)
 	beam.RegisterFunction(targetToFile)
 	beam.RegisterFunction(toDefinition)
+	beam.RegisterFunction(toFileDecorOverride)
+	beam.RegisterFunction(toFunctionParameter)
+	beam.RegisterFunction(toFunctionReturnType)
+	beam.RegisterFunction(toIdentifierNode)
 	beam.RegisterFunction(toFiles)
 	beam.RegisterFunction(toRefs)
+	beam.RegisterFunction(toSearchFile)
 
 	beam.RegisterType(reflect.TypeOf((*combineDecorPieces)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*groupCallgraph)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*groupCrossRefs)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*groupRelatives)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*groupTypeHierarchy)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*ticketKey)(nil)).Elem())
 
 	beam.RegisterType(reflect.TypeOf((*cpb.Diagnostic)(nil)).Elem())
@@ -96,16 +129,26 @@ func init() {
 	beam.RegisterType(reflect.TypeOf((*scpb.Node)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*spb.Entry)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*spb.VName)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.Callgraph)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.CorpusRoots)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.Document)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.EdgePage)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.ExpandedAnchor)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.File)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.FileDecorations)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FileDecorations_Override)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.FileDirectory)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FunctionParameters)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FunctionParameters_Parameter)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.IdentifierMatch)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.IdentifierPostings)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedCrossReferences)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedCrossReferences_Page)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedEdgeSet)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.Relatives)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.SearchFile)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.SearchPostings)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.TypeHierarchy)(nil)).Elem())
 }
 
 // KytheBeam controls the lifetime and generation of PCollections in the Kythe
@@ -122,8 +165,15 @@ type KytheBeam struct {
 	markedSources beam.PCollection // KV<*spb.VName, *cpb.MarkedSource>
 
 	anchorBuildConfigs beam.PCollection // KV<*spb.VName, string>
+
+	maxPageSize int
 }
 
+// SetMaxPageSize sets the maximum number of cross-references allowed in each
+// PagedCrossReferences and PagedCrossReferences_Page emitted by
+// CrossReferences.  If n <= 0, no paging is attempted.
+func (k *KytheBeam) SetMaxPageSize(n int) { k.maxPageSize = n }
+
 // FromNodes creates a KytheBeam pipeline from an input collection of
 // *spb.Nodes.
 func FromNodes(s beam.Scope, nodes beam.PCollection) *KytheBeam { return &KytheBeam{s: s, nodes: nodes} }
@@ -151,22 +201,27 @@ func (k *KytheBeam) SplitCrossReferences() beam.PCollection {
 
 	callgraph := k.callGraph()
 
-	edges := k.edgeRelations()
-	relatedDefs := beam.ParDo(s, emitRelatedDefs, beam.CoGroupByKey(s,
-		k.directDefinitions(),
-		beam.ParDo(s, splitEdge, filter.Distinct(s, beam.ParDo(s, bareRevEdge, edges))),
-	))
-	relations := beam.ParDo(s, edgeToCrossRefRelation, edges)
-
 	return beam.ParDo(s, encodeCrossRef, beam.Flatten(s,
 		idx,
 		refs,
-		relations,
-		relatedDefs,
+		k.relations(),
 		callgraph,
 	))
 }
 
+// relations returns a beam.PCollection of *xspb.CrossReferences containing
+// the Relation, RelatedNode, and NodeDefinition entries for each node's
+// related nodes.
+func (k *KytheBeam) relations() beam.PCollection {
+	s := k.s.Scope("Relations")
+	edges := k.edgeRelations()
+	relatedDefs := beam.ParDo(s, emitRelatedDefs, beam.CoGroupByKey(s,
+		k.directDefinitions(),
+		beam.ParDo(s, splitEdge, filter.Distinct(s, beam.ParDo(s, bareRevEdge, edges))),
+	))
+	return beam.Flatten(s, beam.ParDo(s, edgeToCrossRefRelation, edges), relatedDefs)
+}
+
 func (k *KytheBeam) callGraph() beam.PCollection {
 	s := k.s.Scope("CallGraph")
 	callsites := beam.ParDo(s, refToCallsite, k.References())
@@ -278,20 +333,20 @@ func edgeToCrossRefRelation(eg *gspb.Edges, emit func(*xspb.CrossReferences)) er
 	}
 }
 
-// CrossReferences returns a Kythe file decorations table derived from the Kythe
-// input graph.  The beam.PCollections have elements of type
-// KV<string, *srvpb.PagedCrossReferences> and
-// KV<string, *srvpb.PagedCrossReferences_Page>, respectively.
+// CrossReferences returns a Kythe cross-references table derived from the
+// Kythe input graph.  The beam.PCollections have elements of type
+// KV<string, *srvpb.PagedCrossReferences> and
+// KV<string, *srvpb.PagedCrossReferences_Page>, respectively.  Sets larger
+// than the KytheBeam's maximum page size are split into pages.
 func (k *KytheBeam) CrossReferences() (sets, pages beam.PCollection) {
 	s := k.s.Scope("CrossReferences")
 	refs := beam.CoGroupByKey(s,
 		beam.ParDo(s, keyRef, k.References()),
-		beam.ParDo(s, keyCrossRef, k.callGraph()),
+		beam.ParDo(s, keyCrossRef, beam.Flatten(s, k.callGraph(), k.relations())),
+		beam.ParDo(s, keyNode, k.Nodes()),
+		k.getMarkedSources(),
 	)
-	// TODO(schroederc): related nodes
-	// TODO(schroederc): MarkedSource
-	// TODO(schroederc): source_node
-	return beam.ParDo2(s, groupCrossRefs, refs)
+	return beam.ParDo2(s, &groupCrossRefs{MaxPageSize: k.maxPageSize}, refs)
 }
 
 var callerKinds = map[xspb.CrossReferences_Callsite_Kind]string{
@@ -299,68 +354,86 @@ var callerKinds = map[xspb.CrossReferences_Callsite_Kind]string{
 	xspb.CrossReferences_Callsite_OVERRIDE: "#internal/ref/call/override",
 }
 
-// groupCrossRefs emits *srvpb.PagedCrossReferences and *srvpb.PagedCrossReferences_Pages for a
-// single node's collection of *ppb.References and callsites.
-func groupCrossRefs(
+// groupCrossRefs emits *srvpb.PagedCrossReferences and
+// *srvpb.PagedCrossReferences_Pages for a single node's collection of
+// *ppb.References, callsites, and related nodes.  If MaxPageSize > 0, groups
+// that do not fit within the PagedCrossReferences are split into pages of at
+// most MaxPageSize cross-references.
+type groupCrossRefs struct{ MaxPageSize int }
+
+func (g *groupCrossRefs) ProcessElement(
 	key *spb.VName,
 	refStream func(**ppb.Reference) bool,
-	callStream func(**xspb.CrossReferences) bool,
+	xrefStream func(**xspb.CrossReferences) bool,
+	nodeStream func(**scpb.Node) bool,
+	msStream func(**cpb.MarkedSource) bool,
 	emitSet func(string, *srvpb.PagedCrossReferences),
 	emitPage func(string, *srvpb.PagedCrossReferences_Page)) {
 	set := &srvpb.PagedCrossReferences{SourceTicket: kytheuri.ToString(key)}
-	// TODO(schroederc): add paging
+
+	var node *scpb.Node
+	if nodeStream(&node) {
+		set.SourceNode = convertPipelineNode(node)
+		for _, f := range set.SourceNode.Fact {
+			if f.Name == facts.Complete && string(f.Value) != "definition" {
+				set.Incomplete = true
+			}
+		}
+	}
+	var ms *cpb.MarkedSource
+	if msStream(&ms) {
+		set.MarkedSource = ms
+	}
 
 	// kind -> build_config -> group
 	groups := make(map[string]map[string]*srvpb.PagedCrossReferences_Group)
-
-	var ref *ppb.Reference
-	for refStream(&ref) {
-		kind := refKind(ref)
+	group := func(kind, config string) *srvpb.PagedCrossReferences_Group {
 		configs, ok := groups[kind]
 		if !ok {
 			configs = make(map[string]*srvpb.PagedCrossReferences_Group)
 			groups[kind] = configs
 		}
-		config := ref.Anchor.BuildConfiguration
 		g, ok := configs[config]
 		if !ok {
 			g = &srvpb.PagedCrossReferences_Group{Kind: kind, BuildConfig: config}
 			configs[config] = g
 			set.Group = append(set.Group, g)
 		}
+		return g
+	}
+
+	var ref *ppb.Reference
+	for refStream(&ref) {
+		g := group(refKind(ref), ref.Anchor.BuildConfiguration)
 		g.Anchor = append(g.Anchor, ref.Anchor)
 	}
 
 	callers := make(map[string]*xspb.CrossReferences_Caller)
 	callsites := make(map[string][]*xspb.CrossReferences_Callsite)
-	var call *xspb.CrossReferences
-	for callStream(&call) {
-		switch e := call.Entry.(type) {
+	var relations []*xspb.CrossReferences_Relation
+	relatedNodes := make(map[string]*srvpb.Node)
+	relatedDefs := make(map[string]*srvpb.ExpandedAnchor)
+	var xr *xspb.CrossReferences
+	for xrefStream(&xr) {
+		switch e := xr.Entry.(type) {
 		case *xspb.CrossReferences_Caller_:
 			callers[kytheuri.ToString(e.Caller.Caller)] = e.Caller
 		case *xspb.CrossReferences_Callsite_:
 			ticket := kytheuri.ToString(e.Callsite.Caller)
 			callsites[ticket] = append(callsites[ticket], e.Callsite)
+		case *xspb.CrossReferences_Relation_:
+			relations = append(relations, e.Relation)
+		case *xspb.CrossReferences_RelatedNode_:
+			n := convertPipelineNode(e.RelatedNode.Node)
+			relatedNodes[n.Ticket] = n
+		case *xspb.CrossReferences_NodeDefinition_:
+			relatedDefs[kytheuri.ToString(e.NodeDefinition.Node)] = e.NodeDefinition.Location
 		}
 	}
+
 	for ticket, caller := range callers {
 		for _, site := range callsites[ticket] {
-			kind := callerKinds[site.Kind]
-			configs, ok := groups[kind]
-			if !ok {
-				configs = make(map[string]*srvpb.PagedCrossReferences_Group)
-				groups[kind] = configs
-			}
-			config := site.Location.BuildConfiguration
-			g, ok := configs[config]
-			if !ok {
-				g = &srvpb.PagedCrossReferences_Group{
-					Kind:        kind,
-					BuildConfig: config,
-				}
-				configs[config] = g
-				set.Group = append(set.Group, g)
-			}
+			g := group(callerKinds[site.Kind], site.Location.BuildConfiguration)
 
 			var groupCaller *srvpb.PagedCrossReferences_Caller
 			for _, c := range g.Caller {
@@ -381,20 +454,140 @@ func groupCrossRefs(
 		}
 	}
 
+	related := make(map[string]*srvpb.PagedCrossReferences_Group)
+	for _, r := range relations {
+		kind := r.GetGenericKind()
+		if kind == "" {
+			kind = schema.EdgeKindString(r.GetKytheKind())
+		}
+		if r.Reverse {
+			kind = "%" + kind
+		}
+
+		ticket := kytheuri.ToString(r.Node)
+		n, ok := relatedNodes[ticket]
+		if !ok {
+			n = &srvpb.Node{Ticket: ticket}
+			relatedNodes[ticket] = n
+		}
+		if n.DefinitionLocation == nil {
+			n.DefinitionLocation = relatedDefs[ticket]
+		}
+
+		g, ok := related[kind]
+		if !ok {
+			g = &srvpb.PagedCrossReferences_Group{Kind: kind}
+			related[kind] = g
+			set.Group = append(set.Group, g)
+		}
+		g.RelatedNode = append(g.RelatedNode, &srvpb.PagedCrossReferences_RelatedNode{
+			Node:    n,
+			Ordinal: r.Ordinal,
+		})
+	}
+
+	if len(set.Group) == 0 {
+		return // no cross-references for this node
+	}
+
 	sort.Slice(set.Group, func(i, j int) bool {
 		return compare.Strings(set.Group[i].BuildConfig, set.Group[j].BuildConfig).
 			AndThen(set.Group[i].Kind, set.Group[j].Kind) == compare.LT
 	})
 	for _, g := range set.Group {
 		sort.Slice(g.Anchor, func(i, j int) bool { return g.Anchor[i].Ticket < g.Anchor[j].Ticket })
+		sort.Slice(g.Caller, func(i, j int) bool { return g.Caller[i].SemanticCaller < g.Caller[j].SemanticCaller })
 		for _, caller := range g.Caller {
 			sort.Slice(caller.Callsite, func(i, j int) bool { return caller.Callsite[i].Ticket < caller.Callsite[j].Ticket })
 		}
+		sort.Slice(g.RelatedNode, func(i, j int) bool {
+			a, b := g.RelatedNode[i], g.RelatedNode[j]
+			return compare.Ints(int(a.Ordinal), int(b.Ordinal)).
+				AndThen(a.Node.Ticket, b.Node.Ticket) == compare.LT
+		})
 	}
 
+	for _, p := range g.page(set) {
+		emitPage("xrefPages:"+p.PageKey, p)
+	}
 	emitSet("xrefs:"+set.SourceTicket, set)
 }
 
+// page splits the groups of set into pages, if necessary.  Groups are kept
+// within the set, in order, while the set's total number of cross-references
+// is at most MaxPageSize.  Each remaining group is moved into one or more
+// pages, each holding at most MaxPageSize cross-references, and a
+// corresponding PageIndex is added to the set.
+func (g *groupCrossRefs) page(set *srvpb.PagedCrossReferences) []*srvpb.PagedCrossReferences_Page {
+	if g.MaxPageSize <= 0 {
+		return nil
+	}
+
+	var total int
+	for _, grp := range set.Group {
+		total += xrefGroupSize(grp)
+	}
+	if total <= g.MaxPageSize {
+		return nil
+	}
+
+	var (
+		pages  []*srvpb.PagedCrossReferences_Page
+		inline []*srvpb.PagedCrossReferences_Group
+		size   int
+	)
+	for _, grp := range set.Group {
+		if sz := xrefGroupSize(grp); size+sz <= g.MaxPageSize {
+			inline = append(inline, grp)
+			size += sz
+			continue
+		}
+		for rest := grp; rest != nil; {
+			var pg *srvpb.PagedCrossReferences_Group
+			pg, rest = splitXRefGroup(rest, g.MaxPageSize)
+			key := fmt.Sprintf("%s.%.10d", set.SourceTicket, len(set.PageIndex))
+			set.PageIndex = append(set.PageIndex, &srvpb.PagedCrossReferences_PageIndex{
+				Kind:        pg.Kind,
+				Count:       int32(xrefGroupSize(pg)),
+				PageKey:     key,
+				BuildConfig: pg.BuildConfig,
+			})
+			pages = append(pages, &srvpb.PagedCrossReferences_Page{
+				PageKey:      key,
+				SourceTicket: set.SourceTicket,
+				Group:        pg,
+			})
+		}
+	}
+	set.Group = inline
+	return pages
+}
+
+// xrefGroupSize returns the number of cross-references in the given group.
+func xrefGroupSize(g *srvpb.PagedCrossReferences_Group) int {
+	return len(g.Anchor) + len(g.RelatedNode) + len(g.Caller)
+}
+
+// splitXRefGroup splits g into a group holding its first n cross-references
+// and a group with the remainder, which is nil if nothing remains.
+func splitXRefGroup(g *srvpb.PagedCrossReferences_Group, n int) (head, rest *srvpb.PagedCrossReferences_Group) {
+	if xrefGroupSize(g) <= n {
+		return g, nil
+	}
+	head = &srvpb.PagedCrossReferences_Group{Kind: g.Kind, BuildConfig: g.BuildConfig}
+	rest = &srvpb.PagedCrossReferences_Group{Kind: g.Kind, BuildConfig: g.BuildConfig}
+	// A group is composed entirely of anchors, related nodes, or callers.
+	switch {
+	case len(g.Anchor) > 0:
+		head.Anchor, rest.Anchor = g.Anchor[:n], g.Anchor[n:]
+	case len(g.RelatedNode) > 0:
+		head.RelatedNode, rest.RelatedNode = g.RelatedNode[:n], g.RelatedNode[n:]
+	default:
+		head.Caller, rest.Caller = g.Caller[:n], g.Caller[n:]
+	}
+	return head, rest
+}
+
 func keyRef(r *ppb.Reference) (*spb.VName, *ppb.Reference) {
 	return r.Source, &ppb.Reference{
 		Kind:   r.Kind,
@@ -409,7 +602,8 @@ func keyCrossRef(xr *xspb.CrossReferences) (*spb.VName, *xspb.CrossReferences) {
 func (k *KytheBeam) decorationPieces(s beam.Scope) beam.PCollection {
 	decor := beam.ParDo(s, refToDecorPiece, k.References())
 
-	targets := beam.ParDo(s, targetToFile, decor)
+	overrides, overridden := k.overridePieces(s)
+	targets := beam.Flatten(s, beam.ParDo(s, targetToFile, decor), overridden)
 	bareNodes := beam.ParDo(s, &nodes.Filter{IncludeEdges: []string{}}, k.nodes)
 
 	files := beam.ParDo(s, fileToDecorPiece, k.getFiles())
@@ -417,10 +611,151 @@ func (k *KytheBeam) decorationPieces(s beam.Scope) beam.PCollection {
 		beam.CoGroupByKey(s, beam.ParDo(s, moveSourceToKey, bareNodes), targets))
 	defs := beam.ParDo(s, defToDecorPiece,
 		beam.CoGroupByKey(s, k.directDefinitions(), targets))
-	// TODO(schroederc): overrides
 	decorDiagnostics := k.diagnostics()
 
-	return beam.Flatten(s, decor, files, targetNodes, defs, decorDiagnostics)
+	return beam.Flatten(s, decor, files, targetNodes, defs, overrides, decorDiagnostics)
+}
+
+// overrideClosureRounds is the number of rounds used to find transitive
+// overrides.  Each round doubles the length of the override chains found, so
+// chains of up to 2^overrideClosureRounds overrides edges are closed.
+const overrideClosureRounds = 6
+
+// overrideEdges returns a beam.PCollection of *scpb.Edges for each overrides
+// and extends relation in the Kythe input graph.  Extends edge variants are
+// normalized to the EXTENDS kind and overrides edges are transitively closed.
+//
+// The closure is found by joining the known paths on their shared nodes for a
+// fixed number of rounds, so no single worker must hold all of the relations.
+func (k *KytheBeam) overrideEdges(s beam.Scope) beam.PCollection {
+	s = s.Scope("OverrideEdges")
+	paths := beam.ParDo(s, nodeToOverrideEdges, k.nodes)
+	for i := 0; i < overrideClosureRounds; i++ {
+		composed := beam.ParDo(s, composeOverrides, beam.CoGroupByKey(s,
+			beam.ParDo(s, keyEdgeByTarget, paths),
+			beam.ParDo(s, keyEdgeBySource, paths),
+		))
+		paths = filter.Distinct(s, beam.Flatten(s, paths, composed))
+	}
+	return paths
+}
+
+// overridePieces returns a TargetOverride *ppb.DecorationPiece for each file
+// defining a node that overrides/extends another node.  The overridden nodes
+// are also returned as additional decoration targets (KV<*spb.VName,
+// *spb.VName>) so that their nodes and definitions are included in the file's
+// decorations.
+func (k *KytheBeam) overridePieces(s beam.Scope) (pieces, targets beam.PCollection) {
+	s = s.Scope("Overrides")
+	overrides := beam.ParDo(s, toFileDecorOverride, beam.CoGroupByKey(s,
+		beam.ParDo(s, keyEdgeByTarget, k.overrideEdges(s)),
+		k.getMarkedSources(),
+	))
+	defFiles := beam.ParDo(s, refToDefFile, k.References())
+	return beam.ParDo2(s, overrideToDecorPieces, beam.CoGroupByKey(s, overrides, defFiles))
+}
+
+func nodeToOverrideEdges(n *scpb.Node, emit func(*scpb.Edge)) {
+	for _, e := range n.Edge {
+		kind := schema.GetEdgeKind(e)
+		if kind == edges.Overrides {
+			emit(&scpb.Edge{Source: n.Source, Kind: &scpb.Edge_KytheKind{scpb.EdgeKind_OVERRIDES}, Target: e.Target})
+		} else if edges.IsVariant(kind, edges.Extends) {
+			emit(&scpb.Edge{Source: n.Source, Kind: &scpb.Edge_KytheKind{scpb.EdgeKind_EXTENDS}, Target: e.Target})
+		}
+	}
+}
+
+func keyEdgeBySource(e *scpb.Edge) (*spb.VName, *scpb.Edge) { return e.Source, e }
+func keyEdgeByTarget(e *scpb.Edge) (*spb.VName, *scpb.Edge) { return e.Target, e }
+
+// composeOverrides emits an overrides *scpb.Edge joining each overrides edge
+// targeting key with each overrides edge sourced from key.  No node is emitted
+// as overriding itself.
+func composeOverrides(key *spb.VName, inStream func(**scpb.Edge) bool, outStream func(**scpb.Edge) bool, emit func(*scpb.Edge)) {
+	var in, out []*scpb.Edge
+	var e *scpb.Edge
+	for inStream(&e) {
+		if e.GetKytheKind() == scpb.EdgeKind_OVERRIDES {
+			in = append(in, e)
+		}
+	}
+	if len(in) == 0 {
+		return
+	}
+	for outStream(&e) {
+		if e.GetKytheKind() == scpb.EdgeKind_OVERRIDES {
+			out = append(out, e)
+		}
+	}
+	for _, i := range in {
+		for _, o := range out {
+			if proto.Equal(i.Source, o.Target) {
+				continue // skip override cycles
+			}
+			emit(&scpb.Edge{Source: i.Source, Kind: i.Kind, Target: o.Target})
+		}
+	}
+}
+
+// toFileDecorOverride emits a *srvpb.FileDecorations_Override, keyed by its
+// overriding node, for each override of the given node.
+func toFileDecorOverride(overridden *spb.VName, edgeStream func(**scpb.Edge) bool, msStream func(**cpb.MarkedSource) bool, emit func(*spb.VName, *srvpb.FileDecorations_Override)) {
+	var ms *cpb.MarkedSource
+	msStream(&ms)
+
+	ticket := kytheuri.ToString(overridden)
+	var e *scpb.Edge
+	for edgeStream(&e) {
+		kind := srvpb.FileDecorations_Override_OVERRIDES
+		if e.GetKytheKind() == scpb.EdgeKind_EXTENDS {
+			kind = srvpb.FileDecorations_Override_EXTENDS
+		}
+		emit(e.Source, &srvpb.FileDecorations_Override{
+			Overriding:   kytheuri.ToString(e.Source),
+			Overridden:   ticket,
+			Kind:         kind,
+			MarkedSource: ms,
+		})
+	}
+}
+
+func refToDefFile(r *ppb.Reference, emit func(*spb.VName, *spb.VName)) error {
+	if kind := refKind(r); kind != edges.Defines && kind != edges.DefinesBinding {
+		return nil
+	}
+	file, err := anchorToFileVName(r.Anchor.Ticket)
+	if err != nil {
+		return err
+	}
+	emit(r.Source, file)
+	return nil
+}
+
+// overrideToDecorPieces emits a TargetOverride *ppb.DecorationPiece for each of
+// the node's overrides in each file defining the node.  Each overridden node
+// is also emitted as a target of the file's decorations.
+func overrideToDecorPieces(node *spb.VName, overrideStream func(**srvpb.FileDecorations_Override) bool, fileStream func(**spb.VName) bool, emitPiece func(*spb.VName, *ppb.DecorationPiece), emitTarget func(*spb.VName, *spb.VName)) error {
+	files := distinctFiles(fileStream)
+	if len(files) == 0 {
+		return nil
+	}
+
+	var o *srvpb.FileDecorations_Override
+	for overrideStream(&o) {
+		overridden, err := kytheuri.ToVName(o.Overridden)
+		if err != nil {
+			return err
+		}
+		piece := &ppb.DecorationPiece{
+			Piece: &ppb.DecorationPiece_TargetOverride{o},
+		}
+		for _, file := range files {
+			emitPiece(file, piece)
+			emitTarget(overridden, file)
+		}
+	}
+	return nil
 }
 
 func (k *KytheBeam) diagnostics() beam.PCollection {
@@ -571,6 +906,8 @@ func (c *combineDecorPieces) AddInput(accum *srvpb.FileDecorations, p *ppb.Decor
 		})
 	case *ppb.DecorationPiece_Diagnostic:
 		accum.Diagnostic = append(accum.Diagnostic, p.Diagnostic)
+	case *ppb.DecorationPiece_TargetOverride:
+		accum.TargetOverride = append(accum.TargetOverride, p.TargetOverride)
 	default:
 		panic(fmt.Errorf("unhandled DecorationPiece: %T", p))
 	}
@@ -633,6 +970,13 @@ func (c *combineDecorPieces) ExtractOutput(fd *srvpb.FileDecorations) *srvpb.Fil
 		return fd.Decoration[i].Target < fd.Decoration[j].Target
 	})
 	sort.Slice(fd.Target, func(i, j int) bool { return fd.Target[i].Ticket < fd.Target[j].Ticket })
+	sort.Slice(fd.TargetDefinitions, func(i, j int) bool { return fd.TargetDefinitions[i].Ticket < fd.TargetDefinitions[j].Ticket })
+	sort.Slice(fd.TargetOverride, func(i, j int) bool {
+		a, b := fd.TargetOverride[i], fd.TargetOverride[j]
+		return compare.Strings(a.Overriding, b.Overriding).
+			AndThen(int(a.Kind), int(b.Kind)).
+			AndThen(a.Overridden, b.Overridden) == compare.LT
+	})
 
 	sort.Slice(fd.Diagnostic, func(i, j int) bool {
 		a, b := fd.Diagnostic[i], fd.Diagnostic[j]
@@ -701,8 +1045,7 @@ func nodeToDecorPiece(key *spb.VName, node func(**scpb.Node) bool, file func(**s
 		}},
 	}
 
-	var f *spb.VName
-	for file(&f) {
+	for _, f := range distinctFiles(file) {
 		emit(f, piece)
 	}
 }
@@ -722,12 +1065,26 @@ func defToDecorPiece(node *spb.VName, defs func(**srvpb.ExpandedAnchor) bool, fi
 			Definition: def,
 		}},
 	}
-	var f *spb.VName
-	for file(&f) {
+	for _, f := range distinctFiles(file) {
 		emit(f, piece)
 	}
 }
 
+// distinctFiles returns the distinct file VNames within the given stream.  A
+// node may be the target of many decorations within the same file.
+func distinctFiles(fileStream func(**spb.VName) bool) []*spb.VName {
+	var files []*spb.VName
+	seen := make(map[string]bool)
+	var f *spb.VName
+	for fileStream(&f) {
+		if ticket := kytheuri.ToString(f); !seen[ticket] {
+			seen[ticket] = true
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
 // Nodes returns all *scpb.Nodes from the Kythe input graph.
 func (k *KytheBeam) Nodes() beam.PCollection { return k.nodes }
 
@@ -1072,6 +1429,476 @@ func groupEdges(src *spb.VName, nodeStream func(**scpb.Node) bool, edgeStream, r
 	emitSet("edgeSets:"+set.Source.Ticket, set)
 }
 
+// TypeHierarchy returns the Kythe type hierarchy tables derived from the
+// extends, satisfies, and overrides edges in the Kythe input graph.  The
+// beam.PCollections have elements of type KV<string, *srvpb.TypeHierarchy>
+// holding each node's direct supertypes and subtypes, respectively.
+func (k *KytheBeam) TypeHierarchy() (supertypes, subtypes beam.PCollection) {
+	s := k.s.Scope("TypeHierarchy")
+
+	rels := filter.Distinct(s, beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{},
+	}, nodeToTypeRelations))
+
+	supertypes = beam.ParDo(s, &groupTypeHierarchy{Type: srvpb.TypeHierarchy_SUPERTYPES},
+		beam.GroupByKey(s, beam.ParDo(s, splitEdge, rels)))
+	subtypes = beam.ParDo(s, &groupTypeHierarchy{Type: srvpb.TypeHierarchy_SUBTYPES},
+		beam.GroupByKey(s, beam.ParDo(s, reverseSplitEdge, rels)))
+	return supertypes, subtypes
+}
+
+// nodeToTypeRelations emits a bare (subtype -> supertype) *scpb.Edge for each of
+// n's type hierarchy edges.
+func nodeToTypeRelations(n *scpb.Node, emit func(*scpb.Edge)) {
+	for _, e := range n.Edge {
+		if isTypeHierarchyEdge(schema.GetEdgeKind(e)) {
+			emit(&scpb.Edge{Source: n.Source, Target: e.Target})
+		}
+	}
+}
+
+func reverseSplitEdge(e *scpb.Edge) (*spb.VName, *spb.VName) { return e.Target, e.Source }
+
+// groupTypeHierarchy emits a single *srvpb.TypeHierarchy of the given Type for
+// each node and its directly related types.
+type groupTypeHierarchy struct{ Type srvpb.TypeHierarchy_Type }
+
+func (g *groupTypeHierarchy) ProcessElement(key *spb.VName, relStream func(**spb.VName) bool) (string, *srvpb.TypeHierarchy) {
+	h := &srvpb.TypeHierarchy{Type: g.Type}
+	var rel *spb.VName
+	for relStream(&rel) {
+		h.Tickets = append(h.Tickets, kytheuri.ToString(rel))
+	}
+	sort.Strings(h.Tickets)
+
+	ticket := kytheuri.ToString(key)
+	if g.Type == srvpb.TypeHierarchy_SUBTYPES {
+		return string(explore.SubtypesKey(ticket)), h
+	}
+	return string(explore.SupertypesKey(ticket)), h
+}
+
+// Relatives returns the Kythe parents and children tables derived from the
+// childof edges of the non-anchor nodes in the Kythe input graph.  The
+// beam.PCollections have elements of type KV<string, *srvpb.Relatives>
+// holding each node's parents and children, respectively.
+func (k *KytheBeam) Relatives() (parents, children beam.PCollection) {
+	s := k.s.Scope("Relatives")
+
+	rels := filter.Distinct(s, beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.ChildOf},
+	}, nodeToChildOfEdges))
+
+	parents = beam.ParDo(s, &groupRelatives{Type: srvpb.Relatives_PARENTS},
+		beam.GroupByKey(s, beam.ParDo(s, splitEdge, rels)))
+	children = beam.ParDo(s, &groupRelatives{Type: srvpb.Relatives_CHILDREN},
+		beam.GroupByKey(s, beam.ParDo(s, reverseSplitEdge, rels)))
+	return parents, children
+}
+
+// nodeToChildOfEdges emits a bare (child -> parent) *scpb.Edge for each of n's
+// childof edges, unless n is an anchor.
+func nodeToChildOfEdges(n *scpb.Node, emit func(*scpb.Edge)) {
+	if n.GetKytheKind() == scpb.NodeKind_ANCHOR {
+		return
+	}
+	for _, e := range n.Edge {
+		if e.GetKytheKind() == scpb.EdgeKind_CHILD_OF {
+			emit(&scpb.Edge{Source: n.Source, Target: e.Target})
+		}
+	}
+}
+
+// groupRelatives emits a single *srvpb.Relatives of the given Type for each
+// node and its parents or children.
+type groupRelatives struct{ Type srvpb.Relatives_Type }
+
+func (g *groupRelatives) ProcessElement(key *spb.VName, relStream func(**spb.VName) bool) (string, *srvpb.Relatives) {
+	r := &srvpb.Relatives{Type: g.Type}
+	var rel *spb.VName
+	for relStream(&rel) {
+		r.Tickets = append(r.Tickets, kytheuri.ToString(rel))
+	}
+	sort.Strings(r.Tickets)
+
+	ticket := kytheuri.ToString(key)
+	if g.Type == srvpb.Relatives_CHILDREN {
+		return string(explore.ChildrenKey(ticket)), r
+	}
+	return string(explore.ParentsKey(ticket)), r
+}
+
+// Callgraph returns the Kythe callers and callees tables derived from the
+// ref/call anchors in the Kythe input graph.  A node calls the targets of each
+// ref/call anchor that is a childof it.  The beam.PCollections have elements
+// of type KV<string, *srvpb.Callgraph> holding each node's callers and
+// callees, respectively.
+func (k *KytheBeam) Callgraph() (callers, callees beam.PCollection) {
+	s := k.s.Scope("Callgraph")
+
+	calls := filter.Distinct(s, beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.Anchor},
+		IncludeFacts: []string{},
+	}, anchorToCallEdges))
+
+	callers = beam.ParDo(s, &groupCallgraph{Type: srvpb.Callgraph_CALLER},
+		beam.GroupByKey(s, beam.ParDo(s, reverseSplitEdge, calls)))
+	callees = beam.ParDo(s, &groupCallgraph{Type: srvpb.Callgraph_CALLEE},
+		beam.GroupByKey(s, beam.ParDo(s, splitEdge, calls)))
+	return callers, callees
+}
+
+// anchorToCallEdges emits a bare (caller -> callee) *scpb.Edge for each of the
+// anchor's ref/call targets and each of its parents other than its file.
+func anchorToCallEdges(n *scpb.Node, emit func(*scpb.Edge)) {
+	file := fileVName(n.Source)
+	var callers, callees []*spb.VName
+	for _, e := range n.Edge {
+		if e.GetKytheKind() == scpb.EdgeKind_CHILD_OF {
+			if !proto.Equal(e.Target, file) {
+				callers = append(callers, e.Target)
+			}
+		} else if edges.IsVariant(schema.GetEdgeKind(e), edges.RefCall) {
+			callees = append(callees, e.Target)
+		}
+	}
+	for _, caller := range callers {
+		for _, callee := range callees {
+			emit(&scpb.Edge{Source: caller, Target: callee})
+		}
+	}
+}
+
+// groupCallgraph emits a single *srvpb.Callgraph of the given Type for each
+// node and its callers or callees.
+type groupCallgraph struct{ Type srvpb.Callgraph_Type }
+
+func (g *groupCallgraph) ProcessElement(key *spb.VName, relStream func(**spb.VName) bool) (string, *srvpb.Callgraph) {
+	cg := &srvpb.Callgraph{Type: g.Type}
+	var rel *spb.VName
+	for relStream(&rel) {
+		cg.Tickets = append(cg.Tickets, kytheuri.ToString(rel))
+	}
+	sort.Strings(cg.Tickets)
+
+	ticket := kytheuri.ToString(key)
+	if g.Type == srvpb.Callgraph_CALLER {
+		return string(explore.CallersKey(ticket)), cg
+	}
+	return string(explore.CalleesKey(ticket)), cg
+}
+
+// SearchIndex returns the Kythe code search tables derived from the text of
+// the files in the Kythe input graph and the languages of their anchors.  The
+// postings beam.PCollection has elements of type KV<string,
+// *srvpb.SearchPostings> and the files beam.PCollection has elements of type
+// KV<string, *srvpb.SearchFile>.
+func (k *KytheBeam) SearchIndex() (postings, files beam.PCollection) {
+	s := k.s.Scope("SearchIndex")
+
+	fs := k.getFiles()
+	postings = beam.ParDo(s, groupPostings, beam.GroupByKey(s, beam.ParDo(s, fileToTrigrams, fs)))
+
+	langs := beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.Anchor},
+		IncludeFacts: []string{},
+		IncludeEdges: []string{},
+	}, anchorToFileLanguage)
+	files = beam.ParDo(s, toSearchFile, beam.CoGroupByKey(s, fs, langs))
+	return postings, files
+}
+
+// fileToTrigrams emits a (trigram, file ticket) pair for each distinct trigram
+// of the file's text.
+func fileToTrigrams(src *spb.VName, f *srvpb.File, emit func(string, string)) {
+	ticket := kytheuri.ToString(src)
+	for _, tri := range search.Trigrams(f.Text) {
+		emit(tri, ticket)
+	}
+}
+
+// groupPostings emits a single *srvpb.SearchPostings for each trigram and the
+// files containing it.
+func groupPostings(tri string, ticketStream func(*string) bool) (string, *srvpb.SearchPostings) {
+	p := &srvpb.SearchPostings{Trigram: []byte(tri)}
+	var ticket string
+	for ticketStream(&ticket) {
+		p.FileTicket = append(p.FileTicket, ticket)
+	}
+	sort.Strings(p.FileTicket)
+	return string(search.TrigramKey(tri)), p
+}
+
+// anchorToFileLanguage emits the anchor's file and language, if known.
+func anchorToFileLanguage(n *scpb.Node, emit func(*spb.VName, string)) {
+	if n.Source.Language != "" {
+		emit(fileVName(n.Source), n.Source.Language)
+	}
+}
+
+// toSearchFile emits a *srvpb.SearchFile for each file with the languages of
+// its anchors.
+func toSearchFile(src *spb.VName, fileStream func(**srvpb.File) bool, langStream func(*string) bool, emit func(string, *srvpb.SearchFile)) {
+	var f *srvpb.File
+	if !fileStream(&f) {
+		return
+	}
+	langs := stringset.New()
+	var lang string
+	for langStream(&lang) {
+		langs.Add(lang)
+	}
+	ticket := kytheuri.ToString(src)
+	emit(string(search.FileKey(ticket)), &srvpb.SearchFile{
+		Ticket:   ticket,
+		Language: langs.Elements(),
+	})
+}
+
+// Identifiers returns the Kythe identifier tables derived from the qualified
+// names of the nodes in the Kythe input graph, along with the number of ref
+// anchors targeting each node.  The beam.PCollections have elements of type
+// KV<string, *srvpb.IdentifierMatch> and KV<string, *srvpb.IdentifierPostings>,
+// respectively.
+func (k *KytheBeam) Identifiers() (matches, postings beam.PCollection) {
+	s := k.s.Scope("Identifiers")
+
+	named := beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{facts.Code},
+		IncludeEdges: []string{},
+	}, keyNode)
+	refs := beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.Anchor},
+		IncludeFacts: []string{},
+	}, anchorToRefTargets)
+	idents := beam.ParDo(s, toIdentifierNode, beam.CoGroupByKey(s, named, refs))
+	matches = beam.ParDo(s, groupIdentifierMatch, beam.GroupByKey(s, idents))
+	postings = beam.ParDo(s, groupIdentifierPostings,
+		beam.GroupByKey(s, beam.ParDo(s, identifierToIndexKeys, matches)))
+	return matches, postings
+}
+
+// anchorToRefTargets emits the target of each of the anchor's ref edges.
+func anchorToRefTargets(n *scpb.Node, emit func(*spb.VName, *spb.VName)) {
+	for _, e := range n.Edge {
+		if edges.IsVariant(schema.GetEdgeKind(e), edges.Ref) {
+			emit(e.Target, n.Source)
+		}
+	}
+}
+
+// toIdentifierNode emits a single-node *srvpb.IdentifierMatch, keyed by its
+// qualified name, for the given node if it has a qualified name.
+func toIdentifierNode(src *spb.VName, nodeStream func(**scpb.Node) bool, refStream func(**spb.VName) bool, emit func(string, *srvpb.IdentifierMatch)) error {
+	var n *scpb.Node
+	if !nodeStream(&n) {
+		return nil
+	}
+	var ms *cpb.MarkedSource
+	for _, f := range n.Fact {
+		if f.GetKytheName() == scpb.FactName_CODE {
+			ms = new(cpb.MarkedSource)
+			if err := proto.Unmarshal(f.Value, ms); err != nil {
+				return err
+			}
+		}
+	}
+	qname, base := identifierNames(ms)
+	if qname == "" {
+		return nil
+	}
+
+	var (
+		refs   int32
+		anchor *spb.VName
+	)
+	for refStream(&anchor) {
+		refs++
+	}
+	emit(qname, &srvpb.IdentifierMatch{
+		QualifiedName: qname,
+		BaseName:      base,
+		Node: []*srvpb.IdentifierMatch_Node{{
+			Ticket:      kytheuri.ToString(src),
+			NodeKind:    schema.GetNodeKind(n),
+			NodeSubkind: schema.GetSubkind(n),
+			RefCount:    refs,
+		}},
+	})
+	return nil
+}
+
+// groupIdentifierMatch emits a single *srvpb.IdentifierMatch for each
+// qualified name and its nodes, in ticket order.
+func groupIdentifierMatch(qname string, matchStream func(**srvpb.IdentifierMatch) bool) (string, *srvpb.IdentifierMatch) {
+	var (
+		ms []*srvpb.IdentifierMatch
+		m  *srvpb.IdentifierMatch
+	)
+	for matchStream(&m) {
+		ms = append(ms, m)
+	}
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Node[0].Ticket < ms[j].Node[0].Ticket })
+
+	match := &srvpb.IdentifierMatch{QualifiedName: qname, BaseName: ms[0].BaseName}
+	for _, m := range ms {
+		match.Node = append(match.Node, m.Node...)
+	}
+	return string(identifiers.IdentifierKey(qname)), match
+}
+
+// identifierToIndexKeys emits an (index key, qualified name) pair for each of
+// the identifier's index keys.
+func identifierToIndexKeys(_ string, m *srvpb.IdentifierMatch, emit func(string, string)) {
+	for _, key := range identifiers.IndexKeys(m.QualifiedName, m.BaseName) {
+		emit(key, m.QualifiedName)
+	}
+}
+
+// groupIdentifierPostings emits a single *srvpb.IdentifierPostings for each
+// index key and the qualified names it indexes, limited by
+// identifiers.LimitPostings.
+func groupIdentifierPostings(key string, qnameStream func(*string) bool) (string, *srvpb.IdentifierPostings) {
+	p := &srvpb.IdentifierPostings{Key: key}
+	var qname string
+	for qnameStream(&qname) {
+		p.QualifiedName = append(p.QualifiedName, qname)
+	}
+	sort.Strings(p.QualifiedName)
+	identifiers.LimitPostings(p)
+	return string(identifiers.PostingsKey(key)), p
+}
+
+// Parameters returns the Kythe function parameters table derived from the
+// param.N edges in the Kythe input graph.  The beam.PCollection has elements of
+// type KV<string, *srvpb.FunctionParameters>.
+func (k *KytheBeam) Parameters() beam.PCollection {
+	s := k.s.Scope("Parameters")
+
+	markedSources := k.getMarkedSources()
+	paramEdges := beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.Function},
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.Param},
+	}, nodeToParamEdges)
+	paramNodes := beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.Typed},
+	}, moveSourceToKey)
+	params := beam.ParDo(s, toFunctionParameter, beam.CoGroupByKey(s, paramEdges, paramNodes, markedSources))
+
+	functions := beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.Function},
+		IncludeFacts: []string{},
+		IncludeEdges: []string{},
+	}, moveSourceToKey)
+
+	// A function's return type is the param.1 target of the function's type.
+	functionTypes := beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.Function},
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.Typed},
+	}, keyByEdgeTargets)
+	returnTypes := beam.ParDo(s, keyReturnType, beam.Seq(s, k.nodes, &nodes.Filter{
+		FilterByKind: []string{kinds.TApp},
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.Param},
+	}))
+	functionReturnTypes := beam.ParDo(s, toFunctionReturnType, beam.CoGroupByKey(s, functionTypes, returnTypes))
+
+	return beam.ParDo(s, completeFunctionParameters, beam.CoGroupByKey(s, params, functions, markedSources, functionReturnTypes))
+}
+
+// keyByEdgeTargets emits n's source keyed by the target of each of its edges.
+func keyByEdgeTargets(n *scpb.Node, emit func(*spb.VName, *spb.VName)) {
+	for _, e := range n.Edge {
+		emit(e.Target, n.Source)
+	}
+}
+
+// keyReturnType emits the ticket of the param.1 target of the type n, keyed by
+// n.
+func keyReturnType(n *scpb.Node, emit func(*spb.VName, string)) {
+	for _, e := range n.Edge {
+		if e.Ordinal == 1 {
+			emit(n.Source, kytheuri.ToString(e.Target))
+			return
+		}
+	}
+}
+
+// toFunctionReturnType emits the given return type ticket keyed by each of the
+// functions having the given type.
+func toFunctionReturnType(_ *spb.VName, fnStream func(**spb.VName) bool, retStream func(*string) bool, emit func(*spb.VName, string)) {
+	var ret string
+	if !retStream(&ret) {
+		return
+	}
+	var fn *spb.VName
+	for fnStream(&fn) {
+		emit(fn, ret)
+	}
+}
+
+// nodeToParamEdges emits each of n's param edges keyed by its target parameter.
+func nodeToParamEdges(n *scpb.Node, emit func(*spb.VName, *scpb.Edge)) {
+	for _, e := range n.Edge {
+		emit(e.Target, &scpb.Edge{Source: n.Source, Target: e.Target, Ordinal: e.Ordinal})
+	}
+}
+
+// toFunctionParameter emits a *srvpb.FunctionParameters_Parameter keyed by
+// function for each of the param edges targeting the given parameter.
+func toFunctionParameter(param *spb.VName, edgeStream func(**scpb.Edge) bool, nodeStream func(**scpb.Node) bool, msStream func(**cpb.MarkedSource) bool, emit func(*spb.VName, *srvpb.FunctionParameters_Parameter)) {
+	p := &srvpb.FunctionParameters_Parameter{Ticket: kytheuri.ToString(param)}
+	var n *scpb.Node
+	if nodeStream(&n) {
+		p.Kind = schema.GetNodeKind(n)
+		p.Subkind = schema.GetSubkind(n)
+		if len(n.Edge) > 0 {
+			p.TypeTicket = kytheuri.ToString(n.Edge[0].Target)
+		}
+	}
+	msStream(&p.MarkedSource)
+
+	var e *scpb.Edge
+	for edgeStream(&e) {
+		fp := proto.Clone(p).(*srvpb.FunctionParameters_Parameter)
+		fp.Ordinal = e.Ordinal
+		emit(e.Source, fp)
+	}
+}
+
+// completeFunctionParameters emits the *srvpb.FunctionParameters for the given
+// function, if it has any parameters or a known return type.
+func completeFunctionParameters(key *spb.VName, paramStream func(**srvpb.FunctionParameters_Parameter) bool, nodeStream func(**scpb.Node) bool, msStream func(**cpb.MarkedSource) bool, retStream func(*string) bool, emit func(string, *srvpb.FunctionParameters)) {
+	fp := &srvpb.FunctionParameters{Ticket: kytheuri.ToString(key)}
+	var p *srvpb.FunctionParameters_Parameter
+	for paramStream(&p) {
+		fp.Parameter = append(fp.Parameter, p)
+	}
+	retStream(&fp.ReturnTypeTicket)
+	if len(fp.Parameter) == 0 && fp.ReturnTypeTicket == "" {
+		return
+	}
+	sort.Slice(fp.Parameter, func(i, j int) bool {
+		if a, b := fp.Parameter[i], fp.Parameter[j]; a.Ordinal != b.Ordinal {
+			return a.Ordinal < b.Ordinal
+		}
+		return fp.Parameter[i].Ticket < fp.Parameter[j].Ticket
+	})
+
+	var n *scpb.Node
+	if nodeStream(&n) {
+		fp.Kind = schema.GetNodeKind(n)
+		fp.Subkind = schema.GetSubkind(n)
+	}
+	msStream(&fp.MarkedSource) // embed MarkedSource, if available
+
+	emit(string(explore.ParametersKey(fp.Ticket)), fp)
+}
+
 func (k *KytheBeam) getMarkedSources() beam.PCollection {
 	if !k.markedSources.IsValid() {
 		s := k.s.Scope("MarkedSources")
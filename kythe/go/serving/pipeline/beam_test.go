@@ -17,8 +17,10 @@
 package pipeline
 
 import (
+	"strconv"
 	"testing"
 
+	"kythe.io/kythe/go/serving/identifiers"
 	"kythe.io/kythe/go/serving/pipeline/beamtest"
 
 	"github.com/apache/beam/sdks/go/pkg/beam"
@@ -449,6 +451,190 @@ func TestDecorations_targetDefinition(t *testing.T) {
 	}
 }
 
+func TestDecorations_overrides(t *testing.T) {
+	ms := &cpb.MarkedSource{Kind: cpb.MarkedSource_IDENTIFIER, PreText: "m2"}
+	rec, err := proto.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anchor := func(path, sig string, start, end int, target string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Path: path, Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+				Value: []byte(strconv.Itoa(start)),
+			}, {
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+				Value: []byte(strconv.Itoa(end)),
+			}},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_DEFINES_BINDING},
+				Target: &spb.VName{Signature: target},
+			}},
+		}
+	}
+	method := func(sig string, overrides ...string) *scpb.Node {
+		n := &scpb.Node{
+			Source: &spb.VName{Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		}
+		for _, o := range overrides {
+			n.Edge = append(n.Edge, &scpb.Edge{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_OVERRIDES},
+				Target: &spb.VName{Signature: o},
+			})
+		}
+		return n
+	}
+
+	// m1 overrides m2 which overrides m3
+	m2 := method("m2", "m3")
+	m2.Fact = []*scpb.Fact{{
+		Name:  &scpb.Fact_KytheName{scpb.FactName_CODE},
+		Value: rec,
+	}}
+	testNodes := []*scpb.Node{
+		anchor("path", "a1", 0, 2, "m1"),
+		anchor("path2", "a2", 0, 2, "m2"),
+		anchor("path2", "a3", 3, 5, "m3"),
+		method("m1", "m2"), m2, method("m3"),
+		{
+			Source: &spb.VName{Path: "path"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+				Value: []byte("m1\n"),
+			}},
+		}, {
+			Source: &spb.VName{Path: "path2"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+				Value: []byte("m2 m3\n"),
+			}},
+		},
+	}
+
+	def := func(ticket, text, snippet string, start, end int32) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket: ticket,
+			Text:   text,
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: start, LineNumber: 1, ColumnOffset: start},
+				End:   &cpb.Point{ByteOffset: end, LineNumber: 1, ColumnOffset: end},
+			},
+			Snippet: snippet,
+			SnippetSpan: &cpb.Span{
+				Start: &cpb.Point{LineNumber: 1},
+				End: &cpb.Point{
+					ByteOffset:   int32(len(snippet)),
+					LineNumber:   1,
+					ColumnOffset: int32(len(snippet)),
+				},
+			},
+		}
+	}
+	def1 := def("kythe:?path=path#a1", "m1", "m1", 0, 2)
+	def2 := def("kythe:?path=path2#a2", "m2", "m2 m3", 0, 2)
+	def3 := def("kythe:?path=path2#a3", "m3", "m2 m3", 3, 5)
+	node := func(ticket string) *srvpb.Node {
+		return &srvpb.Node{
+			Ticket: ticket,
+			Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("function")}},
+		}
+	}
+	m2Node := node("kythe:#m2")
+	m2Node.Fact = append([]*cpb.Fact{{Name: "/kythe/code", Value: rec}}, m2Node.Fact...)
+
+	expected := []*srvpb.FileDecorations{{
+		File: &srvpb.File{Text: []byte("m1\n")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor:           &srvpb.RawAnchor{StartOffset: 0, EndOffset: 2},
+			Kind:             "/kythe/edge/defines/binding",
+			Target:           "kythe:#m1",
+			TargetDefinition: def1.Ticket,
+		}},
+		Target:            []*srvpb.Node{node("kythe:#m1"), m2Node, node("kythe:#m3")},
+		TargetDefinitions: []*srvpb.ExpandedAnchor{def1, def2, def3},
+		TargetOverride: []*srvpb.FileDecorations_Override{{
+			Overriding:           "kythe:#m1",
+			Overridden:           "kythe:#m2",
+			OverriddenDefinition: def2.Ticket,
+			Kind:                 srvpb.FileDecorations_Override_OVERRIDES,
+			MarkedSource:         ms,
+		}, {
+			Overriding:           "kythe:#m1",
+			Overridden:           "kythe:#m3",
+			OverriddenDefinition: def3.Ticket,
+			Kind:                 srvpb.FileDecorations_Override_OVERRIDES,
+		}},
+	}, {
+		File: &srvpb.File{Text: []byte("m2 m3\n")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor:           &srvpb.RawAnchor{StartOffset: 0, EndOffset: 2},
+			Kind:             "/kythe/edge/defines/binding",
+			Target:           "kythe:#m2",
+			TargetDefinition: def2.Ticket,
+		}, {
+			Anchor:           &srvpb.RawAnchor{StartOffset: 3, EndOffset: 5},
+			Kind:             "/kythe/edge/defines/binding",
+			Target:           "kythe:#m3",
+			TargetDefinition: def3.Ticket,
+		}},
+		Target:            []*srvpb.Node{m2Node, node("kythe:#m3")},
+		TargetDefinitions: []*srvpb.ExpandedAnchor{def2, def3},
+		TargetOverride: []*srvpb.FileDecorations_Override{{
+			Overriding:           "kythe:#m2",
+			Overridden:           "kythe:#m3",
+			OverriddenDefinition: def3.Ticket,
+			Kind:                 srvpb.FileDecorations_Override_OVERRIDES,
+		}},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	decor := FromNodes(s, nodes).Decorations()
+	debug.Print(s, decor)
+	passert.Equals(s, beam.DropKey(s, decor), beam.CreateList(s, expected))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestDecorations_overrideChain(t *testing.T) {
+	// Each method overrides the next, in a chain that takes every closure
+	// round to close.
+	const n = 40
+	method := func(i int) *spb.VName { return &spb.VName{Signature: "m" + strconv.Itoa(i)} }
+	overrides := &scpb.Edge_KytheKind{scpb.EdgeKind_OVERRIDES}
+
+	var testNodes []*scpb.Node
+	var expected []*scpb.Edge
+	for i := 0; i < n; i++ {
+		node := &scpb.Node{
+			Source: method(i),
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		}
+		if i+1 < n {
+			node.Edge = []*scpb.Edge{{Kind: overrides, Target: method(i + 1)}}
+		}
+		testNodes = append(testNodes, node)
+		for j := i + 1; j < n; j++ {
+			expected = append(expected, &scpb.Edge{Source: method(i), Kind: overrides, Target: method(j)})
+		}
+	}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	edges := FromNodes(s, nodes).overrideEdges(s)
+	passert.Equals(s, edges, beam.CreateList(s, expected))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
 func TestCrossReferences(t *testing.T) {
 	testNodes := []*scpb.Node{{
 		Source: &spb.VName{Signature: "node1"},
@@ -555,6 +741,7 @@ func TestCrossReferences(t *testing.T) {
 	}}
 	expectedSets := []*srvpb.PagedCrossReferences{{
 		SourceTicket: "kythe:#node1",
+		SourceNode:   &srvpb.Node{Ticket: "kythe:#node1"},
 		Group: []*srvpb.PagedCrossReferences_Group{{
 			Kind: "/kythe/edge/ref",
 			Anchor: []*srvpb.ExpandedAnchor{{
@@ -725,6 +912,153 @@ func TestCrossReferences(t *testing.T) {
 	}
 }
 
+func TestCrossReferences_relatedNodes(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Signature: "func"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Target: &spb.VName{Signature: "param0"},
+		}, {
+			Kind:    &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Ordinal: 1,
+			Target:  &spb.VName{Signature: "param1"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "param0"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+	}, {
+		Source: &spb.VName{Signature: "param1"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+	}}
+	expectedSets := []*srvpb.PagedCrossReferences{{
+		SourceTicket: "kythe:#func",
+		SourceNode: &srvpb.Node{
+			Ticket: "kythe:#func",
+			Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("function")}},
+		},
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind: "/kythe/edge/param",
+			RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{
+				Node: &srvpb.Node{
+					Ticket: "kythe:#param0",
+					Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("variable")}},
+				},
+			}, {
+				Node: &srvpb.Node{
+					Ticket: "kythe:#param1",
+					Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("variable")}},
+				},
+				Ordinal: 1,
+			}},
+		}},
+	}, {
+		SourceTicket: "kythe:#param0",
+		SourceNode: &srvpb.Node{
+			Ticket: "kythe:#param0",
+			Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("variable")}},
+		},
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind: "%/kythe/edge/param",
+			RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{
+				Node: &srvpb.Node{
+					Ticket: "kythe:#func",
+					Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("function")}},
+				},
+			}},
+		}},
+	}, {
+		SourceTicket: "kythe:#param1",
+		SourceNode: &srvpb.Node{
+			Ticket: "kythe:#param1",
+			Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("variable")}},
+		},
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind: "%/kythe/edge/param",
+			RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{
+				Node: &srvpb.Node{
+					Ticket: "kythe:#func",
+					Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("function")}},
+				},
+				Ordinal: 1,
+			}},
+		}},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	sets, _ := FromNodes(s, nodes).CrossReferences()
+	debug.Print(s, sets)
+	passert.Equals(s, beam.DropKey(s, sets), beam.CreateList(s, expectedSets))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestCrossReferences_paging(t *testing.T) {
+	var testRefs []*ppb.Reference
+	for _, a := range []string{"a0", "a1", "a2"} {
+		testRefs = append(testRefs, &ppb.Reference{
+			Source: &spb.VName{Signature: "node"},
+			Kind:   &ppb.Reference_KytheKind{scpb.EdgeKind_REF},
+			Anchor: &srvpb.ExpandedAnchor{Ticket: "kythe:?path=path#" + a},
+		})
+	}
+	testRefs = append(testRefs, &ppb.Reference{
+		Source: &spb.VName{Signature: "node"},
+		Kind:   &ppb.Reference_KytheKind{scpb.EdgeKind_DEFINES_BINDING},
+		Anchor: &srvpb.ExpandedAnchor{Ticket: "kythe:?path=path#def"},
+	})
+	testNodes := []*scpb.Node{{Source: &spb.VName{Signature: "node"}}}
+
+	expectedSets := []*srvpb.PagedCrossReferences{{
+		SourceTicket: "kythe:#node",
+		SourceNode:   &srvpb.Node{Ticket: "kythe:#node"},
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:   "/kythe/edge/defines/binding",
+			Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:?path=path#def"}},
+		}},
+		PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+			Kind:    "/kythe/edge/ref",
+			Count:   2,
+			PageKey: "kythe:#node.0000000000",
+		}, {
+			Kind:    "/kythe/edge/ref",
+			Count:   1,
+			PageKey: "kythe:#node.0000000001",
+		}},
+	}}
+	expectedPages := []*srvpb.PagedCrossReferences_Page{{
+		PageKey:      "kythe:#node.0000000000",
+		SourceTicket: "kythe:#node",
+		Group: &srvpb.PagedCrossReferences_Group{
+			Kind: "/kythe/edge/ref",
+			Anchor: []*srvpb.ExpandedAnchor{
+				{Ticket: "kythe:?path=path#a0"},
+				{Ticket: "kythe:?path=path#a1"},
+			},
+		},
+	}, {
+		PageKey:      "kythe:#node.0000000001",
+		SourceTicket: "kythe:#node",
+		Group: &srvpb.PagedCrossReferences_Group{
+			Kind:   "/kythe/edge/ref",
+			Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:?path=path#a2"}},
+		},
+	}}
+
+	p, s, refs, nodes := ptest.CreateList2(testRefs, testNodes)
+	k := &KytheBeam{s: s, refs: refs, nodes: nodes}
+	k.SetMaxPageSize(2)
+	sets, pages := k.CrossReferences()
+	passert.Equals(s, beam.DropKey(s, sets), beam.CreateList(s, expectedSets))
+	passert.Equals(s, beam.DropKey(s, pages), beam.CreateList(s, expectedPages))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
 func TestEdges_grouping(t *testing.T) {
 	testNodes := []*scpb.Node{{
 		Source: &spb.VName{Signature: "node1"},
@@ -819,6 +1153,389 @@ func TestEdges_reverses(t *testing.T) {
 	}
 }
 
+func TestTypeHierarchy(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Signature: "sub"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_RECORD},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_GenericKind{"/kythe/edge/extends/public"},
+			Target: &spb.VName{Signature: "base"},
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_SATISFIES},
+			Target: &spb.VName{Signature: "iface"},
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "pkg"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "other"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_RECORD},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_EXTENDS},
+			Target: &spb.VName{Signature: "base"},
+		}},
+	}}
+	expectedSupertypes := []*srvpb.TypeHierarchy{{
+		Tickets: []string{"kythe:#base", "kythe:#iface"},
+		Type:    srvpb.TypeHierarchy_SUPERTYPES,
+	}, {
+		Tickets: []string{"kythe:#base"},
+		Type:    srvpb.TypeHierarchy_SUPERTYPES,
+	}}
+	expectedSubtypes := []*srvpb.TypeHierarchy{{
+		Tickets: []string{"kythe:#other", "kythe:#sub"},
+		Type:    srvpb.TypeHierarchy_SUBTYPES,
+	}, {
+		Tickets: []string{"kythe:#sub"},
+		Type:    srvpb.TypeHierarchy_SUBTYPES,
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	k := FromNodes(s, nodes)
+	supertypes, subtypes := k.TypeHierarchy()
+	debug.Print(s, supertypes)
+	debug.Print(s, subtypes)
+	passert.Equals(s, beam.DropKey(s, supertypes), beam.CreateList(s, expectedSupertypes))
+	passert.Equals(s, beam.DropKey(s, subtypes), beam.CreateList(s, expectedSubtypes))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestParameters(t *testing.T) {
+	ms := &cpb.MarkedSource{PreText: "param0"}
+	rec, err := proto.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Signature: "func"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Edge: []*scpb.Edge{{
+			Kind:    &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Ordinal: 1,
+			Target:  &spb.VName{Signature: "param1"},
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Target: &spb.VName{Signature: "param0"},
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_TYPED},
+			Target: &spb.VName{Signature: "fntype"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "nullary"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_TYPED},
+			Target: &spb.VName{Signature: "fntype"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "fntype"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_TAPP},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Target: &spb.VName{Signature: "fn#builtin"},
+		}, {
+			Kind:    &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Ordinal: 1,
+			Target:  &spb.VName{Signature: "bool"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "param0"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_CODE},
+			Value: rec,
+		}},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_TYPED},
+			Target: &spb.VName{Signature: "int"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "param1"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+	}, {
+		Source: &spb.VName{Signature: "tapp"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_TAPP},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Target: &spb.VName{Signature: "int"},
+		}},
+	}}
+	expected := []*srvpb.FunctionParameters{{
+		Ticket: "kythe:#func",
+		Kind:   "function",
+		Parameter: []*srvpb.FunctionParameters_Parameter{{
+			Ticket:       "kythe:#param0",
+			Kind:         "variable",
+			MarkedSource: ms,
+			TypeTicket:   "kythe:#int",
+		}, {
+			Ticket:  "kythe:#param1",
+			Ordinal: 1,
+			Kind:    "variable",
+		}},
+		ReturnTypeTicket: "kythe:#bool",
+	}, {
+		Ticket:           "kythe:#nullary",
+		Kind:             "function",
+		ReturnTypeTicket: "kythe:#bool",
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	k := FromNodes(s, nodes)
+	params := k.Parameters()
+	debug.Print(s, params)
+	passert.Equals(s, beam.DropKey(s, params), beam.CreateList(s, expected))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestRelatives(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Signature: "func"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "pkg"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "param"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "func"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "anchor"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "func"},
+		}},
+	}}
+	expectedParents := []*srvpb.Relatives{{
+		Tickets: []string{"kythe:#pkg"},
+		Type:    srvpb.Relatives_PARENTS,
+	}, {
+		Tickets: []string{"kythe:#func"},
+		Type:    srvpb.Relatives_PARENTS,
+	}}
+	expectedChildren := []*srvpb.Relatives{{
+		Tickets: []string{"kythe:#func"},
+		Type:    srvpb.Relatives_CHILDREN,
+	}, {
+		Tickets: []string{"kythe:#param"},
+		Type:    srvpb.Relatives_CHILDREN,
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	k := FromNodes(s, nodes)
+	parents, children := k.Relatives()
+	debug.Print(s, parents)
+	debug.Print(s, children)
+	passert.Equals(s, beam.DropKey(s, parents), beam.CreateList(s, expectedParents))
+	passert.Equals(s, beam.DropKey(s, children), beam.CreateList(s, expectedChildren))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestCallgraph(t *testing.T) {
+	file := &spb.VName{Path: "file"}
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Path: "file", Signature: "call1"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: file,
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "main"},
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_REF_CALL},
+			Target: &spb.VName{Signature: "f"},
+		}},
+	}, {
+		Source: &spb.VName{Path: "file", Signature: "call2"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "main"},
+		}, {
+			Kind:   &scpb.Edge_GenericKind{"/kythe/edge/ref/call/implicit"},
+			Target: &spb.VName{Signature: "g"},
+		}},
+	}, {
+		Source: &spb.VName{Path: "file", Signature: "ref"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "main"},
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_REF},
+			Target: &spb.VName{Signature: "h"},
+		}},
+	}}
+	expectedCallers := []*srvpb.Callgraph{{
+		Tickets: []string{"kythe:#main"},
+		Type:    srvpb.Callgraph_CALLER,
+	}, {
+		Tickets: []string{"kythe:#main"},
+		Type:    srvpb.Callgraph_CALLER,
+	}}
+	expectedCallees := []*srvpb.Callgraph{{
+		Tickets: []string{"kythe:#f", "kythe:#g"},
+		Type:    srvpb.Callgraph_CALLEE,
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	k := FromNodes(s, nodes)
+	callers, callees := k.Callgraph()
+	debug.Print(s, callers)
+	debug.Print(s, callees)
+	passert.Equals(s, beam.DropKey(s, callers), beam.CreateList(s, expectedCallers))
+	passert.Equals(s, beam.DropKey(s, callees), beam.CreateList(s, expectedCallees))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Path: "a"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("abcd"),
+		}},
+	}, {
+		Source: &spb.VName{Path: "b"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("bcd"),
+		}},
+	}, {
+		Source: &spb.VName{Path: "b", Language: "go", Signature: "anchor"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+	}}
+	expectedPostings := []*srvpb.SearchPostings{{
+		Trigram:    []byte("abc"),
+		FileTicket: []string{"kythe:?path=a"},
+	}, {
+		Trigram:    []byte("bcd"),
+		FileTicket: []string{"kythe:?path=a", "kythe:?path=b"},
+	}}
+	expectedFiles := []*srvpb.SearchFile{{
+		Ticket: "kythe:?path=a",
+	}, {
+		Ticket:   "kythe:?path=b",
+		Language: []string{"go"},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	postings, files := FromNodes(s, nodes).SearchIndex()
+	debug.Print(s, postings)
+	debug.Print(s, files)
+	passert.Equals(s, beam.DropKey(s, postings), beam.CreateList(s, expectedPostings))
+	passert.Equals(s, beam.DropKey(s, files), beam.CreateList(s, expectedFiles))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	code, err := proto.Marshal(&cpb.MarkedSource{
+		Kind: cpb.MarkedSource_BOX,
+		Child: []*cpb.MarkedSource{{
+			Kind:          cpb.MarkedSource_CONTEXT,
+			PostChildText: ".",
+			Child:         []*cpb.MarkedSource{{Kind: cpb.MarkedSource_IDENTIFIER, PreText: "pkg"}},
+		}, {
+			Kind:    cpb.MarkedSource_IDENTIFIER,
+			PreText: "Fn",
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNodes := []*scpb.Node{{
+		Source:  &spb.VName{Signature: "fn", Language: "go"},
+		Kind:    &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Subkind: &scpb.Node_GenericSubkind{"method"},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_CODE},
+			Value: code,
+		}},
+	}, {
+		Source: &spb.VName{Signature: "fn", Language: "java"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_CODE},
+			Value: code,
+		}},
+	}, {
+		Source: &spb.VName{Signature: "nameless", Language: "go"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+	}, {
+		Source: &spb.VName{Path: "a", Signature: "a0"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_REF_CALL},
+			Target: &spb.VName{Signature: "fn", Language: "go"},
+		}},
+	}, {
+		Source: &spb.VName{Path: "a", Signature: "a1"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_REF},
+			Target: &spb.VName{Signature: "fn", Language: "go"},
+		}, {
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_REF},
+			Target: &spb.VName{Signature: "nameless", Language: "go"},
+		}},
+	}}
+	expectedMatches := []*srvpb.IdentifierMatch{{
+		QualifiedName: "pkg.Fn",
+		BaseName:      "Fn",
+		Node: []*srvpb.IdentifierMatch_Node{{
+			Ticket:      "kythe:?lang=go#fn",
+			NodeKind:    "function",
+			NodeSubkind: "method",
+			RefCount:    2,
+		}, {
+			Ticket:   "kythe:?lang=java#fn",
+			NodeKind: "function",
+		}},
+	}}
+	var expectedPostings []*srvpb.IdentifierPostings
+	for _, key := range identifiers.IndexKeys("pkg.Fn", "Fn") {
+		expectedPostings = append(expectedPostings, &srvpb.IdentifierPostings{
+			Key:           key,
+			QualifiedName: []string{"pkg.Fn"},
+		})
+	}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	matches, postings := FromNodes(s, nodes).Identifiers()
+	debug.Print(s, matches)
+	debug.Print(s, postings)
+	passert.Equals(s, beam.DropKey(s, matches), beam.CreateList(s, expectedMatches))
+	passert.Equals(s, beam.DropKey(s, postings), beam.CreateList(s, expectedPostings))
+
+	if err := ptest.Run(p); err != nil {
+		t.Fatalf("Pipeline error: %+v", err)
+	}
+}
+
 func TestDocuments_text(t *testing.T) {
 	testNodes := []*scpb.Node{{
 		Source: &spb.VName{Signature: "doc1"},
@@ -952,6 +1669,48 @@ func TestEdges_registrations(t *testing.T) {
 	beamtest.CheckRegistrations(t, p)
 }
 
+func TestTypeHierarchy_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).TypeHierarchy()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestParameters_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Parameters()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestRelatives_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Relatives()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestCallgraph_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Callgraph()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestSearchIndex_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).SearchIndex()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestIdentifiers_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Identifiers()
+	beamtest.CheckRegistrations(t, p)
+}
+
 func TestDocuments_registrations(t *testing.T) {
 	testNodes := []*scpb.Node{{}}
 	p, s, nodes := ptest.CreateList(testNodes)
@@ -96,8 +96,9 @@ func encodeDecorPiece(file *spb.VName, p *ppb.DecorationPiece, emit func([]byte,
 		return encodeDecorDef(file, p.Definition, emit)
 	case *ppb.DecorationPiece_Diagnostic:
 		return encodeDecorDiagnostic(file, p.Diagnostic, emit)
+	case *ppb.DecorationPiece_TargetOverride:
+		return encodeDecorOverride(file, p.TargetOverride, emit)
 	default:
-		// TODO(schroederc): add overrides
 		return fmt.Errorf("unknown DecorationPiece: %T", p)
 	}
 }
@@ -200,6 +201,51 @@ func encodeDecorDef(file *spb.VName, def *ppb.DecorationPiece_Definition, emit f
 	return nil
 }
 
+func encodeDecorOverride(file *spb.VName, o *srvpb.FileDecorations_Override, emit func([]byte, []byte)) error {
+	// TODO(schroederc): use VNames throughout pipeline
+	overriding, err := kytheuri.ToVName(o.Overriding)
+	if err != nil {
+		return err
+	}
+	overridden, err := kytheuri.ToVName(o.Overridden)
+	if err != nil {
+		return err
+	}
+	kind := xspb.FileDecorations_TargetOverride_OVERRIDES
+	if o.Kind == srvpb.FileDecorations_Override_EXTENDS {
+		kind = xspb.FileDecorations_TargetOverride_EXTENDS
+	}
+
+	// The columnar TargetOverride is keyed by the file decorations target (the
+	// overriding node) and its Override carries the MarkedSource of the other
+	// (overridden) node.
+	e, err := columnar.EncodeDecorationsEntry(columnar.DecorationsKeyPrefix, &xspb.FileDecorations{
+		File: file,
+		Entry: &xspb.FileDecorations_TargetOverride_{&xspb.FileDecorations_TargetOverride{
+			Overridden: overriding,
+			Kind:       kind,
+			Overriding: overridden,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	emit(e.Key, e.Value)
+
+	e, err = columnar.EncodeDecorationsEntry(columnar.DecorationsKeyPrefix, &xspb.FileDecorations{
+		File: file,
+		Entry: &xspb.FileDecorations_Override_{&xspb.FileDecorations_Override{
+			Override:     overridden,
+			MarkedSource: o.MarkedSource,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	emit(e.Key, e.Value)
+	return nil
+}
+
 func encodeDecorDiagnostic(file *spb.VName, d *cpb.Diagnostic, emit func([]byte, []byte)) error {
 	e, err := columnar.EncodeDecorationsEntry(columnar.DecorationsKeyPrefix, &xspb.FileDecorations{
 		File: file,
@@ -0,0 +1,1272 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"kythe.io/kythe/go/services/filetree"
+	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/serving/explore"
+	ftsrv "kythe.io/kythe/go/serving/filetree"
+	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/search"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/storage/stream"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/schema/nodes"
+	"kythe.io/kythe/go/util/schema/tickets"
+
+	"bitbucket.org/creachadair/stringset"
+	"github.com/golang/protobuf/proto"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	ftpb "kythe.io/kythe/proto/filetree_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+// A Delta is a set of changes to the entries from which a serving table was
+// written, such as the entries of the compilations for a set of changed files.
+// The Removed entries are applied before the Added entries.  Either may be nil.
+type Delta struct {
+	Removed stream.EntryReader
+	Added   stream.EntryReader
+}
+
+// Update applies delta to the serving table previously written to db by Run,
+// rewriting only the edge sets, file decorations, cross-references, explore
+// tables, search index entries, identifiers, and file tree directories
+// affected by the change.  opts should match those given to Run.  The Writers
+// of db must implement keyvalue.Deleter.
+//
+// Each node's edge set holds all of its facts and edges, so the portion of the
+// graph surrounding the delta is recovered from the existing table.  After the
+// delta is applied to it, the affected tables are rebuilt by Run from that
+// subgraph and copied over their previous values.
+//
+// An entry given to Run (or added by Update) more than once, such as a node
+// emitted by several compilations, is removed from the graph only with its
+// last copy.  Run counts the copies in its input, so a table meant to be
+// updated should be written from the entries of each compilation rather than
+// from a deduplicated GraphStore.
+func Update(ctx context.Context, db keyvalue.DB, delta *Delta, opts *Options) error {
+	if opts == nil {
+		opts = new(Options)
+	}
+
+	g := &subgraph{
+		db:     db,
+		xs:     &table.KVProto{DB: db},
+		nodes:  make(map[string]*graphNode),
+		copies: make(map[string]int),
+	}
+	if err := g.apply(ctx, delta); err != nil {
+		return fmt.Errorf("error applying delta: %v", err)
+	}
+	aff, err := g.affected(ctx)
+	if err != nil {
+		return fmt.Errorf("error determining affected tables: %v", err)
+	}
+	log.Printf("Updating %d edge sets, %d decorations, and %d cross-references",
+		len(aff.edgeSets), len(aff.files), len(aff.xrefs))
+
+	entries, err := g.entries(ctx, aff)
+	if err != nil {
+		return fmt.Errorf("error reading affected subgraph: %v", err)
+	}
+	partial := inmemory.NewKeyValueDB()
+	if err := Run(ctx, func(f func(*spb.Entry) error) error {
+		for _, e := range entries {
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, partial, opts); err != nil {
+		return fmt.Errorf("error building partial serving table: %v", err)
+	}
+
+	u := &tableUpdate{db: db, old: g.xs, partial: partial}
+	if err := u.replaceAll(ctx, aff, g); err != nil {
+		return err
+	}
+	u.updateEntryCounts(g.copies)
+	if err := u.updateFileTree(ctx, aff.addedFiles, aff.removedFiles); err != nil {
+		return fmt.Errorf("error updating file tree: %v", err)
+	}
+	if err := u.updateSearchIndex(ctx, aff.files); err != nil {
+		return fmt.Errorf("error updating search index: %v", err)
+	}
+	// A node's identifier changes with its facts and its reference count with
+	// its cross-references.
+	if err := u.updateIdentifiers(ctx, g, aff.xrefs); err != nil {
+		return fmt.Errorf("error updating identifiers: %v", err)
+	}
+	return u.write(ctx, db)
+}
+
+// A graphEdge is an edge of a graphNode.  For reverse edges, kind is the
+// forward edge kind and ticket is the edge's source.
+type graphEdge struct {
+	kind    string
+	ordinal int32
+	ticket  string
+}
+
+// A graphNode is a node's facts and edges, as recovered from its edge set.
+type graphNode struct {
+	ticket string
+	vname  *spb.VName
+	facts  map[string][]byte
+	edges  map[graphEdge]bool
+	revs   map[graphEdge]bool
+
+	pages []string   // keys of the node's existing EdgePages
+	old   *graphNode // the node before the delta was applied, if modified
+}
+
+func newGraphNode(ticket string) *graphNode {
+	return &graphNode{
+		ticket: ticket,
+		facts:  make(map[string][]byte),
+		edges:  make(map[graphEdge]bool),
+		revs:   make(map[graphEdge]bool),
+	}
+}
+
+func (n *graphNode) clone() *graphNode {
+	c := newGraphNode(n.ticket)
+	for name, val := range n.facts {
+		c.facts[name] = val
+	}
+	for e := range n.edges {
+		c.edges[e] = true
+	}
+	for e := range n.revs {
+		c.revs[e] = true
+	}
+	return c
+}
+
+// is reports whether n is non-nil and has the given node kind.
+func (n *graphNode) is(kind string) bool {
+	return n != nil && string(n.facts[facts.NodeKind]) == kind
+}
+
+func (n *graphNode) factsChanged() bool {
+	if n.old == nil {
+		return false
+	} else if len(n.facts) != len(n.old.facts) {
+		return true
+	}
+	for name, val := range n.facts {
+		if old, ok := n.old.facts[name]; !ok || !bytes.Equal(old, val) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *graphNode) changed() bool {
+	return n.old != nil && (n.factsChanged() || !sameEdges(n.edges, n.old.edges) || !sameEdges(n.revs, n.old.revs))
+}
+
+func sameEdges(a, b map[graphEdge]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for e := range a {
+		if !b[e] {
+			return false
+		}
+	}
+	return true
+}
+
+// A subgraph is the portion of a serving table's graph read by Update.
+type subgraph struct {
+	db       keyvalue.DB
+	xs       table.Proto
+	nodes    map[string]*graphNode
+	modified []*graphNode
+
+	// The number of copies of each entry whose count was read or changed,
+	// keyed by entryCountKey.
+	copies map[string]int
+}
+
+// node returns the graphNode for ticket, reading it from its edge set (and
+// edge pages) if it has not yet been read.  Nodes not in the table are empty.
+func (g *subgraph) node(ctx context.Context, ticket string) (*graphNode, error) {
+	if n, ok := g.nodes[ticket]; ok {
+		return n, nil
+	}
+	n := newGraphNode(ticket)
+	var pes srvpb.PagedEdgeSet
+	if err := g.xs.Lookup(ctx, gsrv.EdgeSetKey(ticket), &pes); err == nil {
+		for _, f := range pes.Source.GetFact() {
+			n.facts[f.Name] = f.Value
+		}
+		groups := pes.Group
+		for _, idx := range pes.PageIndex {
+			var ep srvpb.EdgePage
+			if err := g.xs.Lookup(ctx, gsrv.EdgePageKey(idx.PageKey), &ep); err != nil {
+				return nil, fmt.Errorf("error reading edge page %q: %v", idx.PageKey, err)
+			}
+			n.pages = append(n.pages, idx.PageKey)
+			groups = append(groups, ep.EdgesGroup)
+		}
+		for _, grp := range groups {
+			for _, e := range grp.Edge {
+				if edges.IsReverse(grp.Kind) {
+					n.revs[graphEdge{edges.Mirror(grp.Kind), e.Ordinal, e.Target.Ticket}] = true
+				} else {
+					n.edges[graphEdge{grp.Kind, e.Ordinal, e.Target.Ticket}] = true
+				}
+			}
+		}
+	} else if err != table.ErrNoSuchKey {
+		return nil, fmt.Errorf("error reading edge set for %q: %v", ticket, err)
+	}
+	g.nodes[ticket] = n
+	return n, nil
+}
+
+// modify returns the graphNode for v, recording its state before the delta.
+func (g *subgraph) modify(ctx context.Context, v *spb.VName) (*graphNode, error) {
+	n, err := g.node(ctx, kytheuri.ToString(v))
+	if err != nil {
+		return nil, err
+	}
+	if n.old == nil {
+		n.old = n.clone()
+		g.modified = append(g.modified, n)
+	}
+	if n.vname == nil {
+		n.vname = v
+	}
+	return n, nil
+}
+
+// countCopies returns the number of copies of the entry with the given count
+// key, which is present in the graph if present is true.
+func (g *subgraph) countCopies(ctx context.Context, key string, present bool) (int, error) {
+	if n, ok := g.copies[key]; ok {
+		return n, nil
+	}
+	val, err := g.db.Get(ctx, []byte(key), nil)
+	if err == io.EOF {
+		if present {
+			return 1, nil
+		}
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("error reading %q: %v", key, err)
+	}
+	n, err := strconv.Atoi(string(val))
+	if err != nil {
+		return 0, fmt.Errorf("invalid entry count for %q: %v", key, err)
+	}
+	return n, nil
+}
+
+// apply applies the removed and added entries of delta to g.  An entry with
+// several copies is only removed with its last copy.
+func (g *subgraph) apply(ctx context.Context, delta *Delta) error {
+	update := func(add bool) func(*spb.Entry) error {
+		return func(e *spb.Entry) error {
+			src, err := g.modify(ctx, e.Source)
+			if err != nil {
+				return err
+			}
+
+			var (
+				tgt      *graphNode
+				fwd, rev graphEdge
+				present  bool
+			)
+			if graphstore.IsNodeFact(e) {
+				_, present = src.facts[e.FactName]
+			} else {
+				tgt, err = g.modify(ctx, e.Target)
+				if err != nil {
+					return err
+				}
+				kind, ordinal, _ := edges.ParseOrdinal(e.EdgeKind)
+				fwd = graphEdge{kind, int32(ordinal), tgt.ticket}
+				rev = graphEdge{kind, int32(ordinal), src.ticket}
+				present = src.edges[fwd]
+			}
+
+			key, err := entryCountKey(e)
+			if err != nil {
+				return err
+			}
+			copies, err := g.countCopies(ctx, string(key), present)
+			if err != nil {
+				return err
+			}
+			if add && present {
+				g.copies[string(key)] = copies + 1
+			} else if !add && copies > 1 {
+				g.copies[string(key)] = copies - 1
+				return nil // other copies remain
+			}
+
+			if tgt == nil {
+				if add {
+					src.facts[e.FactName] = e.FactValue
+				} else {
+					delete(src.facts, e.FactName)
+				}
+			} else if add {
+				src.edges[fwd], tgt.revs[rev] = true, true
+			} else {
+				delete(src.edges, fwd)
+				delete(tgt.revs, rev)
+			}
+			return nil
+		}
+	}
+
+	if delta.Removed != nil {
+		if err := filterReverses(delta.Removed)(update(false)); err != nil {
+			return fmt.Errorf("error reading removed entries: %v", err)
+		}
+	}
+	if delta.Added != nil {
+		if err := filterReverses(delta.Added)(update(true)); err != nil {
+			return fmt.Errorf("error reading added entries: %v", err)
+		}
+	}
+	return nil
+}
+
+// affectedTables is the set of serving table entries changed by a Delta.
+type affectedTables struct {
+	edgeSets stringset.Set // node tickets whose edge sets change
+	explore  stringset.Set // node tickets whose explore tables change
+	files    stringset.Set // file tickets whose decorations change
+	xrefs    stringset.Set // node tickets whose cross-references change
+
+	anchors stringset.Set // the anchors within files
+
+	addedFiles, removedFiles []*spb.VName
+}
+
+// affected determines the tables changed by the delta applied to g.
+func (g *subgraph) affected(ctx context.Context) (*affectedTables, error) {
+	aff := &affectedTables{
+		edgeSets: stringset.New(),
+		explore:  stringset.New(),
+		files:    stringset.New(),
+		xrefs:    stringset.New(),
+		anchors:  stringset.New(),
+	}
+
+	var changed, changedAnchors, changedText []*graphNode
+	for _, n := range g.modified {
+		if !n.changed() {
+			continue
+		}
+		changed = append(changed, n)
+		aff.edgeSets.Add(n.ticket)
+		aff.explore.Add(n.ticket)
+		aff.xrefs.Add(n.ticket)
+
+		if n.is(nodes.Anchor) || n.old.is(nodes.Anchor) {
+			changedAnchors = append(changedAnchors, n)
+			if file, err := tickets.AnchorFile(n.ticket); err == nil {
+				aff.files.Add(file)
+			}
+		}
+		if n.is(nodes.File) || n.old.is(nodes.File) {
+			aff.files.Add(n.ticket)
+			if n.factsChanged() {
+				changedText = append(changedText, n)
+			}
+			if n.is(nodes.File) && !n.old.is(nodes.File) {
+				aff.addedFiles = append(aff.addedFiles, n.vname)
+			} else if !n.is(nodes.File) && n.old.is(nodes.File) {
+				aff.removedFiles = append(aff.removedFiles, n.vname)
+			}
+		}
+
+		// Parameters and return types are written with their function.
+		isType := n.is(nodes.TApp) || n.old.is(nodes.TApp)
+		for e := range n.revs {
+			if e.kind == edges.Param || (isType && e.kind == edges.Typed) {
+				aff.explore.Add(e.ticket)
+			}
+		}
+	}
+
+	// A node's facts are copied into the edge sets and decorations of its
+	// neighbors.
+	for _, n := range changed {
+		if !n.factsChanged() {
+			continue
+		}
+		for e := range n.edges {
+			aff.edgeSets.Add(e.ticket)
+			aff.explore.Add(e.ticket)
+		}
+		for e := range n.revs {
+			aff.edgeSets.Add(e.ticket)
+			aff.explore.Add(e.ticket)
+			src, err := g.node(ctx, e.ticket)
+			if err != nil {
+				return nil, err
+			} else if src.is(nodes.Anchor) {
+				if file, err := tickets.AnchorFile(e.ticket); err == nil {
+					aff.files.Add(file)
+				}
+				// An anchor's ref/call targets are only called by its parent if
+				// the parent is not a file.
+				if e.kind == edges.ChildOf {
+					for call := range src.edges {
+						if edges.IsVariant(call.kind, edges.RefCall) {
+							aff.explore.Add(call.ticket)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Each affected file's decorations are rebuilt from all of its anchors.
+	for file := range aff.files {
+		var decor srvpb.FileDecorations
+		if err := g.xs.Lookup(ctx, xsrv.DecorationsKey(file), &decor); err == nil {
+			for _, d := range decor.Decoration {
+				aff.anchors.Add(d.Anchor.Ticket)
+			}
+		} else if err != table.ErrNoSuchKey {
+			return nil, fmt.Errorf("error reading decorations for %q: %v", file, err)
+		}
+	}
+	for _, n := range changedAnchors {
+		aff.anchors.Add(n.ticket)
+	}
+
+	// The targets of changed anchors, and of any anchors whose file text has
+	// changed, have changed cross-references.
+	xrefAnchors := stringset.New()
+	for _, n := range changedAnchors {
+		xrefAnchors.Add(n.ticket)
+	}
+	for _, f := range changedText {
+		for a := range aff.anchors {
+			if file, err := tickets.AnchorFile(a); err == nil && file == f.ticket {
+				xrefAnchors.Add(a)
+			}
+		}
+	}
+	for a := range xrefAnchors {
+		n, err := g.node(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		targets := []map[graphEdge]bool{n.edges}
+		if n.old != nil {
+			targets = append(targets, n.old.edges)
+		}
+		for _, es := range targets {
+			for e := range es {
+				if e.kind != edges.ChildOf {
+					aff.xrefs.Add(e.ticket)
+				}
+			}
+		}
+	}
+
+	return aff, nil
+}
+
+// isCallgraphEdge reports whether an anchor's edge of the given kind
+// determines the callgraph of its target.
+func isCallgraphEdge(kind string) bool {
+	return kind == edges.ChildOf || edges.IsVariant(kind, edges.RefCall)
+}
+
+// entries returns the GraphStore-ordered entries of the subgraph from which
+// the affected tables can be rebuilt.  The facts and edges of each node whose
+// tables are rewritten are included, along with the facts of their neighbors
+// and of the files containing any anchors.  The edges of the anchors within
+// and calling each explore node are also included for its callgraph, as are
+// those of its parameters and types.
+func (g *subgraph) entries(ctx context.Context, aff *affectedTables) ([]*spb.Entry, error) {
+	full := aff.edgeSets.Union(aff.explore).Union(aff.xrefs).Union(aff.anchors)
+	for ticket := range aff.explore {
+		n, err := g.node(ctx, ticket)
+		if err != nil {
+			return nil, err
+		}
+		for e := range n.edges {
+			if e.kind == edges.Param || e.kind == edges.Typed {
+				full.Add(e.ticket)
+			}
+		}
+		for e := range n.revs {
+			if !isCallgraphEdge(e.kind) {
+				continue
+			}
+			src, err := g.node(ctx, e.ticket)
+			if err != nil {
+				return nil, err
+			} else if src.is(nodes.Anchor) {
+				full.Add(e.ticket)
+			}
+		}
+	}
+
+	var entries []*spb.Entry
+	vnames := make(map[string]*spb.VName)
+	vname := func(ticket string) (*spb.VName, error) {
+		if v, ok := vnames[ticket]; ok {
+			return v, nil
+		}
+		v, err := kytheuri.ToVName(ticket)
+		if err != nil {
+			return nil, err
+		}
+		vnames[ticket] = v
+		return v, nil
+	}
+	emitted := stringset.New()
+	emitEdge := func(src string, e graphEdge) error {
+		key := fmt.Sprintf("%s\n%s\n%d\n%s", src, e.kind, e.ordinal, e.ticket)
+		if emitted.Contains(key) {
+			return nil
+		}
+		emitted.Add(key)
+		s, err := vname(src)
+		if err != nil {
+			return err
+		}
+		t, err := vname(e.ticket)
+		if err != nil {
+			return err
+		}
+		kind := e.kind
+		if e.ordinal != 0 {
+			kind = fmt.Sprintf("%s.%d", e.kind, e.ordinal)
+		}
+		entries = append(entries, &spb.Entry{
+			Source:   s,
+			EdgeKind: kind,
+			Target:   t,
+			FactName: "/",
+		})
+		return nil
+	}
+
+	factNodes := aff.files.Clone()
+	for ticket := range full {
+		factNodes.Add(ticket)
+		n, err := g.node(ctx, ticket)
+		if err != nil {
+			return nil, err
+		}
+		for e := range n.edges {
+			factNodes.Add(e.ticket)
+			if err := emitEdge(ticket, e); err != nil {
+				return nil, err
+			}
+		}
+		for e := range n.revs {
+			factNodes.Add(e.ticket)
+			if err := emitEdge(e.ticket, graphEdge{e.kind, e.ordinal, ticket}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Cross-references are only built for anchors in files with known text.
+	anchorFiles := stringset.New()
+	for ticket := range factNodes {
+		n, err := g.node(ctx, ticket)
+		if err != nil {
+			return nil, err
+		} else if n.is(nodes.Anchor) {
+			if file, err := tickets.AnchorFile(ticket); err == nil {
+				anchorFiles.Add(file)
+			}
+		}
+	}
+	factNodes.Update(anchorFiles)
+
+	for ticket := range factNodes {
+		n, err := g.node(ctx, ticket)
+		if err != nil {
+			return nil, err
+		} else if len(n.facts) == 0 {
+			continue
+		}
+		v, err := vname(ticket)
+		if err != nil {
+			return nil, err
+		}
+		for name, val := range n.facts {
+			entries = append(entries, &spb.Entry{
+				Source:    v,
+				FactName:  name,
+				FactValue: val,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return compare.Entries(entries[i], entries[j]) == compare.LT
+	})
+	return entries, nil
+}
+
+// A tableUpdate is a set of deletions and writes to a serving table, applied
+// in order.
+type tableUpdate struct {
+	db      keyvalue.DB // the table being updated
+	old     table.Proto // db's values before the update
+	partial keyvalue.DB
+
+	deletes [][]byte
+	writes  []keyValue
+}
+
+type keyValue struct{ key, val []byte }
+
+// replaceAll replaces each of the affected tables with their values in the
+// partial table.
+func (u *tableUpdate) replaceAll(ctx context.Context, aff *affectedTables, g *subgraph) error {
+	for ticket := range aff.edgeSets {
+		u.deletes = append(u.deletes, gsrv.EdgeSetKey(ticket))
+		for _, p := range g.nodes[ticket].pages {
+			u.deletes = append(u.deletes, gsrv.EdgePageKey(p))
+		}
+		var pes srvpb.PagedEdgeSet
+		if err := u.copy(ctx, gsrv.EdgeSetKey(ticket), &pes); err != nil {
+			return err
+		}
+		for _, idx := range pes.PageIndex {
+			if err := u.copy(ctx, gsrv.EdgePageKey(idx.PageKey), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for ticket := range aff.xrefs {
+		var old srvpb.PagedCrossReferences
+		if err := u.old.Lookup(ctx, xsrv.CrossReferencesKey(ticket), &old); err != nil && err != table.ErrNoSuchKey {
+			return fmt.Errorf("error reading cross-references for %q: %v", ticket, err)
+		}
+		u.deletes = append(u.deletes, xsrv.CrossReferencesKey(ticket))
+		for _, idx := range old.PageIndex {
+			u.deletes = append(u.deletes, xsrv.CrossReferencesPageKey(idx.PageKey))
+		}
+		var sets srvpb.PagedCrossReferences
+		if err := u.copy(ctx, xsrv.CrossReferencesKey(ticket), &sets); err != nil {
+			return err
+		}
+		for _, idx := range sets.PageIndex {
+			if err := u.copy(ctx, xsrv.CrossReferencesPageKey(idx.PageKey), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	for file := range aff.files {
+		u.deletes = append(u.deletes, xsrv.DecorationsKey(file))
+		if err := u.copy(ctx, xsrv.DecorationsKey(file), nil); err != nil {
+			return err
+		}
+	}
+
+	for ticket := range aff.explore {
+		for _, key := range [][]byte{
+			explore.ParentsKey(ticket),
+			explore.ChildrenKey(ticket),
+			explore.CallersKey(ticket),
+			explore.CalleesKey(ticket),
+			explore.SupertypesKey(ticket),
+			explore.SubtypesKey(ticket),
+			explore.ParametersKey(ticket),
+		} {
+			u.deletes = append(u.deletes, key)
+			if err := u.copy(ctx, key, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copy adds a write of key's value in the partial table, if it exists.  If msg
+// is non-nil, the value is also unmarshaled into it.
+func (u *tableUpdate) copy(ctx context.Context, key []byte, msg proto.Message) error {
+	val, err := u.partial.Get(ctx, key, nil)
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading %q: %v", key, err)
+	}
+	u.writes = append(u.writes, keyValue{key, val})
+	if msg != nil {
+		if err := proto.Unmarshal(val, msg); err != nil {
+			return fmt.Errorf("error unmarshaling %q: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// updateSearchIndex replaces the search metadata of each of the given files
+// and moves them between the postings of the trigrams of their old and new
+// text.
+func (u *tableUpdate) updateSearchIndex(ctx context.Context, files stringset.Set) error {
+	partial := &table.KVProto{DB: u.partial}
+	added := make(map[string][]string)   // trigram -> files now containing it
+	removed := make(map[string][]string) // trigram -> files no longer containing it
+	for file := range files {
+		u.deletes = append(u.deletes, search.FileKey(file))
+		if err := u.copy(ctx, search.FileKey(file), nil); err != nil {
+			return err
+		}
+
+		var oldDecor, newDecor srvpb.FileDecorations
+		if err := u.old.Lookup(ctx, xsrv.DecorationsKey(file), &oldDecor); err != nil && err != table.ErrNoSuchKey {
+			return fmt.Errorf("error reading decorations for %q: %v", file, err)
+		} else if err := partial.Lookup(ctx, xsrv.DecorationsKey(file), &newDecor); err != nil && err != table.ErrNoSuchKey {
+			return fmt.Errorf("error reading decorations for %q: %v", file, err)
+		}
+		oldTrigrams := stringset.New(search.Trigrams(oldDecor.GetFile().GetText())...)
+		newTrigrams := stringset.New(search.Trigrams(newDecor.GetFile().GetText())...)
+		for tri := range newTrigrams.Diff(oldTrigrams) {
+			added[tri] = append(added[tri], file)
+		}
+		for tri := range oldTrigrams.Diff(newTrigrams) {
+			removed[tri] = append(removed[tri], file)
+		}
+	}
+
+	trigrams := stringset.New()
+	for tri := range added {
+		trigrams.Add(tri)
+	}
+	for tri := range removed {
+		trigrams.Add(tri)
+	}
+	for _, tri := range trigrams.Elements() {
+		key := search.TrigramKey(tri)
+		p := &srvpb.SearchPostings{Trigram: []byte(tri)}
+		if err := u.old.Lookup(ctx, key, p); err != nil && err != table.ErrNoSuchKey {
+			return fmt.Errorf("error reading search postings: %v", err)
+		}
+		tickets := stringset.New(p.FileTicket...)
+		tickets.Discard(removed[tri]...)
+		tickets.Add(added[tri]...)
+		if tickets.Empty() {
+			u.deletes = append(u.deletes, key)
+			continue
+		}
+		p.FileTicket = tickets.Elements()
+		rec, err := proto.Marshal(p)
+		if err != nil {
+			return err
+		}
+		u.writes = append(u.writes, keyValue{key, rec})
+	}
+	return nil
+}
+
+// updateIdentifiers replaces the entries of each of the given nodes within the
+// identifier matches of their old and new qualified names, and moves any
+// qualified names that are added or removed between the identifier postings.
+func (u *tableUpdate) updateIdentifiers(ctx context.Context, g *subgraph, tickets stringset.Set) error {
+	partial := &table.KVProto{DB: u.partial}
+	matches := make(map[string]*srvpb.IdentifierMatch) // qname -> updated match
+	oldBases := make(map[string]string)                // qname -> old base name, if it existed
+	match := func(qname string) (*srvpb.IdentifierMatch, error) {
+		if m, ok := matches[qname]; ok {
+			return m, nil
+		}
+		m := new(srvpb.IdentifierMatch)
+		if err := u.old.Lookup(ctx, identifiers.IdentifierKey(qname), m); err == nil {
+			oldBases[qname] = m.BaseName
+		} else if err != table.ErrNoSuchKey {
+			return nil, fmt.Errorf("error reading identifier %q: %v", qname, err)
+		}
+		matches[qname] = m
+		return m, nil
+	}
+
+	for _, ticket := range tickets.Elements() {
+		n, err := g.node(ctx, ticket)
+		if err != nil {
+			return err
+		}
+		old := n
+		if n.old != nil {
+			old = n.old
+		}
+		if qname, _, err := graphNodeNames(old); err != nil {
+			return err
+		} else if qname != "" {
+			m, err := match(qname)
+			if err != nil {
+				return err
+			}
+			kept := m.Node[:0]
+			for _, node := range m.Node {
+				if node.Ticket != ticket {
+					kept = append(kept, node)
+				}
+			}
+			m.Node = kept
+		}
+
+		qname, base, err := graphNodeNames(n)
+		if err != nil {
+			return err
+		} else if qname == "" {
+			continue
+		}
+		var pm srvpb.IdentifierMatch
+		if err := partial.Lookup(ctx, identifiers.IdentifierKey(qname), &pm); err != nil && err != table.ErrNoSuchKey {
+			return fmt.Errorf("error reading identifier %q: %v", qname, err)
+		}
+		m, err := match(qname)
+		if err != nil {
+			return err
+		}
+		if len(m.Node) == 0 {
+			m.QualifiedName, m.BaseName = qname, base
+		}
+		for _, node := range pm.Node {
+			if node.Ticket == ticket {
+				m.Node = append(m.Node, node)
+			}
+		}
+	}
+
+	added := make(map[string][]string)   // index key -> qnames now indexed by it
+	removed := make(map[string][]string) // index key -> qnames no longer indexed by it
+	for qname, m := range matches {
+		key := identifiers.IdentifierKey(qname)
+		oldBase, existed := oldBases[qname]
+		var oldKeys, newKeys stringset.Set
+		if existed {
+			oldKeys = stringset.New(identifiers.IndexKeys(qname, oldBase)...)
+		}
+		u.deletes = append(u.deletes, key)
+		if len(m.Node) > 0 {
+			sort.Slice(m.Node, func(i, j int) bool { return m.Node[i].Ticket < m.Node[j].Ticket })
+			rec, err := proto.Marshal(m)
+			if err != nil {
+				return err
+			}
+			u.writes = append(u.writes, keyValue{key, rec})
+			newKeys = stringset.New(identifiers.IndexKeys(qname, m.BaseName)...)
+		}
+		for k := range newKeys.Diff(oldKeys) {
+			added[k] = append(added[k], qname)
+		}
+		for k := range oldKeys.Diff(newKeys) {
+			removed[k] = append(removed[k], qname)
+		}
+	}
+
+	keys := stringset.New()
+	for k := range added {
+		keys.Add(k)
+	}
+	for k := range removed {
+		keys.Add(k)
+	}
+	postings := make(map[string]stringset.Set) // index key -> updated qnames
+	refill := stringset.New()                  // keys whose trimmed postings lost names
+	for k := range keys {
+		p := new(srvpb.IdentifierPostings)
+		if err := u.old.Lookup(ctx, identifiers.PostingsKey(k), p); err != nil && err != table.ErrNoSuchKey {
+			return fmt.Errorf("error reading identifier postings: %v", err)
+		}
+		qnames := stringset.New(p.QualifiedName...)
+		qnames.Discard(removed[k]...)
+		if len(qnames) < len(p.QualifiedName) && len(p.QualifiedName) >= identifiers.MaxShortPostings {
+			refill.Add(k)
+		}
+		qnames.Add(added[k]...)
+		postings[k] = qnames
+	}
+	// Postings at the limit may have been trimmed by LimitPostings, so the
+	// names trimmed from those losing names are recovered from the identifier
+	// matches.
+	if !refill.Empty() {
+		if err := u.refillPostings(ctx, postings, refill, matches); err != nil {
+			return fmt.Errorf("error recovering identifier postings: %v", err)
+		}
+	}
+
+	for _, k := range keys.Elements() {
+		key := identifiers.PostingsKey(k)
+		qnames := postings[k]
+		if qnames.Empty() {
+			u.deletes = append(u.deletes, key)
+			continue
+		}
+		p := &srvpb.IdentifierPostings{Key: k, QualifiedName: qnames.Elements()}
+		identifiers.LimitPostings(p)
+		rec, err := proto.Marshal(p)
+		if err != nil {
+			return err
+		}
+		u.writes = append(u.writes, keyValue{key, rec})
+	}
+	return nil
+}
+
+// refillPostings adds to the postings of each of the given keys the qualified
+// names of all identifiers indexed by it.  The identifier matches of the table
+// are read as updated by matches.
+func (u *tableUpdate) refillPostings(ctx context.Context, postings map[string]stringset.Set, keys stringset.Set, matches map[string]*srvpb.IdentifierMatch) error {
+	add := func(qname, base string) {
+		for _, k := range identifiers.IndexKeys(qname, base) {
+			if keys.Contains(k) {
+				qnames := postings[k]
+				qnames.Add(qname)
+			}
+		}
+	}
+	for qname, m := range matches {
+		if len(m.Node) > 0 {
+			add(qname, m.BaseName)
+		}
+	}
+
+	it, err := u.db.ScanPrefix(ctx, []byte(identifiers.IdentifierTablePrefix), &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for {
+		key, val, err := it.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		qname := strings.TrimPrefix(string(key), identifiers.IdentifierTablePrefix)
+		if _, ok := matches[qname]; ok {
+			continue // already added, if it remains
+		}
+		var m srvpb.IdentifierMatch
+		if err := proto.Unmarshal(val, &m); err != nil {
+			return fmt.Errorf("error unmarshaling identifier %q: %v", qname, err)
+		}
+		add(qname, m.BaseName)
+	}
+}
+
+// graphNodeNames returns the qualified and simple names of n's MarkedSource.
+func graphNodeNames(n *graphNode) (qname, base string, err error) {
+	code, ok := n.facts[facts.Code]
+	if !ok {
+		return "", "", nil
+	}
+	var ms cpb.MarkedSource
+	if err := proto.Unmarshal(code, &ms); err != nil {
+		return "", "", fmt.Errorf("error unmarshaling code for %q: %v", n.ticket, err)
+	}
+	qname, base = identifierNames(&ms)
+	return qname, base, nil
+}
+
+type dirKey struct{ corpus, root, path string }
+
+// updateFileTree adds the writes and deletions needed to add and remove the
+// given files from the table's file tree.  As in filetree.Map, a directory
+// exists only while it holds at least one file, recursively.
+func (u *tableUpdate) updateFileTree(ctx context.Context, added, removed []*spb.VName) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	var cr ftpb.CorpusRootsReply
+	if err := u.old.Lookup(ctx, ftsrv.CorpusRootsPrefixedKey, &cr); err != nil && err != table.ErrNoSuchKey {
+		return err
+	}
+
+	dirs := make(map[dirKey]*srvpb.FileDirectory) // nil for absent directories
+	dir := func(k dirKey) (*srvpb.FileDirectory, error) {
+		if d, ok := dirs[k]; ok {
+			return d, nil
+		}
+		var d srvpb.FileDirectory
+		if err := u.old.Lookup(ctx, ftsrv.PrefixedDirKey(k.corpus, k.root, k.path), &d); err == table.ErrNoSuchKey {
+			dirs[k] = nil
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		dirs[k] = &d
+		return &d, nil
+	}
+	parent := func(k dirKey) (dirKey, string) {
+		p := filepath.Dir(k.path)
+		if p == "." {
+			p = ""
+		}
+		return dirKey{k.corpus, k.root, p}, filepath.Base(k.path)
+	}
+
+	for _, file := range added {
+		k := dirKey{file.Corpus, file.Root, filetree.CleanDirPath(path.Dir(file.Path))}
+		entry := &srvpb.FileDirectory_Entry{
+			Kind: srvpb.FileDirectory_FILE,
+			Name: filepath.Base(file.Path),
+		}
+		for {
+			d, err := dir(k)
+			if err != nil {
+				return err
+			}
+			created := d == nil
+			if created {
+				d = &srvpb.FileDirectory{}
+				dirs[k] = d
+			}
+			d.Entry = addDirEntry(d.Entry, entry)
+			if !created {
+				break
+			} else if k.path == "" {
+				addCorpusRoot(&cr, k.corpus, k.root)
+				break
+			}
+			var name string
+			k, name = parent(k)
+			entry = &srvpb.FileDirectory_Entry{
+				Kind: srvpb.FileDirectory_DIRECTORY,
+				Name: name,
+			}
+		}
+	}
+
+	for _, file := range removed {
+		k := dirKey{file.Corpus, file.Root, filetree.CleanDirPath(path.Dir(file.Path))}
+		kind, name := srvpb.FileDirectory_FILE, filepath.Base(file.Path)
+		for {
+			d, err := dir(k)
+			if err != nil {
+				return err
+			} else if d == nil {
+				break
+			}
+			d.Entry = removeDirEntry(d.Entry, kind, name)
+			if len(d.Entry) > 0 {
+				break
+			}
+			dirs[k] = nil
+			if k.path == "" {
+				removeCorpusRoot(&cr, k.corpus, k.root)
+				break
+			}
+			k, name = parent(k)
+			kind = srvpb.FileDirectory_DIRECTORY
+		}
+	}
+
+	for k, d := range dirs {
+		key := ftsrv.PrefixedDirKey(k.corpus, k.root, k.path)
+		if d == nil {
+			u.deletes = append(u.deletes, key)
+			continue
+		}
+		rec, err := proto.Marshal(d)
+		if err != nil {
+			return err
+		}
+		u.writes = append(u.writes, keyValue{key, rec})
+	}
+	rec, err := proto.Marshal(&cr)
+	if err != nil {
+		return err
+	}
+	u.writes = append(u.writes, keyValue{ftsrv.CorpusRootsPrefixedKey, rec})
+	return nil
+}
+
+func addDirEntry(entries []*srvpb.FileDirectory_Entry, e *srvpb.FileDirectory_Entry) []*srvpb.FileDirectory_Entry {
+	for _, x := range entries {
+		if x.Kind == e.Kind && x.Name == e.Name {
+			return entries
+		}
+	}
+	return append(entries, e)
+}
+
+func removeDirEntry(entries []*srvpb.FileDirectory_Entry, kind srvpb.FileDirectory_Kind, name string) []*srvpb.FileDirectory_Entry {
+	res := entries[:0]
+	for _, e := range entries {
+		if e.Kind != kind || e.Name != name {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func addCorpusRoot(cr *ftpb.CorpusRootsReply, corpus, root string) {
+	for _, c := range cr.Corpus {
+		if c.Name == corpus {
+			for _, r := range c.Root {
+				if r == root {
+					return
+				}
+			}
+			c.Root = append(c.Root, root)
+			return
+		}
+	}
+	cr.Corpus = append(cr.Corpus, &ftpb.CorpusRootsReply_Corpus{
+		Name: corpus,
+		Root: []string{root},
+	})
+}
+
+func removeCorpusRoot(cr *ftpb.CorpusRootsReply, corpus, root string) {
+	corpora := cr.Corpus[:0]
+	for _, c := range cr.Corpus {
+		if c.Name == corpus {
+			roots := c.Root[:0]
+			for _, r := range c.Root {
+				if r != root {
+					roots = append(roots, r)
+				}
+			}
+			if c.Root = roots; len(roots) == 0 {
+				continue
+			}
+		}
+		corpora = append(corpora, c)
+	}
+	cr.Corpus = corpora
+}
+
+// entryCountPrefix is the key prefix of the number of copies of an entry given
+// to Run or added by Update.  Counts are only kept for entries with more than
+// one copy.
+const entryCountPrefix = "entrycount:"
+
+// entryCountKey returns the table key of the number of copies of e.
+func entryCountKey(e *spb.Entry) ([]byte, error) {
+	key, err := keyvalue.EncodeKey(e.Source, e.FactName, e.EdgeKind, e.Target)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(entryCountPrefix), key...), nil
+}
+
+// countEntries returns a reader of the entries of rd, which must be in
+// GraphStore order, that writes the number of copies of each entry given more
+// than once to w.  The copies are still passed along.
+func countEntries(ctx context.Context, rd stream.EntryReader, w *keyvalue.WritePool) stream.EntryReader {
+	return func(f func(*spb.Entry) error) error {
+		var (
+			last   *spb.Entry
+			copies int
+		)
+		flush := func() error {
+			if copies < 2 {
+				return nil
+			}
+			key, err := entryCountKey(last)
+			if err != nil {
+				return err
+			}
+			return w.Write(ctx, key, []byte(strconv.Itoa(copies)))
+		}
+		if err := rd(func(e *spb.Entry) error {
+			if last != nil && compare.Entries(last, e) == compare.EQ {
+				copies++
+			} else {
+				if err := flush(); err != nil {
+					return err
+				}
+				last, copies = e, 1
+			}
+			return f(e)
+		}); err != nil {
+			return err
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+}
+
+// updateEntryCounts adds the writes and deletions of the given entry counts,
+// keyed by entryCountKey.
+func (u *tableUpdate) updateEntryCounts(copies map[string]int) {
+	for key, n := range copies {
+		if n > 1 {
+			u.writes = append(u.writes, keyValue{[]byte(key), []byte(strconv.Itoa(n))})
+		} else {
+			u.deletes = append(u.deletes, []byte(key))
+		}
+	}
+}
+
+// write applies the update's deletions and then its writes to db.
+func (u *tableUpdate) write(ctx context.Context, db keyvalue.DB) error {
+	w, err := db.Writer(ctx)
+	if err != nil {
+		return err
+	}
+	d, ok := w.(keyvalue.Deleter)
+	if !ok {
+		w.Close()
+		return fmt.Errorf("keyvalue.Writer does not support deletion: %T", w)
+	}
+	for _, key := range u.deletes {
+		if err := d.Delete(key); err != nil {
+			d.Close()
+			return fmt.Errorf("error deleting %q: %v", key, err)
+		}
+	}
+	for _, kv := range u.writes {
+		if err := d.Write(kv.key, kv.val); err != nil {
+			d.Close()
+			return fmt.Errorf("error writing %q: %v", kv.key, err)
+		}
+	}
+	return d.Close()
+}
@@ -0,0 +1,348 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	ftsrv "kythe.io/kythe/go/serving/filetree"
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/schema/nodes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	spb "kythe.io/kythe/proto/storage_go_proto"
+)
+
+type testGraph []*spb.Entry
+
+func (g *testGraph) node(v *spb.VName, kvs ...string) {
+	for i := 0; i+1 < len(kvs); i += 2 {
+		*g = append(*g, &spb.Entry{Source: v, FactName: kvs[i], FactValue: []byte(kvs[i+1])})
+	}
+}
+
+func (g *testGraph) edge(src *spb.VName, kind string, tgt *spb.VName) {
+	*g = append(*g, &spb.Entry{Source: src, EdgeKind: kind, Target: tgt, FactName: "/"})
+}
+
+func (g *testGraph) file(v *spb.VName, text string) {
+	g.node(v, facts.NodeKind, nodes.File, facts.Text, text)
+}
+
+// code returns the serialized MarkedSource of an identifier with the given
+// name and qualifier.
+func code(qualifier, name string) string {
+	rec, err := proto.Marshal(&cpb.MarkedSource{
+		Kind: cpb.MarkedSource_BOX,
+		Child: []*cpb.MarkedSource{{
+			Kind:          cpb.MarkedSource_CONTEXT,
+			PostChildText: ".",
+			Child:         []*cpb.MarkedSource{{Kind: cpb.MarkedSource_IDENTIFIER, PreText: qualifier}},
+		}, {
+			Kind:    cpb.MarkedSource_IDENTIFIER,
+			PreText: name,
+		}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return string(rec)
+}
+
+func (g *testGraph) anchor(file *spb.VName, start, end int, kind string, tgt *spb.VName) *spb.VName {
+	a := &spb.VName{
+		Corpus:    file.Corpus,
+		Root:      file.Root,
+		Path:      file.Path,
+		Signature: fmt.Sprintf("@%d:%d", start, end),
+	}
+	g.node(a,
+		facts.NodeKind, nodes.Anchor,
+		facts.AnchorStart, fmt.Sprint(start),
+		facts.AnchorEnd, fmt.Sprint(end))
+	g.edge(a, edges.ChildOf, file)
+	g.edge(a, kind, tgt)
+	return a
+}
+
+// sorted returns the entries of g in GraphStore order.
+func (g testGraph) sorted() testGraph {
+	sort.Slice(g, func(i, j int) bool { return compare.Entries(g[i], g[j]) == compare.LT })
+	return g
+}
+
+func (g testGraph) reader(f func(*spb.Entry) error) error {
+	for _, e := range g {
+		if err := f(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minus returns the entries of g that are not in h.
+func (g testGraph) minus(h testGraph) testGraph {
+	var res testGraph
+	for _, e := range g {
+		var found bool
+		for _, x := range h {
+			if compare.EntriesEqual(e, x) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func testNode(path, sig string) *spb.VName {
+	return &spb.VName{Corpus: "corpus", Path: path, Signature: sig, Language: "lang"}
+}
+
+func testFile(path string) *spb.VName {
+	return &spb.VName{Corpus: "corpus", Path: path}
+}
+
+var (
+	fileA = testFile("src/a.go")
+	fileB = testFile("src/b.go")
+	fileD = testFile("src/sub/d.go")
+	fileE = testFile("old/e.go")
+
+	funcF  = testNode("", "f")
+	funcG  = testNode("", "g")
+	funcH  = testNode("", "h")
+	funcM  = testNode("", "main")
+	varV   = testNode("", "v")
+	paramX = testNode("", "x")
+	intT   = testNode("", "int")
+	fnT    = testNode("", "fn")
+	funcT  = testNode("", "func")
+	typeT  = testNode("", "T")
+	typeU  = testNode("", "U")
+	typeV  = testNode("", "V")
+)
+
+// before returns the entries of the graph before the delta.
+func before() testGraph {
+	var g testGraph
+	g.file(fileA, "func f(x int) {}\nvar v = f()\n")
+	g.node(funcF, facts.NodeKind, nodes.Function, facts.Code, code("pkg", "f"))
+	g.node(paramX, facts.NodeKind, nodes.Variable)
+	g.node(intT, facts.NodeKind, nodes.TBuiltin)
+	g.node(fnT, facts.NodeKind, nodes.TBuiltin)
+	g.node(funcT, facts.NodeKind, nodes.TApp)
+	g.edge(funcT, edges.ParamIndex(0), fnT)
+	g.edge(funcT, edges.ParamIndex(1), intT)
+	g.edge(funcF, edges.Typed, funcT)
+	g.node(funcH, facts.NodeKind, nodes.Function)
+	g.edge(funcH, edges.Typed, funcT)
+	g.edge(funcF, edges.ParamIndex(0), paramX)
+	g.edge(funcF, edges.ChildOf, fileA)
+	g.edge(paramX, edges.Typed, intT)
+	g.edge(paramX, edges.ChildOf, funcF)
+	g.anchor(fileA, 5, 6, edges.DefinesBinding, funcF)
+	g.anchor(fileA, 7, 8, edges.DefinesBinding, paramX)
+	g.anchor(fileA, 9, 12, edges.Ref, intT)
+	g.node(varV, facts.NodeKind, nodes.Variable)
+	g.edge(varV, edges.ChildOf, fileA)
+	g.edge(g.anchor(fileA, 25, 26, edges.RefCall, funcF), edges.ChildOf, varV)
+
+	g.node(typeT, facts.NodeKind, nodes.Record, facts.Code, code("pkg", "T"))
+	g.node(typeU, facts.NodeKind, nodes.Record)
+	g.edge(typeT, edges.Extends, typeU)
+
+	g.file(fileB, "f()\nT\n")
+	g.node(funcM, facts.NodeKind, nodes.Function, facts.Code, code("pkg", "main"))
+	g.edge(funcM, edges.ChildOf, fileB)
+	g.edge(g.anchor(fileB, 0, 1, edges.RefCall, funcF), edges.ChildOf, funcM)
+	g.anchor(fileB, 4, 5, edges.Ref, typeT)
+
+	g.file(fileE, "f\n")
+	g.anchor(fileE, 0, 1, edges.Ref, funcF)
+	return g
+}
+
+// after returns the entries of the graph after the delta.  b.go is changed to
+// also call g, e.go is removed, d.go is added, T extends V rather than U, and
+// the functions of type func now return T.
+func after() testGraph {
+	var g testGraph
+	g.file(fileA, "func f(x int) {}\nvar v = f()\n")
+	g.node(funcF, facts.NodeKind, nodes.Function, facts.Code, code("pkg", "f"))
+	g.node(paramX, facts.NodeKind, nodes.Variable)
+	g.node(intT, facts.NodeKind, nodes.TBuiltin)
+	g.node(fnT, facts.NodeKind, nodes.TBuiltin)
+	g.node(funcT, facts.NodeKind, nodes.TApp)
+	g.edge(funcT, edges.ParamIndex(0), fnT)
+	g.edge(funcT, edges.ParamIndex(1), typeT)
+	g.edge(funcF, edges.Typed, funcT)
+	g.node(funcH, facts.NodeKind, nodes.Function)
+	g.edge(funcH, edges.Typed, funcT)
+	g.edge(funcF, edges.ParamIndex(0), paramX)
+	g.edge(funcF, edges.ChildOf, fileA)
+	g.edge(paramX, edges.Typed, intT)
+	g.edge(paramX, edges.ChildOf, funcF)
+	g.anchor(fileA, 5, 6, edges.DefinesBinding, funcF)
+	g.anchor(fileA, 7, 8, edges.DefinesBinding, paramX)
+	g.anchor(fileA, 9, 12, edges.Ref, intT)
+	g.node(varV, facts.NodeKind, nodes.Variable)
+	g.edge(varV, edges.ChildOf, fileA)
+	g.edge(g.anchor(fileA, 25, 26, edges.RefCall, funcF), edges.ChildOf, varV)
+
+	g.node(typeT, facts.NodeKind, nodes.Record, facts.Complete, "definition", facts.Code, code("pkg", "T"))
+	g.node(typeU, facts.NodeKind, nodes.Record)
+	g.node(typeV, facts.NodeKind, nodes.Record)
+	g.edge(typeT, edges.Extends, typeV)
+
+	g.file(fileB, "  g()\n  f()\nT\n")
+	g.node(funcM, facts.NodeKind, nodes.Function, facts.Code, code("pkg", "start"))
+	g.edge(funcM, edges.ChildOf, fileB)
+	g.edge(g.anchor(fileB, 2, 3, edges.RefCall, funcG), edges.ChildOf, funcM)
+	g.edge(g.anchor(fileB, 8, 9, edges.RefCall, funcF), edges.ChildOf, funcM)
+	g.anchor(fileB, 12, 13, edges.Ref, typeT)
+
+	g.file(fileD, "func g(x int) {}\n")
+	g.node(funcG, facts.NodeKind, nodes.Function, facts.Code, code("pkg", "g"))
+	g.edge(funcG, edges.Typed, funcT)
+	g.edge(funcG, edges.ParamIndex(0), paramX)
+	g.edge(funcG, edges.ChildOf, fileD)
+	g.anchor(fileD, 5, 6, edges.DefinesBinding, funcG)
+	return g
+}
+
+func TestUpdate(t *testing.T) {
+	for _, pageSize := range []int{0, 2} {
+		t.Run(fmt.Sprintf("max_page_size=%d", pageSize), func(t *testing.T) {
+			ctx := context.Background()
+			opts := &Options{MaxPageSize: pageSize}
+			prev, next := before(), after()
+
+			// The compilation of e.go also emitted entries of a.go's, which
+			// remain after it is removed.
+			var shared testGraph
+			shared.node(intT, facts.NodeKind, nodes.TBuiltin)
+			shared.edge(funcF, edges.ChildOf, fileA)
+			prev = append(prev, shared...)
+
+			updated := inmemory.NewKeyValueDB()
+			if err := Run(ctx, prev.sorted().reader, updated, opts); err != nil {
+				t.Fatalf("Error writing initial table: %v", err)
+			}
+			if err := Update(ctx, updated, &Delta{
+				Removed: append(prev.minus(next), shared...).reader,
+				Added:   next.minus(prev).reader,
+			}, opts); err != nil {
+				t.Fatalf("Error updating table: %v", err)
+			}
+
+			expected := inmemory.NewKeyValueDB()
+			if err := Run(ctx, next.sorted().reader, expected, opts); err != nil {
+				t.Fatalf("Error writing expected table: %v", err)
+			}
+
+			found, want := readTable(t, updated), readTable(t, expected)
+			if diff := cmp.Diff(want, found); diff != "" {
+				t.Errorf("Updated table differs from rebuilt table: (- expected; + found)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate_limitedPostings(t *testing.T) {
+	ctx := context.Background()
+
+	// More functions share the "f" prefix than its postings can hold, so
+	// removing one of the shortest must restore one that was trimmed.
+	var prev testGraph
+	for i := 0; i <= identifiers.MaxShortPostings; i++ {
+		fn := testNode("", fmt.Sprintf("f%d", i))
+		prev.node(fn, facts.NodeKind, nodes.Function, facts.Code, code("pkg", fmt.Sprintf("f%d", i)))
+	}
+	var removed testGraph
+	removed.node(testNode("", "f0"), facts.NodeKind, nodes.Function, facts.Code, code("pkg", "f0"))
+	next := prev.minus(removed)
+
+	updated := inmemory.NewKeyValueDB()
+	if err := Run(ctx, prev.sorted().reader, updated, nil); err != nil {
+		t.Fatalf("Error writing initial table: %v", err)
+	}
+	if err := Update(ctx, updated, &Delta{Removed: removed.reader}, nil); err != nil {
+		t.Fatalf("Error updating table: %v", err)
+	}
+
+	expected := inmemory.NewKeyValueDB()
+	if err := Run(ctx, next.sorted().reader, expected, nil); err != nil {
+		t.Fatalf("Error writing expected table: %v", err)
+	}
+
+	found, want := readTable(t, updated), readTable(t, expected)
+	if diff := cmp.Diff(want, found); diff != "" {
+		t.Errorf("Updated table differs from rebuilt table: (- expected; + found)\n%s", diff)
+	}
+}
+
+// readTable returns a textual representation of each value in db, keyed by
+// its key.  Directory entries are sorted, as their order is unspecified.
+func readTable(t *testing.T, db keyvalue.DB) map[string]string {
+	t.Helper()
+	it, err := db.ScanPrefix(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	res := make(map[string]string)
+	for {
+		key, val, err := it.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if k := string(key); k != string(ftsrv.CorpusRootsPrefixedKey) && strings.HasPrefix(k, ftsrv.DirTablePrefix) {
+			var d srvpb.FileDirectory
+			if err := proto.Unmarshal(val, &d); err != nil {
+				t.Fatalf("Error unmarshaling %q: %v", key, err)
+			}
+			sort.Slice(d.Entry, func(i, j int) bool { return d.Entry[i].Name < d.Entry[j].Name })
+			val, err = proto.Marshal(&d)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		res[string(key)] = fmt.Sprintf("%q", val)
+	}
+	return res
+}
@@ -29,22 +29,29 @@ import (
 
 	"kythe.io/kythe/go/services/filetree"
 	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/serving/explore"
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/search"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
 	"kythe.io/kythe/go/serving/xrefs/assemble"
 	"kythe.io/kythe/go/storage/keyvalue"
 	"kythe.io/kythe/go/storage/stream"
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/util/disksort"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/markedsource"
 	"kythe.io/kythe/go/util/schema/edges"
 	"kythe.io/kythe/go/util/schema/facts"
 	"kythe.io/kythe/go/util/schema/nodes"
 	"kythe.io/kythe/go/util/sortutil"
 	"kythe.io/kythe/go/util/span"
 
+	"bitbucket.org/creachadair/stringset"
 	"github.com/golang/protobuf/proto"
 
+	cpb "kythe.io/kythe/proto/common_go_proto"
 	ftpb "kythe.io/kythe/proto/filetree_go_proto"
 	ipb "kythe.io/kythe/proto/internal_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
@@ -85,8 +92,9 @@ type servingOutput struct {
 	xs table.Proto
 }
 
-// Run writes the xrefs and filetree serving tables to db based on the given
-// entries (in GraphStore-order).
+// Run writes the xrefs, filetree, explore, search, and identifier serving
+// tables to db based on the given entries (in GraphStore-order).  The number
+// of copies of each entry given more than once is also recorded for Update.
 func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Options) error {
 	if opts == nil {
 		opts = new(Options)
@@ -97,7 +105,7 @@ func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Optio
 	out := &servingOutput{
 		xs: &table.KVProto{DB: db},
 	}
-	rd = filterReverses(rd)
+	rd = countEntries(ctx, filterReverses(rd), keyvalue.NewPool(db, nil))
 
 	var cErr error
 	var wg sync.WaitGroup
@@ -117,8 +125,11 @@ func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Optio
 	}
 
 	pesIn, dIn := make(chan *srvpb.Edge, chBuf), make(chan *srvpb.Edge, chBuf)
-	var pErr, fErr error
-	wg.Add(2)
+	tIn, paIn := make(chan *srvpb.Edge, chBuf), make(chan *srvpb.Edge, chBuf)
+	rIn, cIn := make(chan *srvpb.Edge, chBuf), make(chan *srvpb.Edge, chBuf)
+	iIn := make(chan *srvpb.Edge, chBuf)
+	var pErr, fErr, tErr, paErr, rErr, cgErr, iErr error
+	wg.Add(7)
 	go func() {
 		defer wg.Done()
 		if err := writePagedEdges(ctx, pesIn, out.xs, opts); err != nil {
@@ -131,15 +142,55 @@ func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Optio
 			fErr = fmt.Errorf("error writing file decorations: %v", err)
 		}
 	}()
+	go func() {
+		defer wg.Done()
+		if err := writeTypeHierarchy(ctx, tIn, out.xs); err != nil {
+			tErr = fmt.Errorf("error writing type hierarchy: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := writeParameters(ctx, opts, paIn, out.xs); err != nil {
+			paErr = fmt.Errorf("error writing function parameters: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := writeRelatives(ctx, rIn, out.xs); err != nil {
+			rErr = fmt.Errorf("error writing relatives: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := writeCallgraph(ctx, opts, cIn, out.xs); err != nil {
+			cgErr = fmt.Errorf("error writing callgraph: %v", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := writeIdentifiers(ctx, opts, iIn, out.xs); err != nil {
+			iErr = fmt.Errorf("error writing identifiers: %v", err)
+		}
+	}()
 
 	err := sortedEdges.Read(func(x interface{}) error {
 		e := x.(*srvpb.Edge)
 		pesIn <- e
 		dIn <- e
+		tIn <- e
+		paIn <- e
+		rIn <- e
+		cIn <- e
+		iIn <- e
 		return nil
 	})
 	close(pesIn)
 	close(dIn)
+	close(tIn)
+	close(paIn)
+	close(rIn)
+	close(cIn)
+	close(iIn)
 	if err != nil {
 		return fmt.Errorf("error reading edges table: %v", err)
 	}
@@ -147,6 +198,16 @@ func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Optio
 	wg.Wait()
 	if pErr != nil {
 		return pErr
+	} else if tErr != nil {
+		return tErr
+	} else if paErr != nil {
+		return paErr
+	} else if rErr != nil {
+		return rErr
+	} else if cgErr != nil {
+		return cgErr
+	} else if iErr != nil {
+		return iErr
 	}
 	return fErr
 }
@@ -347,6 +408,570 @@ func writePagedEdges(ctx context.Context, edges <-chan *srvpb.Edge, out table.Pr
 	return buffer.Flush(ctx)
 }
 
+// isTypeHierarchyEdge reports whether kind relates a subtype to one of its
+// direct supertypes.
+func isTypeHierarchyEdge(kind string) bool {
+	return edges.IsVariant(kind, edges.Extends) || kind == edges.Satisfies || kind == edges.Overrides
+}
+
+// writeTypeHierarchy writes a srvpb.TypeHierarchy of supertypes and of subtypes
+// for each source node with type hierarchy edges in the given stream.
+func writeTypeHierarchy(ctx context.Context, in <-chan *srvpb.Edge, out table.Proto) error {
+	buffer := out.Buffered()
+	log.Println("Writing TypeHierarchy")
+
+	var ticket string
+	supertypes, subtypes := stringset.New(), stringset.New()
+	flush := func() error {
+		if len(supertypes) > 0 {
+			if err := buffer.Put(ctx, explore.SupertypesKey(ticket), &srvpb.TypeHierarchy{
+				Tickets: supertypes.Elements(),
+				Type:    srvpb.TypeHierarchy_SUPERTYPES,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(subtypes) > 0 {
+			if err := buffer.Put(ctx, explore.SubtypesKey(ticket), &srvpb.TypeHierarchy{
+				Tickets: subtypes.Elements(),
+				Type:    srvpb.TypeHierarchy_SUBTYPES,
+			}); err != nil {
+				return err
+			}
+		}
+		supertypes, subtypes = stringset.New(), stringset.New()
+		return nil
+	}
+
+	for e := range in {
+		if e.Source.Ticket != ticket {
+			if err := flush(); err != nil {
+				for range in {
+				} // drain input channel
+				return err
+			}
+			ticket = e.Source.Ticket
+		}
+		if e.Target == nil {
+			continue
+		}
+
+		if edges.IsReverse(e.Kind) {
+			if isTypeHierarchyEdge(edges.Mirror(e.Kind)) {
+				subtypes.Add(e.Target.Ticket)
+			}
+		} else if isTypeHierarchyEdge(e.Kind) {
+			supertypes.Add(e.Target.Ticket)
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return buffer.Flush(ctx)
+}
+
+// nodeKind returns the node kind fact of the given node.
+func nodeKind(n *srvpb.Node) string {
+	for _, f := range n.GetFact() {
+		if f.Name == facts.NodeKind {
+			return string(f.Value)
+		}
+	}
+	return ""
+}
+
+// writeRelatives writes a srvpb.Relatives of parents and of children for each
+// source node with childof edges in the given stream.  Anchors are neither
+// parents nor children.
+func writeRelatives(ctx context.Context, in <-chan *srvpb.Edge, out table.Proto) error {
+	buffer := out.Buffered()
+	log.Println("Writing Relatives")
+
+	var (
+		ticket string
+		anchor bool
+	)
+	parents, children := stringset.New(), stringset.New()
+	flush := func() error {
+		if len(parents) > 0 {
+			if err := buffer.Put(ctx, explore.ParentsKey(ticket), &srvpb.Relatives{
+				Tickets: parents.Elements(),
+				Type:    srvpb.Relatives_PARENTS,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(children) > 0 {
+			if err := buffer.Put(ctx, explore.ChildrenKey(ticket), &srvpb.Relatives{
+				Tickets: children.Elements(),
+				Type:    srvpb.Relatives_CHILDREN,
+			}); err != nil {
+				return err
+			}
+		}
+		parents, children = stringset.New(), stringset.New()
+		return nil
+	}
+
+	for e := range in {
+		if e.Source.Ticket != ticket {
+			if err := flush(); err != nil {
+				for range in {
+				} // drain input channel
+				return err
+			}
+			ticket, anchor = e.Source.Ticket, false
+		}
+		if e.Target == nil {
+			// Head-only edge: carries the facts of the source node
+			anchor = nodeKind(e.Source) == nodes.Anchor
+			continue
+		} else if anchor || nodeKind(e.Target) == nodes.Anchor {
+			continue
+		}
+
+		switch e.Kind {
+		case edges.ChildOf:
+			parents.Add(e.Target.Ticket)
+		case edges.Mirror(edges.ChildOf):
+			children.Add(e.Target.Ticket)
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return buffer.Flush(ctx)
+}
+
+// writeCallgraph writes a srvpb.Callgraph of callers and of callees for each
+// function node in the given stream.  A function calls each target of the
+// ref/call anchors that are children of it.
+func writeCallgraph(ctx context.Context, opts *Options, in <-chan *srvpb.Edge, out table.Proto) error {
+	calls, err := opts.diskSorter(edgeLesser{}, edgeMarshaler{})
+	if err != nil {
+		for range in {
+		} // drain input channel
+		return err
+	}
+
+	log.Println("Writing call edges")
+	if err := createCallEdges(ctx, in, calls); err != nil {
+		return err
+	}
+
+	log.Println("Writing Callgraph")
+	buffer := out.Buffered()
+	var (
+		ticket, kind string
+		related      stringset.Set
+	)
+	flush := func() error {
+		if len(related) == 0 {
+			return nil
+		}
+		key, cg := explore.CalleesKey(ticket), &srvpb.Callgraph{
+			Tickets: related.Elements(),
+			Type:    srvpb.Callgraph_CALLEE,
+		}
+		if edges.IsReverse(kind) {
+			key, cg.Type = explore.CallersKey(ticket), srvpb.Callgraph_CALLER
+		}
+		related = nil
+		return buffer.Put(ctx, key, cg)
+	}
+	if err := calls.Read(func(x interface{}) error {
+		e := x.(*srvpb.Edge)
+		if e.Source.Ticket != ticket || e.Kind != kind {
+			if err := flush(); err != nil {
+				return err
+			}
+			ticket, kind = e.Source.Ticket, e.Kind
+		}
+		if related == nil {
+			related = stringset.New()
+		}
+		related.Add(e.Target.Ticket)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error reading call edges: %v", err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return buffer.Flush(ctx)
+}
+
+// createCallEdges adds a bare (caller -> callee) ref/call edge, and its mirror,
+// to calls for each ref/call anchor in the given stream.  An anchor's callers
+// are the non-file nodes it is a childof.
+func createCallEdges(ctx context.Context, in <-chan *srvpb.Edge, calls disksort.Interface) error {
+	var (
+		anchor           bool
+		callers, callees []string
+	)
+	flush := func() error {
+		defer func() { callers, callees = nil, nil }()
+		for _, caller := range callers {
+			for _, callee := range callees {
+				if err := calls.Add(&srvpb.Edge{
+					Source: &srvpb.Node{Ticket: caller},
+					Kind:   edges.RefCall,
+					Target: &srvpb.Node{Ticket: callee},
+				}); err != nil {
+					return err
+				}
+				if err := calls.Add(&srvpb.Edge{
+					Source: &srvpb.Node{Ticket: callee},
+					Kind:   edges.Mirror(edges.RefCall),
+					Target: &srvpb.Node{Ticket: caller},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	for e := range in {
+		if e.Target == nil {
+			// Head-only edge: signals a new set of edges with the same Source
+			if err := flush(); err != nil {
+				for range in {
+				} // drain input channel
+				return err
+			}
+			anchor = nodeKind(e.Source) == nodes.Anchor
+			continue
+		} else if !anchor {
+			continue
+		}
+
+		if e.Kind == edges.ChildOf {
+			if kind := nodeKind(e.Target); kind != nodes.File && kind != nodes.Anchor {
+				callers = append(callers, e.Target.Ticket)
+			}
+		} else if edges.IsVariant(e.Kind, edges.RefCall) {
+			callees = append(callees, e.Target.Ticket)
+		}
+	}
+	return flush()
+}
+
+// writeParameters writes a srvpb.FunctionParameters for each function node
+// with param edges, or a known return type, in the given stream.
+func writeParameters(ctx context.Context, opts *Options, in <-chan *srvpb.Edge, out table.Proto) error {
+	fragments, err := opts.diskSorter(paramLesser{}, paramMarshaler{})
+	if err != nil {
+		for range in {
+		} // drain input channel
+		return err
+	}
+
+	log.Println("Writing parameter fragments")
+	if err := createParameterFragments(ctx, in, fragments); err != nil {
+		return err
+	}
+
+	log.Println("Writing FunctionParameters")
+	buffer := out.Buffered()
+	var params *srvpb.FunctionParameters
+	if err := fragments.Read(func(x interface{}) error {
+		fragment := x.(*srvpb.FunctionParameters)
+		if params != nil && params.Ticket != fragment.Ticket {
+			if err := buffer.Put(ctx, explore.ParametersKey(params.Ticket), params); err != nil {
+				return err
+			}
+			params = nil
+		}
+		if params == nil {
+			params = fragment
+		} else {
+			params.Parameter = append(params.Parameter, fragment.Parameter...)
+			if fragment.ReturnTypeTicket != "" {
+				params.ReturnTypeTicket = fragment.ReturnTypeTicket
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error reading parameter fragments: %v", err)
+	}
+	if params != nil {
+		if err := buffer.Put(ctx, explore.ParametersKey(params.Ticket), params); err != nil {
+			return err
+		}
+	}
+	return buffer.Flush(ctx)
+}
+
+// createParameterFragments adds a srvpb.FunctionParameters holding a single
+// parameter to fragments for each reverse param edge in the given stream.  The
+// parameter's type is taken from the typed edge sharing its source node.  For
+// each reverse typed edge from a function type to a function, a fragment
+// holding the function's return type (the type's param.1 target) is added.
+func createParameterFragments(ctx context.Context, in <-chan *srvpb.Edge, fragments disksort.Interface) error {
+	var (
+		src        *srvpb.Node
+		typeTicket string
+		returnType string
+		functions  []*srvpb.Edge // reverse param edges
+		typed      []*srvpb.Edge // reverse typed edges
+	)
+	flush := func() error {
+		defer func() { functions, typed, typeTicket, returnType = nil, nil, "", "" }()
+		if src == nil || (len(functions) == 0 && len(typed) == 0) {
+			return nil
+		}
+		srcKind, srcSubkind, srcCode, err := nodeInfo(src)
+		if err != nil {
+			return err
+		}
+		for _, e := range functions {
+			kind, subkind, code, err := nodeInfo(e.Target)
+			if err != nil {
+				return err
+			} else if kind != nodes.Function {
+				continue
+			}
+			if err := fragments.Add(&srvpb.FunctionParameters{
+				Ticket:       e.Target.Ticket,
+				Kind:         kind,
+				Subkind:      subkind,
+				MarkedSource: code,
+				Parameter: []*srvpb.FunctionParameters_Parameter{{
+					Ticket:       src.Ticket,
+					Ordinal:      e.Ordinal,
+					Kind:         srcKind,
+					Subkind:      srcSubkind,
+					MarkedSource: srcCode,
+					TypeTicket:   typeTicket,
+				}},
+			}); err != nil {
+				return err
+			}
+		}
+		if srcKind != nodes.TApp || returnType == "" {
+			return nil
+		}
+		for _, e := range typed {
+			kind, subkind, code, err := nodeInfo(e.Target)
+			if err != nil {
+				return err
+			} else if kind != nodes.Function {
+				continue
+			}
+			if err := fragments.Add(&srvpb.FunctionParameters{
+				Ticket:           e.Target.Ticket,
+				Kind:             kind,
+				Subkind:          subkind,
+				MarkedSource:     code,
+				ReturnTypeTicket: returnType,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for e := range in {
+		if e.Target == nil {
+			// Head-only edge: signals a new set of edges with the same Source
+			if err := flush(); err != nil {
+				for range in {
+				} // drain input channel
+				return err
+			}
+			src = e.Source
+			continue
+		}
+
+		switch e.Kind {
+		case edges.Mirror(edges.Param):
+			functions = append(functions, e)
+		case edges.Mirror(edges.Typed):
+			typed = append(typed, e)
+		case edges.Typed:
+			typeTicket = e.Target.Ticket
+		case edges.Param:
+			if e.Ordinal == 1 {
+				returnType = e.Target.Ticket
+			}
+		}
+	}
+	return flush()
+}
+
+// writeIdentifiers writes a srvpb.IdentifierMatch for each qualified name of the
+// nodes in the given stream, along with the srvpb.IdentifierPostings indexing
+// them.  Each node's reference count is its number of incoming ref edges.
+func writeIdentifiers(ctx context.Context, opts *Options, in <-chan *srvpb.Edge, out table.Proto) error {
+	idents, err := opts.diskSorter(identLesser{}, identMarshaler{})
+	if err != nil {
+		for range in {
+		} // drain input channel
+		return err
+	}
+	postings, err := opts.diskSorter(identPostingLesser{}, identPostingMarshaler{})
+	if err != nil {
+		for range in {
+		} // drain input channel
+		return err
+	}
+
+	log.Println("Writing identifier nodes")
+	if err := createIdentifierNodes(ctx, in, idents); err != nil {
+		return err
+	}
+
+	log.Println("Writing IdentifierMatches")
+	buffer := out.Buffered()
+	var cur *srvpb.IdentifierMatch
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+		for _, key := range identifiers.IndexKeys(cur.QualifiedName, cur.BaseName) {
+			if err := postings.Add(&srvpb.IdentifierPostings{
+				Key:           key,
+				QualifiedName: []string{cur.QualifiedName},
+			}); err != nil {
+				return fmt.Errorf("error adding IdentifierPostings to sorter: %v", err)
+			}
+		}
+		return buffer.Put(ctx, identifiers.IdentifierKey(cur.QualifiedName), cur)
+	}
+	if err := idents.Read(func(x interface{}) error {
+		m := x.(*srvpb.IdentifierMatch)
+		if cur != nil && cur.QualifiedName == m.QualifiedName {
+			cur.Node = append(cur.Node, m.Node...)
+			return nil
+		} else if err := flush(); err != nil {
+			return err
+		}
+		cur = m
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error reading identifier nodes: %v", err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	log.Println("Writing IdentifierPostings")
+	var p *srvpb.IdentifierPostings
+	putPostings := func() error {
+		identifiers.LimitPostings(p)
+		return buffer.Put(ctx, identifiers.PostingsKey(p.Key), p)
+	}
+	if err := postings.Read(func(x interface{}) error {
+		next := x.(*srvpb.IdentifierPostings)
+		if p != nil && p.Key == next.Key {
+			p.QualifiedName = append(p.QualifiedName, next.QualifiedName...)
+			return nil
+		} else if p != nil {
+			if err := putPostings(); err != nil {
+				return err
+			}
+		}
+		p = next
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error reading identifier postings: %v", err)
+	}
+	if p != nil {
+		if err := putPostings(); err != nil {
+			return err
+		}
+	}
+	return buffer.Flush(ctx)
+}
+
+// createIdentifierNodes adds a single-node *srvpb.IdentifierMatch to idents for
+// each node with a qualified name in the given stream.
+func createIdentifierNodes(ctx context.Context, in <-chan *srvpb.Edge, idents disksort.Interface) error {
+	var (
+		ticket string
+		match  *srvpb.IdentifierMatch
+		refs   int32
+	)
+	flush := func() error {
+		if match == nil {
+			return nil
+		}
+		match.Node[0].RefCount = refs
+		m := match
+		match = nil
+		return idents.Add(m)
+	}
+
+	for e := range in {
+		if e.Source.Ticket != ticket {
+			if err := flush(); err != nil {
+				for range in {
+				} // drain input channel
+				return fmt.Errorf("error adding IdentifierMatch to sorter: %v", err)
+			}
+			ticket, refs = e.Source.Ticket, 0
+		}
+		if e.Target == nil {
+			// Head-only edge: carries the facts of the source node
+			kind, subkind, ms, err := nodeInfo(e.Source)
+			if err != nil {
+				log.Printf("WARNING: %v", err)
+				continue
+			}
+			if qname, base := identifierNames(ms); qname != "" {
+				match = &srvpb.IdentifierMatch{
+					QualifiedName: qname,
+					BaseName:      base,
+					Node: []*srvpb.IdentifierMatch_Node{{
+						Ticket:      e.Source.Ticket,
+						NodeKind:    kind,
+						NodeSubkind: subkind,
+					}},
+				}
+			}
+		} else if edges.IsReverse(e.Kind) && edges.IsVariant(edges.Mirror(e.Kind), edges.Ref) {
+			refs++
+		}
+	}
+	if err := flush(); err != nil {
+		return fmt.Errorf("error adding IdentifierMatch to sorter: %v", err)
+	}
+	return nil
+}
+
+// identifierNames returns the qualified and simple names rendered from ms.  A
+// name without any qualifiers is its own qualified name.
+func identifierNames(ms *cpb.MarkedSource) (qname, base string) {
+	if ms == nil {
+		return "", ""
+	}
+	info := markedsource.RenderQualifiedName(ms)
+	qname = info.QualifiedName
+	if qname == "" {
+		qname = info.BaseName
+	}
+	return qname, info.BaseName
+}
+
+// nodeInfo returns the kind, subkind, and MarkedSource facts of the given node.
+func nodeInfo(n *srvpb.Node) (kind, subkind string, ms *cpb.MarkedSource, err error) {
+	for _, f := range n.Fact {
+		switch f.Name {
+		case facts.NodeKind:
+			kind = string(f.Value)
+		case facts.Subkind:
+			subkind = string(f.Value)
+		case facts.Code:
+			ms = new(cpb.MarkedSource)
+			if err := proto.Unmarshal(f.Value, ms); err != nil {
+				return "", "", nil, fmt.Errorf("error unmarshaling code for %q: %v", n.Ticket, err)
+			}
+		}
+	}
+	return kind, subkind, ms, nil
+}
+
 func e2e(e *srvpb.Edge) *srvpb.EdgeGroup_Edge {
 	return &srvpb.EdgeGroup_Edge{
 		Target:  e.Target,
@@ -410,6 +1035,12 @@ func writeDecorAndRefs(ctx context.Context, opts *Options, edges <-chan *srvpb.E
 		return fmt.Errorf("error creating sorter: %v", err)
 	}
 
+	// postingSorter stores a *srvpb.SearchPostings for each trigram of each file
+	postingSorter, err := opts.diskSorter(postingLesser{}, postingMarshaler{})
+	if err != nil {
+		return fmt.Errorf("error creating sorter: %v", err)
+	}
+
 	buffer := out.xs.Buffered()
 	var (
 		curFile string
@@ -427,6 +1058,8 @@ func writeDecorAndRefs(ctx context.Context, opts *Options, edges <-chan *srvpb.E
 			if decor.File != nil {
 				if err := writeDecor(ctx, buffer, decor, targets); err != nil {
 					return err
+				} else if err := writeSearchFile(ctx, buffer, postingSorter, decor); err != nil {
+					return err
 				}
 				file = nil
 			}
@@ -479,9 +1112,17 @@ func writeDecorAndRefs(ctx context.Context, opts *Options, edges <-chan *srvpb.E
 	if decor != nil && decor.File != nil {
 		if err := writeDecor(ctx, buffer, decor, targets); err != nil {
 			return err
+		} else if err := writeSearchFile(ctx, buffer, postingSorter, decor); err != nil {
+			return err
 		}
 	}
 
+	log.Println("Writing SearchPostings")
+
+	if err := writeSearchPostings(ctx, buffer, postingSorter); err != nil {
+		return err
+	}
+
 	log.Println("Writing CrossReferences")
 
 	xb := &assemble.CrossReferencesBuilder{
@@ -534,6 +1175,56 @@ func writeDecor(ctx context.Context, t table.BufferedProto, decor *srvpb.FileDec
 	return t.Put(ctx, xsrv.DecorationsKey(decor.File.Ticket), decor)
 }
 
+// writeSearchFile writes the search metadata for the decorated file and adds
+// a *srvpb.SearchPostings to postings for each of its trigrams.
+func writeSearchFile(ctx context.Context, t table.BufferedProto, postings disksort.Interface, decor *srvpb.FileDecorations) error {
+	ticket := decor.File.Ticket
+	for _, tri := range search.Trigrams(decor.File.Text) {
+		if err := postings.Add(&srvpb.SearchPostings{
+			Trigram:    []byte(tri),
+			FileTicket: []string{ticket},
+		}); err != nil {
+			return fmt.Errorf("error adding SearchPostings to sorter: %v", err)
+		}
+	}
+
+	langs := stringset.New()
+	for _, d := range decor.Decoration {
+		if uri, err := kytheuri.Parse(d.Anchor.Ticket); err == nil && uri.Language != "" {
+			langs.Add(uri.Language)
+		}
+	}
+	return t.Put(ctx, search.FileKey(ticket), &srvpb.SearchFile{
+		Ticket:   ticket,
+		Language: langs.Elements(),
+	})
+}
+
+// writeSearchPostings writes a *srvpb.SearchPostings for each trigram from the
+// sorted single-file postings.
+func writeSearchPostings(ctx context.Context, t table.BufferedProto, postings disksort.Interface) error {
+	var cur *srvpb.SearchPostings
+	if err := postings.Read(func(i interface{}) error {
+		p := i.(*srvpb.SearchPostings)
+		if cur != nil && bytes.Equal(cur.Trigram, p.Trigram) {
+			cur.FileTicket = append(cur.FileTicket, p.FileTicket...)
+			return nil
+		} else if cur != nil {
+			if err := t.Put(ctx, search.TrigramKey(string(cur.Trigram)), cur); err != nil {
+				return err
+			}
+		}
+		cur = p
+		return nil
+	}); err != nil {
+		return fmt.Errorf("error reading search postings: %v", err)
+	}
+	if cur != nil {
+		return t.Put(ctx, search.TrigramKey(string(cur.Trigram)), cur)
+	}
+	return nil
+}
+
 type edgeLesser struct{}
 
 func (edgeLesser) Less(a, b interface{}) bool {
@@ -562,6 +1253,32 @@ func (edgeMarshaler) Unmarshal(rec []byte) (interface{}, error) {
 	return &e, proto.Unmarshal(rec, &e)
 }
 
+type paramLesser struct{}
+
+func (paramLesser) Less(a, b interface{}) bool {
+	x, y := a.(*srvpb.FunctionParameters), b.(*srvpb.FunctionParameters)
+	if x.Ticket == y.Ticket {
+		if len(x.Parameter) == 0 || len(y.Parameter) == 0 {
+			return len(x.Parameter) < len(y.Parameter) // return types first
+		}
+		xp, yp := x.Parameter[0], y.Parameter[0]
+		if xp.Ordinal == yp.Ordinal {
+			return xp.Ticket < yp.Ticket
+		}
+		return xp.Ordinal < yp.Ordinal
+	}
+	return x.Ticket < y.Ticket
+}
+
+type paramMarshaler struct{}
+
+func (paramMarshaler) Marshal(x interface{}) ([]byte, error) { return proto.Marshal(x.(proto.Message)) }
+
+func (paramMarshaler) Unmarshal(rec []byte) (interface{}, error) {
+	var p srvpb.FunctionParameters
+	return &p, proto.Unmarshal(rec, &p)
+}
+
 type fragmentMarshaler struct{}
 
 func (fragmentMarshaler) Marshal(x interface{}) ([]byte, error) {
@@ -588,6 +1305,67 @@ func (fragmentMarshaler) Unmarshal(rec []byte) (interface{}, error) {
 	}, nil
 }
 
+type postingLesser struct{}
+
+func (postingLesser) Less(a, b interface{}) bool {
+	x, y := a.(*srvpb.SearchPostings), b.(*srvpb.SearchPostings)
+	if c := bytes.Compare(x.Trigram, y.Trigram); c != 0 {
+		return c < 0
+	}
+	return x.FileTicket[0] < y.FileTicket[0]
+}
+
+type postingMarshaler struct{}
+
+func (postingMarshaler) Marshal(x interface{}) ([]byte, error) {
+	return proto.Marshal(x.(proto.Message))
+}
+
+func (postingMarshaler) Unmarshal(rec []byte) (interface{}, error) {
+	var p srvpb.SearchPostings
+	return &p, proto.Unmarshal(rec, &p)
+}
+
+type identLesser struct{}
+
+func (identLesser) Less(a, b interface{}) bool {
+	x, y := a.(*srvpb.IdentifierMatch), b.(*srvpb.IdentifierMatch)
+	if x.QualifiedName == y.QualifiedName {
+		return x.Node[0].Ticket < y.Node[0].Ticket
+	}
+	return x.QualifiedName < y.QualifiedName
+}
+
+type identMarshaler struct{}
+
+func (identMarshaler) Marshal(x interface{}) ([]byte, error) { return proto.Marshal(x.(proto.Message)) }
+
+func (identMarshaler) Unmarshal(rec []byte) (interface{}, error) {
+	var m srvpb.IdentifierMatch
+	return &m, proto.Unmarshal(rec, &m)
+}
+
+type identPostingLesser struct{}
+
+func (identPostingLesser) Less(a, b interface{}) bool {
+	x, y := a.(*srvpb.IdentifierPostings), b.(*srvpb.IdentifierPostings)
+	if x.Key == y.Key {
+		return x.QualifiedName[0] < y.QualifiedName[0]
+	}
+	return x.Key < y.Key
+}
+
+type identPostingMarshaler struct{}
+
+func (identPostingMarshaler) Marshal(x interface{}) ([]byte, error) {
+	return proto.Marshal(x.(proto.Message))
+}
+
+func (identPostingMarshaler) Unmarshal(rec []byte) (interface{}, error) {
+	var p srvpb.IdentifierPostings
+	return &p, proto.Unmarshal(rec, &p)
+}
+
 type refMarshaler struct{}
 
 func (refMarshaler) Marshal(x interface{}) ([]byte, error) { return proto.Marshal(x.(proto.Message)) }
@@ -0,0 +1,463 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package search provides a trigram table-based implementation of the
+// SearchService defined in kythe/proto/search.proto.
+//
+// Table format:
+//   search:tri:<trigram>    -> srvpb.SearchPostings
+//   search:file:<ticket>    -> srvpb.SearchFile
+//
+// The text and references of each candidate file are read from an
+// xrefs.Service's Decorations.
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/span"
+
+	"bitbucket.org/creachadair/stringset"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	ipb "kythe.io/kythe/proto/internal_go_proto"
+	spb "kythe.io/kythe/proto/search_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// Key prefixes for the search tables within a combined serving table.
+const (
+	trigramTablePrefix = "search:tri:"
+	fileTablePrefix    = "search:file:"
+)
+
+// TrigramKey returns the combined table key for the postings of the given
+// trigram.
+func TrigramKey(trigram string) []byte { return []byte(trigramTablePrefix + trigram) }
+
+// FileKey returns the combined table key for the search metadata of the given
+// file ticket.
+func FileKey(ticket string) []byte { return []byte(fileTablePrefix + ticket) }
+
+// Trigrams returns the distinct trigrams of text in sorted order.  Text
+// containing a NUL byte is considered binary and has no trigrams.
+func Trigrams(text []byte) []string {
+	if bytes.IndexByte(text, 0) >= 0 {
+		return nil
+	}
+	set := stringset.New()
+	for i := 0; i+3 <= len(text); i++ {
+		set.Add(string(text[i : i+3]))
+	}
+	return set.Elements()
+}
+
+// DefaultPageSize is the default maximum number of hits returned by Search.
+const DefaultPageSize = 100
+
+// Table implements the search.Service interface using a combined serving
+// table holding the trigram postings and file metadata for each file.
+type Table struct {
+	table.ProtoLookup
+
+	// XRefs is used to read the text and references of each candidate file.
+	XRefs xrefs.Service
+}
+
+// Search implements part of the search.Service interface.
+func (t *Table) Search(ctx context.Context, req *spb.SearchRequest) (*spb.SearchReply, error) {
+	if req.Query == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing query")
+	}
+	pattern := req.Query
+	if !req.Regexp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	re, err := regexp.Compile("(?m)" + pattern)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+	trigrams, err := queryTrigrams(pattern)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	} else if len(trigrams) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "query must require a literal string of at least 3 bytes: %q", req.Query)
+	}
+	var pathRE *regexp.Regexp
+	if req.Path != "" {
+		pathRE, err = regexp.Compile(req.Path)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid path: %v", err)
+		}
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	var skip int
+	if req.PageToken != "" {
+		rec, err := base64.StdEncoding.DecodeString(req.PageToken)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", req.PageToken)
+		}
+		var token ipb.PageToken
+		if err := proto.Unmarshal(rec, &token); err != nil || token.Index < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", req.PageToken)
+		}
+		skip = int(token.Index)
+	}
+
+	files, err := t.candidates(ctx, trigrams)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &spb.SearchReply{}
+	var seen int
+	for _, ticket := range files {
+		if ok, err := t.matchesFilters(ctx, req, pathRE, ticket); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		decor, err := t.XRefs.Decorations(ctx, &xpb.DecorationsRequest{
+			Location:   &xpb.Location{Ticket: ticket},
+			SourceText: true,
+			References: true,
+		})
+		if status.Code(err) == codes.NotFound {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading decorations for %q: %v", ticket, err)
+		}
+
+		f := newFileMatcher(ticket, decor, int(req.ContextLines))
+		for _, m := range re.FindAllIndex(decor.SourceText, -1) {
+			if m[0] == m[1] {
+				continue // skip empty matches
+			}
+			seen++
+			if seen <= skip {
+				continue
+			} else if len(reply.Hit) == pageSize {
+				token, err := proto.Marshal(&ipb.PageToken{Index: int32(skip + pageSize)})
+				if err != nil {
+					return nil, fmt.Errorf("error marshaling page token: %v", err)
+				}
+				reply.NextPageToken = base64.StdEncoding.EncodeToString(token)
+				return reply, nil
+			}
+			reply.Hit = append(reply.Hit, f.hit(m[0], m[1]))
+		}
+	}
+	return reply, nil
+}
+
+// candidates returns the sorted tickets of the files containing at least one
+// of the alternatives for each of the given trigrams.
+func (t *Table) candidates(ctx context.Context, trigrams [][]string) ([]string, error) {
+	var postings [][]string
+	for _, alts := range trigrams {
+		var files []string
+		for _, tri := range alts {
+			var p srvpb.SearchPostings
+			if err := t.Lookup(ctx, TrigramKey(tri), &p); err == table.ErrNoSuchKey {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("error looking up postings for %q: %v", tri, err)
+			}
+			files = union(files, p.FileTicket)
+		}
+		if len(files) == 0 {
+			return nil, nil
+		}
+		postings = append(postings, files)
+	}
+	sort.Slice(postings, func(i, j int) bool { return len(postings[i]) < len(postings[j]) })
+
+	files := postings[0]
+	for _, p := range postings[1:] {
+		files = intersect(files, p)
+	}
+	return files, nil
+}
+
+// intersect returns the elements common to the sorted slices a and b.
+func intersect(a, b []string) []string {
+	var res []string
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			res = append(res, a[i])
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+// union returns the elements of either of the sorted slices a and b.
+func union(a, b []string) []string {
+	if len(a) == 0 {
+		return b
+	}
+	res := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			res = append(res, a[i])
+			i++
+		case a[i] > b[j]:
+			res = append(res, b[j])
+			j++
+		default:
+			res = append(res, a[i])
+			i++
+			j++
+		}
+	}
+	res = append(res, a[i:]...)
+	return append(res, b[j:]...)
+}
+
+// matchesFilters reports whether the file with the given ticket satisfies the
+// corpus, root, path, and language filters of req.
+func (t *Table) matchesFilters(ctx context.Context, req *spb.SearchRequest, pathRE *regexp.Regexp, ticket string) (bool, error) {
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil {
+		return false, fmt.Errorf("invalid file ticket %q: %v", ticket, err)
+	}
+	if len(req.Corpus) > 0 && !contains(req.Corpus, uri.Corpus) {
+		return false, nil
+	} else if len(req.Root) > 0 && !contains(req.Root, uri.Root) {
+		return false, nil
+	} else if pathRE != nil && !pathRE.MatchString(uri.Path) {
+		return false, nil
+	} else if len(req.Language) == 0 {
+		return true, nil
+	}
+
+	var file srvpb.SearchFile
+	if err := t.Lookup(ctx, FileKey(ticket), &file); err == table.ErrNoSuchKey {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error looking up search file %q: %v", ticket, err)
+	}
+	for _, lang := range file.Language {
+		if contains(req.Language, lang) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// A fileMatcher constructs the hits within a single file.
+type fileMatcher struct {
+	ticket       string
+	norm         *span.Normalizer
+	lines        [][]byte
+	contextLines int
+	refs         []*xpb.DecorationsReply_Reference
+}
+
+func newFileMatcher(ticket string, decor *xpb.DecorationsReply, contextLines int) *fileMatcher {
+	if contextLines < 0 {
+		contextLines = 0
+	}
+	return &fileMatcher{
+		ticket:       ticket,
+		norm:         span.NewNormalizer(decor.SourceText),
+		lines:        bytes.Split(decor.SourceText, []byte("\n")),
+		contextLines: contextLines,
+		refs:         decor.Reference,
+	}
+}
+
+// hit returns the Hit for the match at the byte offsets [start, end).
+func (f *fileMatcher) hit(start, end int) *spb.SearchReply_Hit {
+	h := &spb.SearchReply_Hit{
+		FileTicket: f.ticket,
+		Span: &cpb.Span{
+			Start: f.norm.ByteOffset(int32(start)),
+			End:   f.norm.ByteOffset(int32(end)),
+		},
+	}
+
+	// Line numbers are 1-based; a match ending with a newline doesn't include
+	// the following line.
+	first := int(h.Span.Start.LineNumber) - f.contextLines
+	last := int(f.norm.ByteOffset(int32(end-1)).LineNumber) + f.contextLines
+	if first < 1 {
+		first = 1
+	}
+	if last > len(f.lines) {
+		last = len(f.lines)
+	}
+	for n := first; n <= last; n++ {
+		h.Snippet = append(h.Snippet, &spb.SearchReply_Line{
+			LineNumber: int32(n),
+			Text:       string(f.lines[n-1]),
+		})
+	}
+
+	for _, ref := range f.refs {
+		if ref.Span.GetStart().GetByteOffset() < int32(end) && ref.Span.GetEnd().GetByteOffset() > int32(start) {
+			h.Reference = append(h.Reference, ref)
+		}
+	}
+	return h
+}
+
+// queryTrigrams returns the distinct trigrams of the literal strings required
+// by any match of the regular expression pattern.  Each element of the result
+// lists the variants of one trigram, at least one of which must appear in a
+// matching file; a trigram of a case-sensitive literal has only one variant.
+func queryTrigrams(pattern string) ([][]string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	set := stringset.New()
+	for _, lit := range requiredLiterals(re.Simplify()) {
+		for _, alts := range lit.trigrams() {
+			set.Add(strings.Join(alts, "\x00"))
+		}
+	}
+	var trigrams [][]string
+	for _, key := range set.Elements() {
+		trigrams = append(trigrams, strings.Split(key, "\x00"))
+	}
+	return trigrams, nil
+}
+
+// A literal is a string required by a regular expression.
+type literal struct {
+	text     string
+	foldCase bool // whether the literal matches case-insensitively
+}
+
+// trigrams returns the trigrams of the literal, each with its case variants.
+//
+// The index is case-sensitive, so the trigrams of a case-folded literal are
+// drawn only from runs of ASCII characters and each is expanded to all its
+// ASCII case variants.  Matches relying on a non-ASCII folding of an ASCII
+// letter, such as the Kelvin sign for "k", are not found.
+func (l literal) trigrams() [][]string {
+	if !l.foldCase {
+		var res [][]string
+		for _, tri := range Trigrams([]byte(l.text)) {
+			res = append(res, []string{tri})
+		}
+		return res
+	}
+
+	if strings.IndexByte(l.text, 0) >= 0 {
+		return nil // binary text has no trigrams
+	}
+	var res [][]string
+	var run [][]byte // the case variants of each byte in the current run
+	for _, r := range l.text + "\x00" { // the NUL ends the final run
+		if r == 0 || r >= utf8.RuneSelf {
+			for i := 0; i+3 <= len(run); i++ {
+				res = append(res, variants(run[i:i+3]))
+			}
+			run = run[:0]
+			continue
+		}
+		alts := []byte{byte(r)}
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < utf8.RuneSelf {
+				alts = append(alts, byte(f))
+			}
+		}
+		run = append(run, alts)
+	}
+	return res
+}
+
+// variants returns the strings formed by choosing one of the alternatives for
+// each byte position.
+func variants(pos [][]byte) []string {
+	res := []string{""}
+	for _, alts := range pos {
+		var next []string
+		for _, prefix := range res {
+			for _, b := range alts {
+				next = append(next, prefix+string(b))
+			}
+		}
+		res = next
+	}
+	sort.Strings(res)
+	return res
+}
+
+// requiredLiterals returns the literal strings that must appear in any text
+// matched by re.
+func requiredLiterals(re *syntax.Regexp) []literal {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []literal{{
+			text:     string(re.Rune),
+			foldCase: re.Flags&syntax.FoldCase != 0,
+		}}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		var lits []literal
+		for _, sub := range re.Sub {
+			lits = append(lits, requiredLiterals(sub)...)
+		}
+		return lits
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright 2019 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/test/testutil"
+	"kythe.io/kythe/go/util/span"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spb "kythe.io/kythe/proto/search_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+const (
+	mainFile = "kythe://corpus?path=main.go"
+	libFile  = "kythe://corpus?path=lib/lib.go"
+	docFile  = "kythe://other?path=README"
+)
+
+var testFiles = []struct {
+	ticket   string
+	text     string
+	language []string
+	refs     [][2]int32
+}{{
+	ticket:   mainFile,
+	text:     "package main\n\nfunc main() {\n\tlib.Hello()\n}\n",
+	language: []string{"go"},
+	refs:     [][2]int32{{19, 23}, {33, 38}},
+}, {
+	ticket:   libFile,
+	text:     "package lib\n\n// Hello says hello.\nfunc Hello() {}\n",
+	language: []string{"go"},
+	refs:     [][2]int32{{39, 44}},
+}, {
+	ticket: docFile,
+	text:   "Say Hello to the world.\n",
+}}
+
+func TestSearch(t *testing.T) {
+	tbl := newTestTable()
+	mainText, libText := []byte(testFiles[0].text), []byte(testFiles[1].text)
+
+	tests := []struct {
+		req  *spb.SearchRequest
+		hits []*spb.SearchReply_Hit
+	}{{
+		req: &spb.SearchRequest{Query: "Hello("},
+		hits: []*spb.SearchReply_Hit{
+			hit(libFile, libText, 39, 45, []int{4}, [2]int32{39, 44}),
+			hit(mainFile, mainText, 33, 39, []int{4}, [2]int32{33, 38}),
+		},
+	}, {
+		req: &spb.SearchRequest{Query: "Hello", Corpus: []string{"other"}},
+		hits: []*spb.SearchReply_Hit{
+			hit(docFile, []byte(testFiles[2].text), 4, 9, []int{1}),
+		},
+	}, {
+		req: &spb.SearchRequest{Query: "Hello", Language: []string{"go"}, Path: `^lib/`},
+		hits: []*spb.SearchReply_Hit{
+			hit(libFile, libText, 16, 21, []int{3}),
+			hit(libFile, libText, 39, 44, []int{4}, [2]int32{39, 44}),
+		},
+	}, {
+		req: &spb.SearchRequest{Query: `^func \w+\(`, Regexp: true, ContextLines: 1},
+		hits: []*spb.SearchReply_Hit{
+			hit(libFile, libText, 34, 45, []int{3, 4, 5}, [2]int32{39, 44}),
+			hit(mainFile, mainText, 14, 24, []int{2, 3, 4}, [2]int32{19, 23}),
+		},
+	}, {
+		req: &spb.SearchRequest{Query: "main() {\n\t", ContextLines: 1},
+		hits: []*spb.SearchReply_Hit{
+			hit(mainFile, mainText, 19, 29, []int{2, 3, 4, 5}, [2]int32{19, 23}),
+		},
+	}, {
+		req: &spb.SearchRequest{Query: `(?i)hello\b`, Regexp: true, Corpus: []string{"corpus"}},
+		hits: []*spb.SearchReply_Hit{
+			hit(libFile, libText, 16, 21, []int{3}),
+			hit(libFile, libText, 27, 32, []int{3}),
+			hit(libFile, libText, 39, 44, []int{4}, [2]int32{39, 44}),
+			hit(mainFile, mainText, 33, 38, []int{4}, [2]int32{33, 38}),
+		},
+	}, {
+		req: &spb.SearchRequest{Query: "Goodbye"},
+	}}
+
+	ctx := context.Background()
+	for _, test := range tests {
+		reply, err := tbl.Search(ctx, test.req)
+		if err != nil {
+			t.Errorf("Search(%v) error: %v", test.req, err)
+			continue
+		}
+		if err := testutil.DeepEqual(&spb.SearchReply{Hit: test.hits}, reply); err != nil {
+			t.Errorf("Search(%v): %v", test.req, err)
+		}
+	}
+}
+
+func TestSearchPaging(t *testing.T) {
+	tbl := newTestTable()
+	ctx := context.Background()
+
+	req := &spb.SearchRequest{Query: "ello", PageSize: 2}
+	var tickets []string
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("Too many pages: %v", tickets)
+		}
+		reply, err := tbl.Search(ctx, req)
+		if err != nil {
+			t.Fatalf("Search error: %v", err)
+		} else if len(reply.Hit) > 2 {
+			t.Fatalf("Too many hits on page: %v", reply.Hit)
+		}
+		for _, h := range reply.Hit {
+			tickets = append(tickets, h.FileTicket)
+		}
+		if reply.NextPageToken == "" {
+			break
+		}
+		req.PageToken = reply.NextPageToken
+	}
+
+	expected := []string{libFile, libFile, libFile, mainFile, docFile}
+	if err := testutil.DeepEqual(expected, tickets); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSearchInvalid(t *testing.T) {
+	tbl := newTestTable()
+	ctx := context.Background()
+
+	for _, req := range []*spb.SearchRequest{
+		{},
+		{Query: "He"},
+		{Query: "Hel+o|func", Regexp: true},
+		{Query: "Hello(", Regexp: true},
+		{Query: "Hello", Path: "("},
+		{Query: "Hello", PageToken: "!!"},
+	} {
+		if reply, err := tbl.Search(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Search(%v): expected InvalidArgument; found %v, %v", req, reply, err)
+		}
+	}
+}
+
+func TestQueryTrigrams(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		trigrams [][]string
+	}{
+		{"hello", [][]string{{"ell"}, {"hel"}, {"llo"}}},
+		{`func \w+\(`, [][]string{{"fun"}, {"nc "}, {"unc"}}},
+		{"(abc)+x?(def){2}", [][]string{{"abc"}, {"def"}}},
+		{"abc|def", nil},
+		{"ab.cd", nil},
+		{"a*bcd", [][]string{{"bcd"}}},
+		{"(?i)a1_", [][]string{{"A1_", "a1_"}}},
+		{"(?i)abé", nil},
+		{"(?i)ab-c", [][]string{{"AB-", "Ab-", "aB-", "ab-"}, {"B-C", "B-c", "b-C", "b-c"}}},
+	}
+
+	for _, test := range tests {
+		trigrams, err := queryTrigrams(test.pattern)
+		if err != nil {
+			t.Errorf("queryTrigrams(%q) error: %v", test.pattern, err)
+		} else if err := testutil.DeepEqual(test.trigrams, trigrams); err != nil {
+			t.Errorf("queryTrigrams(%q): %v", test.pattern, err)
+		}
+	}
+}
+
+func TestTrigrams(t *testing.T) {
+	if err := testutil.DeepEqual([]string{"aba", "bab"}, Trigrams([]byte("ababab"))); err != nil {
+		t.Error(err)
+	}
+	if trigrams := Trigrams([]byte("binary\x00data")); trigrams != nil {
+		t.Errorf("Expected no trigrams for binary text; found %v", trigrams)
+	}
+}
+
+func hit(ticket string, text []byte, start, end int32, lines []int, refs ...[2]int32) *spb.SearchReply_Hit {
+	norm := span.NewNormalizer(text)
+	h := &spb.SearchReply_Hit{
+		FileTicket: ticket,
+		Span:       norm.SpanOffsets(start, end),
+	}
+	textLines := strings.Split(string(text), "\n")
+	for _, n := range lines {
+		h.Snippet = append(h.Snippet, &spb.SearchReply_Line{LineNumber: int32(n), Text: textLines[n-1]})
+	}
+	for _, r := range refs {
+		h.Reference = append(h.Reference, ref(norm, r))
+	}
+	return h
+}
+
+func ref(norm *span.Normalizer, r [2]int32) *xpb.DecorationsReply_Reference {
+	return &xpb.DecorationsReply_Reference{
+		TargetTicket: "kythe://corpus?lang=go#target",
+		Kind:         "/kythe/edge/ref",
+		Span:         norm.SpanOffsets(r[0], r[1]),
+	}
+}
+
+func newTestTable() *Table {
+	tbl := testProtoTable{}
+	decor := testDecorations{}
+	postings := make(map[string]*srvpb.SearchPostings)
+	for _, f := range testFiles {
+		text := []byte(f.text)
+		for _, tri := range Trigrams(text) {
+			p, ok := postings[tri]
+			if !ok {
+				p = &srvpb.SearchPostings{Trigram: []byte(tri)}
+				postings[tri] = p
+				tbl[string(TrigramKey(tri))] = p
+			}
+			p.FileTicket = append(p.FileTicket, f.ticket)
+		}
+		if len(f.language) > 0 {
+			tbl[string(FileKey(f.ticket))] = &srvpb.SearchFile{Ticket: f.ticket, Language: f.language}
+		}
+
+		norm := span.NewNormalizer(text)
+		reply := &xpb.DecorationsReply{
+			Location:   &xpb.Location{Ticket: f.ticket},
+			SourceText: text,
+		}
+		for _, r := range f.refs {
+			reply.Reference = append(reply.Reference, ref(norm, r))
+		}
+		decor[f.ticket] = reply
+	}
+	for _, p := range postings {
+		sort.Strings(p.FileTicket)
+	}
+	return &Table{ProtoLookup: tbl, XRefs: decor}
+}
+
+type testDecorations map[string]*xpb.DecorationsReply
+
+// Decorations implements part of the xrefs.Service interface.
+func (d testDecorations) Decorations(_ context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	reply, ok := d[req.GetLocation().GetTicket()]
+	if !ok {
+		return nil, xrefs.ErrDecorationsNotFound
+	}
+	return reply, nil
+}
+
+// CrossReferences implements part of the xrefs.Service interface.
+func (d testDecorations) CrossReferences(context.Context, *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	return nil, status.Error(codes.Unimplemented, "CrossReferences")
+}
+
+// Documentation implements part of the xrefs.Service interface.
+func (d testDecorations) Documentation(context.Context, *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	return nil, status.Error(codes.Unimplemented, "Documentation")
+}
+
+type testProtoTable map[string]proto.Message
+
+func (t testProtoTable) Lookup(_ context.Context, key []byte, msg proto.Message) error {
+	m, ok := t[string(key)]
+	if !ok {
+		return table.ErrNoSuchKey
+	}
+	proto.Merge(msg, m)
+	return nil
+}
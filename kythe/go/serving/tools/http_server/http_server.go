@@ -14,32 +14,47 @@
  * limitations under the License.
  */
 
-// Binary http_server exposes HTTP interfaces for the xrefs and filetree
-// services backed by a combined serving table.
+// Binary http_server exposes HTTP and gRPC interfaces for the xrefs, graph,
+// filetree, identifiers, explore, link, and search services backed by a
+// combined serving table.
 package main
 
 import (
 	"context"
 	"flag"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 
+	"kythe.io/kythe/go/services/explore"
 	"kythe.io/kythe/go/services/filetree"
 	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/link"
+	"kythe.io/kythe/go/services/search"
 	"kythe.io/kythe/go/services/xrefs"
+	esrv "kythe.io/kythe/go/serving/explore"
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/identifiers"
+	ssrv "kythe.io/kythe/go/serving/search"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/util/flagutil"
 
 	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
 
 	_ "kythe.io/kythe/go/services/graphstore/proxy"
 	_ "kythe.io/kythe/go/storage/leveldb"
+
+	ftpb "kythe.io/kythe/proto/filetree_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
+	spb "kythe.io/kythe/proto/search_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
 )
 
 var (
@@ -53,20 +68,25 @@ var (
 	tlsCertFile      = flag.String("tls_cert_file", "", "Path to file with concatenation of TLS certificates")
 	tlsKeyFile       = flag.String("tls_key_file", "", "Path to file with TLS private key")
 
+	grpcListeningAddr = flag.String("grpc_listen", "", "Listening address for gRPC server")
+
 	maxTicketsPerRequest = flag.Int("max_tickets_per_request", 20, "Maximum number of tickets allowed per request")
+
+	maxSearchPageSize     = flag.Int("max_search_page_size", 1000, "Maximum number of hits allowed per search request")
+	maxSearchContextLines = flag.Int("max_search_context_lines", 20, "Maximum number of context lines allowed per search request")
 )
 
 func init() {
-	flag.Usage = flagutil.SimpleUsage("Exposes HTTP interfaces for the xrefs and filetree services",
-		"(--graphstore spec | --serving_table path) [--listen addr] [--public_resources dir]")
+	flag.Usage = flagutil.SimpleUsage("Exposes HTTP and gRPC interfaces for the xrefs, graph, filetree, identifiers, explore, link, and search services",
+		"(--graphstore spec | --serving_table path) [--listen addr] [--grpc_listen addr] [--public_resources dir]")
 }
 
 func main() {
 	flag.Parse()
 	if *servingTable == "" {
 		flagutil.UsageError("missing --serving_table")
-	} else if *httpListeningAddr == "" && *tlsListeningAddr == "" {
-		flagutil.UsageError("missing either --listen or --tls_listen argument")
+	} else if *httpListeningAddr == "" && *tlsListeningAddr == "" && *grpcListeningAddr == "" {
+		flagutil.UsageError("missing one of --listen, --tls_listen, or --grpc_listen arguments")
 	} else if *tlsListeningAddr != "" && (*tlsCertFile == "" || *tlsKeyFile == "") {
 		flagutil.UsageError("--tls_cert_file and --tls_key_file are required if given --tls_listen")
 	} else if flag.NArg() > 0 {
@@ -77,6 +97,9 @@ func main() {
 		xs xrefs.Service
 		gs graph.Service
 		ft filetree.Service
+		id identifiers.Service
+		es explore.Service
+		ss search.Service
 	)
 
 	ctx := context.Background()
@@ -85,8 +108,10 @@ func main() {
 		log.Fatalf("Error opening db at %q: %v", *servingTable, err)
 	}
 	defer db.Close(ctx)
+	tbl := &table.KVProto{db}
 	xs = xsrv.NewService(ctx, db)
 	gs = gsrv.NewService(ctx, db)
+	es = esrv.NewCombinedTable(tbl)
 	if *maxTicketsPerRequest > 0 {
 		xs = xrefs.BoundedRequests{
 			Service:    xs,
@@ -96,9 +121,19 @@ func main() {
 			Service:    gs,
 			MaxTickets: *maxTicketsPerRequest,
 		}
+		es = explore.BoundedRequests{
+			Service:    es,
+			MaxTickets: *maxTicketsPerRequest,
+		}
 	}
-	tbl := &table.KVProto{db}
 	ft = &ftsrv.Table{Proto: tbl, PrefixedKeys: true}
+	id = &identifiers.Table{Proto: tbl, PrefixedKeys: true}
+	resolver := &link.Resolver{Client: linkClient{xs, id}}
+	ss = search.BoundedRequests{
+		Service:         &ssrv.Table{ProtoLookup: tbl, XRefs: xs},
+		MaxPageSize:     *maxSearchPageSize,
+		MaxContextLines: *maxSearchContextLines,
+	}
 
 	if *httpListeningAddr != "" || *tlsListeningAddr != "" {
 		apiMux := http.NewServeMux()
@@ -112,6 +147,10 @@ func main() {
 		xrefs.RegisterHTTPHandlers(ctx, xs, apiMux)
 		graph.RegisterHTTPHandlers(ctx, gs, apiMux)
 		filetree.RegisterHTTPHandlers(ctx, ft, apiMux)
+		identifiers.RegisterHTTPHandlers(ctx, id, apiMux)
+		explore.RegisterHTTPHandlers(ctx, es, apiMux)
+		link.RegisterHTTPHandlers(ctx, resolver, apiMux)
+		search.RegisterHTTPHandlers(ctx, ss, apiMux)
 		if *publicResources != "" {
 			log.Println("Serving public resources at", *publicResources)
 			if s, err := os.Stat(*publicResources); err != nil {
@@ -130,10 +169,31 @@ func main() {
 	if *tlsListeningAddr != "" {
 		go startTLS()
 	}
+	if *grpcListeningAddr != "" {
+		srv := grpc.NewServer()
+		xpb.RegisterXRefServiceServer(srv, xs)
+		gpb.RegisterGraphServiceServer(srv, gs)
+		ftpb.RegisterFileTreeServiceServer(srv, ft)
+		ipb.RegisterIdentifierServiceServer(srv, id)
+		spb.RegisterSearchServiceServer(srv, ss)
+		go startGRPC(srv)
+	}
 
 	select {} // block forever
 }
 
+// linkClient combines the xrefs and identifiers services used by a
+// link.Resolver.
+type linkClient struct {
+	xrefs.Service
+	id identifiers.Service
+}
+
+// Find implements part of the link.Resolver Client interface.
+func (c linkClient) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	return c.id.Find(ctx, req)
+}
+
 func startHTTP() {
 	log.Printf("HTTP server listening on %q", *httpListeningAddr)
 	log.Fatal(http.ListenAndServe(*httpListeningAddr, nil))
@@ -146,3 +206,12 @@ func startTLS() {
 	log.Printf("TLS HTTP2 server listening on %q", *tlsListeningAddr)
 	log.Fatal(srv.ListenAndServeTLS(*tlsCertFile, *tlsKeyFile))
 }
+
+func startGRPC(srv *grpc.Server) {
+	l, err := net.Listen("tcp", *grpcListeningAddr)
+	if err != nil {
+		log.Fatalf("Error listening on %q: %v", *grpcListeningAddr, err)
+	}
+	log.Printf("gRPC server listening on %q", *grpcListeningAddr)
+	log.Fatal(srv.Serve(l))
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"time"
 
@@ -49,7 +50,8 @@ var (
 	gs          graphstore.Service
 	entriesFile = flag.String("entries", "",
 		"In non-beam mode: path to GraphStore-ordered entries file (mutually exclusive with --graphstore).\n"+
-			"In beam mode: path to an unordered entries file, or if ending with slash, a directory containing such files.")
+			"In beam mode: path to an unordered entries file, or if ending with slash, a directory containing such files.\n"+
+			"In incremental mode: path to an unordered entries file to add to the table.")
 
 	tablePath = flag.String("out", "", "Directory path to output serving table")
 
@@ -66,13 +68,18 @@ var (
 	beamShards               = flag.Int("beam_shards", 0, "Number of shards for beam processing. If non-positive, a reasonable default will be chosen.")
 	experimentalColumnarData = flag.Bool("experimental_beam_columnar_data", false, "Whether to emit columnar data from the Beam pipeline implementation")
 	compactTable             = flag.Bool("compact_table", false, "Whether to compact the output LevelDB after its creation")
+
+	incremental = flag.Bool("incremental", false,
+		"Whether to update the existing table at --out rather than rebuilding it.  The entries of --entries are added to the table and those of --removed_entries are removed.")
+	removedEntriesFile = flag.String("removed_entries", "", "In incremental mode: path to an unordered entries file to remove from the table")
 )
 
 func init() {
 	gsutil.Flag(&gs, "graphstore", "GraphStore to read (mutually exclusive with --entries)")
 	flag.Usage = flagutil.SimpleUsage(
 		"Creates a combined xrefs/filetree/search serving table based on a given GraphStore or stream of GraphStore-ordered entries",
-		"(--graphstore spec | --entries path) --out path")
+		"(--graphstore spec | --entries path) --out path",
+		"--incremental [--entries path] [--removed_entries path] --out path")
 }
 
 func main() {
@@ -91,11 +98,20 @@ func main() {
 		return
 	}
 
-	if gs == nil && *entriesFile == "" {
+	if *incremental {
+		if gs != nil {
+			flagutil.UsageError("--graphstore input not supported with --incremental")
+		} else if *entriesFile == "" && *removedEntriesFile == "" {
+			flagutil.UsageError("missing --entries or --removed_entries")
+		}
+	} else if *removedEntriesFile != "" {
+		flagutil.UsageError("--removed_entries requires --incremental")
+	} else if gs == nil && *entriesFile == "" {
 		flagutil.UsageError("missing --graphstore or --entries")
 	} else if gs != nil && *entriesFile != "" {
 		flagutil.UsageError("--graphstore and --entries are mutually exclusive")
-	} else if *tablePath == "" {
+	}
+	if *tablePath == "" {
 		flagutil.UsageError("missing required --out flag")
 	}
 
@@ -110,28 +126,40 @@ func main() {
 	}
 	defer profile.Stop()
 
-	var rd stream.EntryReader
-	if gs != nil {
-		rd = func(f func(e *spb.Entry) error) error {
-			defer gs.Close(ctx)
-			return gs.Scan(ctx, &spb.ScanRequest{}, f)
-		}
-	} else {
-		f, err := vfs.Open(ctx, *entriesFile)
-		if err != nil {
-			log.Fatalf("Error opening %q: %v", *entriesFile, err)
-		}
-		defer f.Close()
-		rd = stream.NewReader(f)
-	}
-
-	if err := pipeline.Run(ctx, rd, db, &pipeline.Options{
+	opts := &pipeline.Options{
 		Verbose:        *verbose,
 		MaxPageSize:    *maxPageSize,
 		CompressShards: *compressShards,
 		MaxShardSize:   *maxShardSize,
-	}); err != nil {
-		log.Fatal("FATAL ERROR: ", err)
+	}
+
+	if *incremental {
+		delta := &pipeline.Delta{
+			Added:   openEntries(ctx, *entriesFile),
+			Removed: openEntries(ctx, *removedEntriesFile),
+		}
+		if err := pipeline.Update(ctx, db, delta, opts); err != nil {
+			log.Fatal("FATAL ERROR: ", err)
+		}
+	} else {
+		var rd stream.EntryReader
+		if gs != nil {
+			rd = func(f func(e *spb.Entry) error) error {
+				defer gs.Close(ctx)
+				return gs.Scan(ctx, &spb.ScanRequest{}, f)
+			}
+		} else {
+			f, err := vfs.Open(ctx, *entriesFile)
+			if err != nil {
+				log.Fatalf("Error opening %q: %v", *entriesFile, err)
+			}
+			defer f.Close()
+			rd = stream.NewReader(f)
+		}
+
+		if err := pipeline.Run(ctx, rd, db, opts); err != nil {
+			log.Fatal("FATAL ERROR: ", err)
+		}
 	}
 
 	if *compactTable {
@@ -141,6 +169,22 @@ func main() {
 	}
 }
 
+// openEntries returns a reader for the entries file at path, or nil if path is
+// empty.  The file is read each time the reader is called.
+func openEntries(ctx context.Context, path string) stream.EntryReader {
+	if path == "" {
+		return nil
+	}
+	return func(f func(*spb.Entry) error) error {
+		file, err := vfs.Open(ctx, path)
+		if err != nil {
+			return fmt.Errorf("error opening %q: %v", path, err)
+		}
+		defer file.Close()
+		return stream.NewReader(file)(f)
+	}
+}
+
 func compactLevelDB(path string) error {
 	defer func(start time.Time) { log.Printf("Compaction completed in %s", time.Since(start)) }(time.Now())
 	return leveldb.CompactRange(*tablePath, nil)
@@ -153,6 +197,8 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 
 	if gs != nil {
 		return errors.New("--graphstore input not supported with --experimental_beam_pipeline")
+	} else if *incremental {
+		return errors.New("--incremental not supported with --experimental_beam_pipeline")
 	} else if *entriesFile == "" {
 		return errors.New("--entries file path required")
 	} else if *tablePath == "" {
@@ -165,11 +211,17 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 		log.Fatal("Error reading entries: ", err)
 	}
 	k := pipeline.FromEntries(s, entries)
+	k.SetMaxPageSize(*maxPageSize)
 	shards := *beamShards
 	if shards <= 0 {
 		// TODO(schroederc): better determine number of shards
 		shards = 128
 	}
+	supertypes, subtypes := k.TypeHierarchy()
+	parents, children := k.Relatives()
+	callers, callees := k.Callgraph()
+	postings, searchFiles := k.SearchIndex()
+	idents, identPostings := k.Identifiers()
 	if *experimentalColumnarData {
 		beamio.WriteLevelDB(s, *tablePath, shards,
 			createColumnarMetadata(s),
@@ -179,6 +231,12 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 			k.Directories(),
 			k.Documents(),
 			k.SplitEdges(),
+			supertypes, subtypes,
+			k.Parameters(),
+			parents, children,
+			callers, callees,
+			postings, searchFiles,
+			idents, identPostings,
 		)
 	} else {
 		edgeSets, edgePages := k.Edges()
@@ -190,6 +248,12 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 			k.Documents(),
 			xrefSets, xrefPages,
 			edgeSets, edgePages,
+			supertypes, subtypes,
+			k.Parameters(),
+			parents, children,
+			callers, callees,
+			postings, searchFiles,
+			idents, identPostings,
 		)
 	}
 
@@ -251,6 +251,17 @@ func (w kvWriter) Write(key, val []byte) error {
 	return nil
 }
 
+// Delete implements part of the keyvalue.Deleter interface.
+func (w kvWriter) Delete(key []byte) error {
+	k := string(key)
+	i := sort.Search(len(w.db.keys), func(i int) bool { return strings.Compare(w.db.keys[i], k) >= 0 })
+	if i < len(w.db.keys) && w.db.keys[i] == k {
+		w.db.keys = append(w.db.keys[:i], w.db.keys[i+1:]...)
+	}
+	delete(w.db.db, k)
+	return nil
+}
+
 // Close implements part of the keyvalue.Writer interface.
 func (w kvWriter) Close() error {
 	w.db.mu.Unlock()
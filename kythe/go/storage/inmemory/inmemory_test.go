@@ -77,6 +77,52 @@ func TestKeyValueDB_overwrite(t *testing.T) {
 
 type entry struct{ Key, Value string }
 
+func TestKeyValueDB_delete(t *testing.T) {
+	db := NewKeyValueDB()
+
+	write(t, db, "a", "1")
+	write(t, db, "b", "2")
+	write(t, db, "c", "3")
+
+	w, err := db.Writer(ctx)
+	if err != nil {
+		t.Fatalf("Writer error: %v", err)
+	}
+	d := w.(keyvalue.Deleter)
+	if err := d.Delete([]byte("b")); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	} else if err := d.Delete([]byte("nonExistent")); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	} else if err := d.Close(); err != nil {
+		t.Fatalf("Write close error: %v", err)
+	}
+
+	if val, err := db.Get(ctx, []byte("b"), nil); err != io.EOF {
+		t.Errorf("Found deleted value: %q (err: %v)", val, err)
+	}
+
+	it, err := db.ScanPrefix(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("ScanPrefix error: %v", err)
+	}
+	var found []entry
+	for {
+		k, v, err := it.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Iterator error: %v", err)
+		}
+		found = append(found, entry{string(k), string(v)})
+	}
+	if err := it.Close(); err != nil {
+		t.Fatalf("Iterator close error: %v", err)
+	}
+	if diff := cmp.Diff([]entry{{"a", "1"}, {"c", "3"}}, found); diff != "" {
+		t.Errorf("Unexpected entries after delete: (- expected; + found)\n%s", diff)
+	}
+}
+
 func TestKeyValueDB_scanPrefix(t *testing.T) {
 	db := NewKeyValueDB()
 
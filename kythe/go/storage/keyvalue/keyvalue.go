@@ -142,6 +142,15 @@ type Writer interface {
 	Write(key, val []byte) error
 }
 
+// A Deleter is a Writer that can also remove entries from the DB.
+type Deleter interface {
+	Writer
+
+	// Delete removes the entry with the given key from the DB, if it exists.
+	// Deletes may be batched, along with writes, until the Writer is Closed.
+	Delete(key []byte) error
+}
+
 // WritePool is a wrapper around a DB that automatically creates and flushes
 // Writers as data size is written, creating a simple buffered interface for
 // writing to a DB.  This interface is not thread-safe.
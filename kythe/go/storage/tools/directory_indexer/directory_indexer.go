@@ -57,7 +57,7 @@ import (
 	"regexp"
 	"strings"
 
-	"kythe.io/kythe/go/platform/delimited"
+	"kythe.io/kythe/go/platform/analysis/sink"
 	"kythe.io/kythe/go/platform/vfs"
 	"kythe.io/kythe/go/util/flagutil"
 	"kythe.io/kythe/go/util/vnameutil"
@@ -75,6 +75,7 @@ var (
 	exclude          = flag.String("exclude", "", "Comma-separated list of exclude regexp patterns")
 	verbose          = flag.Bool("verbose", false, "Print verbose logging")
 	emitIrregular    = flag.Bool("emit_irregular", false, "Emit nodes for irregular files")
+	sinkFlags        = sink.DefineFlags()
 )
 
 var (
@@ -84,10 +85,10 @@ var (
 	fileKind = []byte("file")
 )
 
-var w = delimited.NewWriter(os.Stdout)
+var w *sink.Writer
 
 func emitEntry(v *spb.VName, label string, value []byte) error {
-	return w.PutProto(&spb.Entry{Source: v, FactName: label, FactValue: value})
+	return w.Put(context.Background(), &spb.Entry{Source: v, FactName: label, FactValue: value})
 }
 
 var (
@@ -152,9 +153,16 @@ func main() {
 		dirs = []string{"."}
 	}
 
+	var err error
+	if w, err = sinkFlags.Create(os.Stdout); err != nil {
+		log.Fatalf("Error creating output: %v", err)
+	}
 	for _, dir := range dirs {
 		if err := filepath.Walk(dir, emitPath); err != nil {
 			log.Fatalf("Error walking %s: %v", dir, err)
 		}
 	}
+	if err := w.Close(); err != nil {
+		log.Fatalf("Error writing output: %v", err)
+	}
 }
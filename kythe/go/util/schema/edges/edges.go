@@ -30,6 +30,7 @@ const Prefix = schema.Prefix + "edge/"
 
 // Edge kind labels
 const (
+	Aliases                 = Prefix + "aliases"
 	ChildOf                 = Prefix + "childof"
 	Extends                 = Prefix + "extends"
 	ExtendsPrivate          = Prefix + "extends/private"
@@ -40,6 +41,7 @@ const (
 	ExtendsPublicVirtual    = Prefix + "extends/public/virtual"
 	ExtendsVirtual          = Prefix + "extends/virtual"
 	Generates               = Prefix + "generates"
+	Influences              = Prefix + "influences"
 	Named                   = Prefix + "named"
 	Overrides               = Prefix + "overrides"
 	Param                   = Prefix + "param"
@@ -277,6 +277,8 @@ func (m *Tickets) GetTickets() []string {
 type TypeHierarchyRequest struct {
 	TypeTicket           string      `protobuf:"bytes,1,opt,name=type_ticket,json=typeTicket,proto3" json:"type_ticket,omitempty"`
 	NodeFilter           *NodeFilter `protobuf:"bytes,2,opt,name=node_filter,json=nodeFilter,proto3" json:"node_filter,omitempty"`
+	MaxDepth             int32       `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	MaxFanOut            int32       `protobuf:"varint,4,opt,name=max_fan_out,json=maxFanOut,proto3" json:"max_fan_out,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -321,6 +323,20 @@ func (m *TypeHierarchyRequest) GetNodeFilter() *NodeFilter {
 	return nil
 }
 
+func (m *TypeHierarchyRequest) GetMaxDepth() int32 {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return 0
+}
+
+func (m *TypeHierarchyRequest) GetMaxFanOut() int32 {
+	if m != nil {
+		return m.MaxFanOut
+	}
+	return 0
+}
+
 type TypeHierarchyReply struct {
 	TypeTicket           string   `protobuf:"bytes,1,opt,name=type_ticket,json=typeTicket,proto3" json:"type_ticket,omitempty"`
 	Graph                *Graph   `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
@@ -567,6 +583,7 @@ type ParametersReply struct {
 	FunctionToParameters  map[string]*Tickets  `protobuf:"bytes,1,rep,name=function_to_parameters,json=functionToParameters,proto3" json:"function_to_parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	FunctionToReturnValue map[string]string    `protobuf:"bytes,2,rep,name=function_to_return_value,json=functionToReturnValue,proto3" json:"function_to_return_value,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	NodeData              map[string]*NodeData `protobuf:"bytes,3,rep,name=node_data,json=nodeData,proto3" json:"node_data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ParameterToType       map[string]string    `protobuf:"bytes,4,rep,name=parameter_to_type,json=parameterToType,proto3" json:"parameter_to_type,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral  struct{}             `json:"-"`
 	XXX_unrecognized      []byte               `json:"-"`
 	XXX_sizecache         int32                `json:"-"`
@@ -618,6 +635,13 @@ func (m *ParametersReply) GetNodeData() map[string]*NodeData {
 	return nil
 }
 
+func (m *ParametersReply) GetParameterToType() map[string]string {
+	if m != nil {
+		return m.ParameterToType
+	}
+	return nil
+}
+
 type ParentsRequest struct {
 	Tickets              []string `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -792,6 +816,7 @@ func init() {
 	proto.RegisterMapType((map[string]*Tickets)(nil), "kythe.proto.ParametersReply.FunctionToParametersEntry")
 	proto.RegisterMapType((map[string]string)(nil), "kythe.proto.ParametersReply.FunctionToReturnValueEntry")
 	proto.RegisterMapType((map[string]*NodeData)(nil), "kythe.proto.ParametersReply.NodeDataEntry")
+	proto.RegisterMapType((map[string]string)(nil), "kythe.proto.ParametersReply.ParameterToTypeEntry")
 	proto.RegisterType((*ParentsRequest)(nil), "kythe.proto.ParentsRequest")
 	proto.RegisterType((*ParentsReply)(nil), "kythe.proto.ParentsReply")
 	proto.RegisterMapType((map[string]*Tickets)(nil), "kythe.proto.ParentsReply.InputToParentsEntry")
@@ -803,65 +828,70 @@ func init() {
 func init() { proto.RegisterFile("kythe/proto/explore.proto", fileDescriptor_ee5b2ef3873ea484) }
 
 var fileDescriptor_ee5b2ef3873ea484 = []byte{
-	// 958 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcf, 0x73, 0xdb, 0x54,
-	0x10, 0x8e, 0xea, 0x98, 0xc4, 0xeb, 0xc4, 0x71, 0x1e, 0x6e, 0x70, 0x44, 0x69, 0x8c, 0xb8, 0x98,
-	0x84, 0x3a, 0x33, 0x0e, 0x3f, 0x02, 0x07, 0x66, 0x20, 0x34, 0x2d, 0x33, 0xa1, 0x93, 0x51, 0x4b,
-	0xcb, 0xc0, 0x30, 0x1a, 0x55, 0x5a, 0xdb, 0x1a, 0xcb, 0x7a, 0xea, 0x93, 0x94, 0xa9, 0xcf, 0xdc,
-	0xf9, 0x77, 0xb8, 0x72, 0xe2, 0xc4, 0x81, 0x3f, 0x89, 0x79, 0x3f, 0x24, 0xeb, 0x39, 0x72, 0x9a,
-	0x4e, 0x4f, 0x91, 0x76, 0xbf, 0xfd, 0xbe, 0xf7, 0xed, 0x3e, 0x6d, 0x0c, 0xfb, 0xd3, 0x79, 0x3a,
-	0xc1, 0xe3, 0x98, 0xd1, 0x94, 0x1e, 0xe3, 0xeb, 0x38, 0xa4, 0x0c, 0x07, 0xe2, 0x8d, 0x34, 0x45,
-	0x4a, 0xbe, 0x98, 0xdd, 0x32, 0xce, 0xa3, 0xb3, 0x19, 0x8d, 0x54, 0x46, 0x63, 0x48, 0x52, 0xca,
-	0xdc, 0x71, 0x5e, 0xb4, 0x57, 0x4e, 0xbd, 0x66, 0x38, 0x92, 0x71, 0xeb, 0x3f, 0x03, 0x36, 0x9f,
-	0x50, 0x1f, 0x7f, 0x70, 0x53, 0x97, 0x10, 0x58, 0x9f, 0x06, 0x91, 0xdf, 0x35, 0x7a, 0x46, 0xbf,
-	0x61, 0x8b, 0x67, 0xd2, 0x85, 0x8d, 0x24, 0x7b, 0x29, 0xc2, 0x77, 0x44, 0x38, 0x7f, 0x25, 0x27,
-	0xd0, 0x08, 0xa9, 0xe7, 0xa6, 0x01, 0x8d, 0x92, 0x6e, 0xad, 0x57, 0xeb, 0x37, 0x87, 0x77, 0x07,
-	0xa5, 0x83, 0x0e, 0x2e, 0x54, 0xd6, 0x5e, 0xe0, 0xc8, 0x11, 0xec, 0xfa, 0x38, 0x0a, 0xa2, 0x80,
-	0xbf, 0x3a, 0x6e, 0xe4, 0x4d, 0x28, 0xeb, 0xae, 0x0b, 0xe2, 0xf6, 0x22, 0xf1, 0x9d, 0x88, 0x93,
-	0xcf, 0x61, 0xdd, 0xa3, 0x3e, 0x76, 0xeb, 0x3d, 0xa3, 0xdf, 0x1c, 0xf6, 0x34, 0x72, 0x65, 0xfc,
-	0x27, 0x97, 0x4d, 0xd1, 0x7f, 0x4a, 0x33, 0xe6, 0xa1, 0x2d, 0xd0, 0xd6, 0x1f, 0x06, 0x34, 0x1e,
-	0x31, 0x37, 0x9e, 0x70, 0x5f, 0x64, 0x08, 0x8d, 0x88, 0xfa, 0xe8, 0xf8, 0x6e, 0xea, 0x0a, 0x63,
-	0xcb, 0xa7, 0xcc, 0xdd, 0xdb, 0x9b, 0x51, 0xde, 0x07, 0x0b, 0xb6, 0x62, 0x86, 0x3e, 0x7a, 0x98,
-	0x24, 0x94, 0x25, 0xdd, 0x3b, 0xbd, 0x5a, 0xbf, 0x61, 0x6b, 0x31, 0x72, 0x1f, 0x20, 0xc9, 0xbc,
-	0x1c, 0x51, 0x13, 0x88, 0x52, 0xc4, 0xfa, 0xd3, 0x80, 0xba, 0x38, 0x05, 0x39, 0x81, 0x3a, 0x67,
-	0x4e, 0xba, 0x86, 0xe8, 0xd1, 0x47, 0x9a, 0xba, 0x80, 0x88, 0x33, 0x24, 0x0f, 0xa3, 0x94, 0xcd,
-	0x6d, 0x89, 0x35, 0x2f, 0x01, 0x16, 0x41, 0xd2, 0x86, 0xda, 0x14, 0xe7, 0x6a, 0x2e, 0xfc, 0x91,
-	0x7c, 0x06, 0xf5, 0x2b, 0x37, 0xcc, 0x50, 0x0c, 0xa5, 0x39, 0xdc, 0xbb, 0x4e, 0xca, 0xcb, 0x6d,
-	0x09, 0xfa, 0xe6, 0xce, 0xa9, 0x61, 0x5d, 0x49, 0xc6, 0xf3, 0x20, 0x4c, 0x91, 0x91, 0x07, 0x40,
-	0x82, 0xc8, 0x0b, 0x33, 0x1f, 0x7d, 0x27, 0x74, 0xa3, 0x71, 0xe6, 0x8e, 0xd5, 0x09, 0x1b, 0xf6,
-	0x6e, 0x9e, 0xb9, 0xc8, 0x13, 0xe4, 0x6b, 0x68, 0x15, 0xf0, 0x51, 0x10, 0xa2, 0xec, 0x49, 0x73,
-	0x48, 0x34, 0xdd, 0xe7, 0x4f, 0xdc, 0x19, 0xda, 0xdb, 0x39, 0xf2, 0x9c, 0x03, 0xad, 0x4f, 0x60,
-	0xe3, 0x59, 0xe0, 0x4d, 0x31, 0x4d, 0xf8, 0x5d, 0x4a, 0xe5, 0xa3, 0x52, 0xca, 0x5f, 0xad, 0x57,
-	0xd0, 0x79, 0x36, 0x8f, 0xf1, 0x71, 0x80, 0xcc, 0x65, 0xde, 0x64, 0x6e, 0xe3, 0xab, 0x0c, 0x93,
-	0x94, 0x1c, 0x40, 0x33, 0x9d, 0xc7, 0xe8, 0x48, 0x9c, 0x6a, 0x00, 0xf0, 0x90, 0xe4, 0x24, 0xa7,
-	0xd0, 0x14, 0xe3, 0x1d, 0x09, 0x5b, 0xaa, 0x1b, 0x1f, 0x5c, 0x1b, 0xb0, 0x74, 0x6d, 0x43, 0x54,
-	0x3c, 0x5b, 0x0e, 0x90, 0x25, 0xc9, 0x38, 0x9c, 0xbf, 0x59, 0xb0, 0x0f, 0xf5, 0x31, 0x6f, 0xaf,
-	0x92, 0x22, 0xd7, 0x1b, 0x6f, 0x4b, 0x80, 0x75, 0x08, 0xad, 0x33, 0x37, 0x0c, 0x91, 0x25, 0xb9,
-	0x9b, 0xd5, 0xfe, 0x4f, 0x61, 0xab, 0xc0, 0xf2, 0x63, 0x14, 0x2a, 0xc6, 0x6d, 0x55, 0xf0, 0x2d,
-	0x54, 0xf0, 0xad, 0x55, 0xbe, 0x85, 0xdd, 0x4b, 0x97, 0xb9, 0x33, 0x4c, 0x4b, 0x76, 0x3e, 0x85,
-	0xf6, 0x28, 0x8b, 0x3c, 0xf1, 0x25, 0xeb, 0x8a, 0x3b, 0x79, 0x5c, 0x4d, 0xde, 0xfa, 0x6b, 0x1d,
-	0x76, 0xca, 0x04, 0x5c, 0x3d, 0x84, 0xbd, 0x45, 0x39, 0x75, 0xe2, 0x22, 0xad, 0x3e, 0x94, 0x2f,
-	0xb5, 0xe3, 0x2c, 0x55, 0x0f, 0xce, 0x73, 0x05, 0xba, 0xc8, 0xc8, 0x2f, 0xa8, 0x33, 0xaa, 0x48,
-	0x91, 0x18, 0xba, 0x65, 0x35, 0x86, 0x69, 0xc6, 0x22, 0x27, 0xff, 0x86, 0xb8, 0xde, 0x57, 0xb7,
-	0xd4, 0xb3, 0x45, 0xe9, 0x73, 0x5e, 0x29, 0x05, 0xef, 0x8e, 0xaa, 0x72, 0xe4, 0x51, 0x79, 0xf3,
-	0xc8, 0xfd, 0x78, 0x78, 0xa3, 0x44, 0xbe, 0x89, 0x24, 0x6b, 0xb1, 0x8e, 0xcc, 0xdf, 0x61, 0x7f,
-	0xa5, 0xdb, 0x8a, 0xd5, 0x70, 0xa8, 0xaf, 0x86, 0x8e, 0xa6, 0xa9, 0x06, 0x52, 0x5a, 0x0c, 0xe6,
-	0x63, 0x30, 0x57, 0x9b, 0xab, 0xe0, 0xef, 0x94, 0xf9, 0x1b, 0x65, 0x26, 0x1b, 0xb6, 0x35, 0x0f,
-	0x15, 0xc5, 0x47, 0xfa, 0xe1, 0x56, 0xac, 0xe2, 0xd2, 0xda, 0x3a, 0x84, 0xd6, 0xa5, 0xcb, 0x30,
-	0x4a, 0x6f, 0x71, 0xbf, 0xff, 0x36, 0x60, 0xab, 0x00, 0xf3, 0x2b, 0xf6, 0x02, 0xda, 0x41, 0x14,
-	0x67, 0xa9, 0xba, 0x5f, 0x3c, 0xa1, 0x2e, 0xd7, 0x83, 0xe5, 0x49, 0x14, 0x45, 0x83, 0x1f, 0x79,
-	0x85, 0x68, 0x34, 0x8f, 0xc9, 0x61, 0xb4, 0x02, 0x2d, 0x68, 0xbe, 0x80, 0xf7, 0x2b, 0x60, 0xef,
-	0x3e, 0x0c, 0xeb, 0x08, 0x76, 0xce, 0x26, 0x41, 0xe8, 0x33, 0x8c, 0xde, 0xec, 0xf7, 0x1f, 0x03,
-	0xb6, 0x17, 0x68, 0x6e, 0xf8, 0x37, 0xd8, 0x2d, 0x0c, 0x7b, 0x2a, 0xa3, 0x1c, 0x1f, 0x6b, 0xd2,
-	0x5a, 0x59, 0x6e, 0x39, 0x0f, 0x4a, 0xcf, 0x3b, 0x81, 0x1e, 0x35, 0x7f, 0x81, 0x4e, 0x15, 0xf0,
-	0xdd, 0x5d, 0x0f, 0xff, 0xad, 0x41, 0xeb, 0xa1, 0xfc, 0xc5, 0xf3, 0x14, 0xd9, 0x55, 0xe0, 0x21,
-	0x39, 0x83, 0x0d, 0xb5, 0x11, 0xc9, 0x87, 0xfa, 0xc9, 0xb5, 0x9d, 0x6a, 0xee, 0x57, 0x27, 0xe3,
-	0x70, 0x6e, 0xad, 0x15, 0x24, 0x58, 0x49, 0x82, 0x37, 0x91, 0x60, 0x99, 0x44, 0x0d, 0x79, 0x89,
-	0x44, 0xbf, 0x97, 0x4b, 0x24, 0xe5, 0x2b, 0x65, 0xad, 0x91, 0x73, 0xd8, 0xcc, 0x9b, 0x46, 0xee,
-	0xad, 0x98, 0x84, 0xa4, 0x31, 0x57, 0xcf, 0xc9, 0x5a, 0x23, 0x3f, 0xc3, 0xb6, 0xf6, 0x5f, 0x8b,
-	0x7c, 0xac, 0xf7, 0xb6, 0xe2, 0x9f, 0xa8, 0x79, 0x70, 0x13, 0x44, 0xd2, 0x5e, 0x00, 0x94, 0x76,
-	0xe5, 0xfd, 0x95, 0x6b, 0x4a, 0x12, 0xde, 0xbb, 0x69, 0x8d, 0x59, 0x6b, 0xdf, 0x7f, 0x01, 0x07,
-	0x1e, 0x9d, 0x0d, 0xc6, 0x94, 0x8e, 0x43, 0x1c, 0xf8, 0x78, 0x95, 0x52, 0x1a, 0x26, 0xe5, 0xa2,
-	0x4b, 0xe3, 0xd7, 0xb6, 0xfa, 0x89, 0xeb, 0x8c, 0xa9, 0x23, 0x62, 0x2f, 0xdf, 0x13, 0x7f, 0x4e,
-	0xfe, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x44, 0xae, 0xfe, 0x27, 0x09, 0x0b, 0x00, 0x00,
+	// 1033 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4b, 0x73, 0xdb, 0x54,
+	0x14, 0x8e, 0xe2, 0x98, 0xc4, 0xc7, 0x79, 0x5e, 0xdc, 0xa0, 0xa8, 0x25, 0x31, 0x62, 0x63, 0x12,
+	0xea, 0x0c, 0x0e, 0x8f, 0xc0, 0x82, 0x19, 0x9a, 0x36, 0x2d, 0x33, 0xa1, 0x64, 0xd4, 0xd0, 0x32,
+	0x30, 0x1d, 0xcd, 0xad, 0x74, 0x6c, 0x6b, 0x22, 0xeb, 0x0a, 0x3d, 0x32, 0xf1, 0x9a, 0x3d, 0x3f,
+	0x85, 0xdf, 0xc0, 0x8a, 0x15, 0x0b, 0xd6, 0xfc, 0x1a, 0xe6, 0x3e, 0x64, 0xeb, 0x26, 0x72, 0x9a,
+	0x4c, 0x57, 0xf1, 0x3d, 0xe7, 0x3b, 0xdf, 0x77, 0x1e, 0xf7, 0x9e, 0x08, 0xb6, 0xce, 0xc7, 0xd9,
+	0x10, 0xf7, 0xe3, 0x84, 0x65, 0x6c, 0x1f, 0x2f, 0xe3, 0x90, 0x25, 0xd8, 0x15, 0x27, 0xd2, 0x14,
+	0x2e, 0x79, 0xb0, 0xcc, 0x32, 0xce, 0x63, 0xa3, 0x11, 0x8b, 0x94, 0x47, 0x63, 0x48, 0x33, 0x96,
+	0xd0, 0x41, 0x11, 0xb4, 0x59, 0x76, 0x5d, 0x26, 0xd8, 0x97, 0x76, 0xfb, 0x5f, 0x03, 0x96, 0x9e,
+	0x33, 0x1f, 0x1f, 0xd3, 0x8c, 0x12, 0x02, 0x0b, 0xe7, 0x41, 0xe4, 0x9b, 0x46, 0xdb, 0xe8, 0x34,
+	0x1c, 0xf1, 0x9b, 0x98, 0xb0, 0x98, 0xe6, 0x6f, 0x84, 0x79, 0x5e, 0x98, 0x8b, 0x23, 0x39, 0x80,
+	0x46, 0xc8, 0x3c, 0x9a, 0x05, 0x2c, 0x4a, 0xcd, 0x5a, 0xbb, 0xd6, 0x69, 0xf6, 0xee, 0x75, 0x4b,
+	0x89, 0x76, 0x4f, 0x94, 0xd7, 0x99, 0xe2, 0xc8, 0x1e, 0x6c, 0xf8, 0xd8, 0x0f, 0xa2, 0x80, 0x1f,
+	0x5d, 0x1a, 0x79, 0x43, 0x96, 0x98, 0x0b, 0x82, 0x78, 0x7d, 0xea, 0xf8, 0x4e, 0xd8, 0xc9, 0xe7,
+	0xb0, 0xe0, 0x31, 0x1f, 0xcd, 0x7a, 0xdb, 0xe8, 0x34, 0x7b, 0x6d, 0x8d, 0x5c, 0x15, 0xfe, 0x03,
+	0x4d, 0xce, 0xd1, 0x7f, 0xc1, 0xf2, 0xc4, 0x43, 0x47, 0xa0, 0xed, 0xdf, 0x0d, 0x68, 0x3c, 0x4d,
+	0x68, 0x3c, 0xe4, 0x75, 0x91, 0x1e, 0x34, 0x22, 0xe6, 0xa3, 0xeb, 0xd3, 0x8c, 0x8a, 0xc2, 0xae,
+	0x66, 0x59, 0x54, 0xef, 0x2c, 0x45, 0x45, 0x1f, 0x6c, 0x58, 0x8e, 0x13, 0xf4, 0xd1, 0xc3, 0x34,
+	0x65, 0x49, 0x6a, 0xce, 0xb7, 0x6b, 0x9d, 0x86, 0xa3, 0xd9, 0xc8, 0x36, 0x40, 0x9a, 0x7b, 0x05,
+	0xa2, 0x26, 0x10, 0x25, 0x8b, 0xfd, 0x87, 0x01, 0x75, 0x91, 0x05, 0x39, 0x80, 0x3a, 0x67, 0x4e,
+	0x4d, 0x43, 0xf4, 0xe8, 0x43, 0x4d, 0x5d, 0x40, 0x44, 0x0e, 0xe9, 0x93, 0x28, 0x4b, 0xc6, 0x8e,
+	0xc4, 0x5a, 0xa7, 0x00, 0x53, 0x23, 0x59, 0x87, 0xda, 0x39, 0x8e, 0xd5, 0x5c, 0xf8, 0x4f, 0xf2,
+	0x29, 0xd4, 0x2f, 0x68, 0x98, 0xa3, 0x18, 0x4a, 0xb3, 0xb7, 0x79, 0x9d, 0x94, 0x87, 0x3b, 0x12,
+	0xf4, 0xcd, 0xfc, 0xa1, 0x61, 0x5f, 0x48, 0xc6, 0xe3, 0x20, 0xcc, 0x30, 0x21, 0x0f, 0x81, 0x04,
+	0x91, 0x17, 0xe6, 0x3e, 0xfa, 0x6e, 0x48, 0xa3, 0x41, 0x4e, 0x07, 0x2a, 0xc3, 0x86, 0xb3, 0x51,
+	0x78, 0x4e, 0x0a, 0x07, 0xf9, 0x1a, 0x56, 0x27, 0xf0, 0x7e, 0x10, 0xa2, 0xec, 0x49, 0xb3, 0x47,
+	0x34, 0xdd, 0x97, 0xcf, 0xe9, 0x08, 0x9d, 0x95, 0x02, 0x79, 0xcc, 0x81, 0xf6, 0xc7, 0xb0, 0x78,
+	0x16, 0x78, 0xe7, 0x98, 0xa5, 0xfc, 0x2e, 0x65, 0xf2, 0xa7, 0x52, 0x2a, 0x8e, 0xf6, 0x9f, 0x06,
+	0xb4, 0xce, 0xc6, 0x31, 0x3e, 0x0b, 0x30, 0xa1, 0x89, 0x37, 0x1c, 0x3b, 0xf8, 0x5b, 0x8e, 0x69,
+	0x46, 0x76, 0xa0, 0x99, 0x8d, 0x63, 0x74, 0x25, 0x50, 0x75, 0x00, 0xb8, 0x49, 0x92, 0x92, 0x43,
+	0x68, 0x8a, 0xf9, 0xf6, 0x45, 0x5d, 0xaa, 0x1d, 0x1f, 0x5c, 0x9b, 0xb0, 0x2c, 0xdb, 0x81, 0x68,
+	0xda, 0x82, 0xfb, 0xd0, 0x18, 0xd1, 0x4b, 0xd7, 0xc7, 0x38, 0x1b, 0x9a, 0xb5, 0xb6, 0xd1, 0xa9,
+	0x3b, 0x4b, 0x23, 0x7a, 0xf9, 0x98, 0x9f, 0xc9, 0x36, 0x34, 0xb9, 0xb3, 0x4f, 0x23, 0x97, 0xe5,
+	0x99, 0xb8, 0xa1, 0x75, 0x87, 0xe3, 0x8f, 0x69, 0xf4, 0x63, 0x9e, 0xd9, 0x2e, 0x90, 0x2b, 0xf9,
+	0xc6, 0xe1, 0xf8, 0xed, 0xd9, 0x76, 0xa0, 0x3e, 0xe0, 0xc3, 0x51, 0x79, 0x92, 0xeb, 0x63, 0x73,
+	0x24, 0xc0, 0xde, 0x85, 0xd5, 0x23, 0x1a, 0x86, 0x98, 0xa4, 0x45, 0x2b, 0x66, 0x77, 0xef, 0x10,
+	0x96, 0x27, 0x58, 0x9e, 0xc6, 0x44, 0xc5, 0xb8, 0xad, 0x0a, 0xde, 0x41, 0x05, 0xef, 0xac, 0xf2,
+	0x2d, 0x6c, 0x9c, 0xd2, 0x84, 0x8e, 0x30, 0x2b, 0x95, 0xf3, 0x09, 0xac, 0xf7, 0xf3, 0xc8, 0x13,
+	0x7b, 0x40, 0x57, 0x5c, 0x2b, 0xec, 0xea, 0xde, 0xd8, 0xff, 0xd5, 0x61, 0xad, 0x4c, 0xc0, 0xd5,
+	0x43, 0xd8, 0x9c, 0x86, 0x33, 0x37, 0x9e, 0xb8, 0xd5, 0x33, 0xfb, 0x52, 0x4b, 0xe7, 0x4a, 0x74,
+	0xf7, 0xb8, 0x50, 0x60, 0x53, 0x8f, 0x7c, 0x7f, 0xad, 0x7e, 0x85, 0x8b, 0xc4, 0x60, 0x96, 0xd5,
+	0x12, 0xcc, 0xf2, 0x24, 0x72, 0x8b, 0x17, 0xc8, 0xf5, 0xbe, 0xba, 0xa5, 0x9e, 0x23, 0x42, 0x5f,
+	0xf2, 0x48, 0x29, 0x78, 0xaf, 0x5f, 0xe5, 0x23, 0x4f, 0xcb, 0x7b, 0x4b, 0x6e, 0xd7, 0xdd, 0x1b,
+	0x25, 0x8a, 0x3d, 0x26, 0x59, 0xa7, 0xcb, 0xec, 0x35, 0x6c, 0x4c, 0x9a, 0xc3, 0x73, 0xe7, 0xb7,
+	0xd1, 0x5c, 0x10, 0x84, 0x9f, 0xdd, 0x48, 0x38, 0x39, 0x9f, 0x31, 0x7e, 0xd5, 0x25, 0xef, 0x5a,
+	0xac, 0x5b, 0xad, 0xd7, 0xb0, 0x35, 0xb3, 0x99, 0x15, 0x7b, 0x6b, 0x57, 0xdf, 0x5b, 0x2d, 0x2d,
+	0x03, 0x35, 0xef, 0xd2, 0xd6, 0xb2, 0x9e, 0x81, 0x35, 0xbb, 0x77, 0x15, 0xfc, 0xad, 0x32, 0x7f,
+	0xa3, 0xcc, 0xe4, 0xc0, 0x8a, 0xd6, 0xa2, 0x8a, 0xe0, 0x3d, 0x3d, 0xb9, 0x19, 0xff, 0x27, 0x4a,
+	0x9c, 0x8f, 0xa0, 0x55, 0xd5, 0xa5, 0xbb, 0xe4, 0xc5, 0x9f, 0xe0, 0x29, 0x4d, 0x30, 0xca, 0x6e,
+	0xf1, 0x04, 0xff, 0x32, 0x60, 0x79, 0x02, 0xe6, 0xaf, 0xe0, 0x15, 0xac, 0x07, 0x51, 0x9c, 0x67,
+	0xea, 0x09, 0x60, 0xa4, 0x62, 0x9a, 0xbd, 0x87, 0x57, 0x67, 0x3b, 0x09, 0xea, 0x7e, 0xcf, 0x23,
+	0xce, 0x98, 0xb2, 0xc9, 0xb9, 0xae, 0x06, 0x9a, 0xd1, 0x7a, 0x05, 0xef, 0x57, 0xc0, 0xde, 0x7d,
+	0xa0, 0xf6, 0x1e, 0xac, 0x1d, 0x0d, 0x83, 0xd0, 0x4f, 0x30, 0x7a, 0x7b, 0xbd, 0x7f, 0x1b, 0xb0,
+	0x32, 0x45, 0xf3, 0x82, 0x7f, 0x85, 0x8d, 0x49, 0xc1, 0x9e, 0xf2, 0xa8, 0x8a, 0xf7, 0x35, 0x69,
+	0x2d, 0xac, 0x28, 0xb9, 0x30, 0xaa, 0xbb, 0x1c, 0xe8, 0x56, 0xeb, 0x67, 0x68, 0x55, 0x01, 0xdf,
+	0xbd, 0xea, 0xde, 0x3f, 0x35, 0x58, 0x7d, 0x22, 0x3f, 0xe9, 0x5e, 0x60, 0x72, 0x11, 0x78, 0x48,
+	0x8e, 0x60, 0x51, 0x2d, 0x6d, 0x72, 0x5f, 0xcf, 0x5c, 0x5b, 0xfb, 0xd6, 0x56, 0xb5, 0x33, 0x0e,
+	0xc7, 0xf6, 0xdc, 0x84, 0x04, 0x2b, 0x49, 0xf0, 0x26, 0x12, 0x2c, 0x93, 0xa8, 0x21, 0x5f, 0x21,
+	0xd1, 0xef, 0xa5, 0xb5, 0x55, 0xed, 0x94, 0x24, 0xc7, 0xb0, 0x54, 0x34, 0x8d, 0x3c, 0x98, 0x31,
+	0x09, 0x49, 0x63, 0xcd, 0x9e, 0x93, 0x3d, 0x47, 0x7e, 0x82, 0x15, 0xed, 0x1f, 0x2b, 0xf9, 0x48,
+	0xef, 0x6d, 0xc5, 0x47, 0x82, 0xb5, 0x73, 0x13, 0x44, 0xd2, 0x9e, 0x00, 0x94, 0xd6, 0xf9, 0xf6,
+	0xcc, 0xc5, 0x27, 0x09, 0x1f, 0xdc, 0xb4, 0x18, 0xed, 0xb9, 0x47, 0x5f, 0xc0, 0x8e, 0xc7, 0x46,
+	0xdd, 0x01, 0x63, 0x83, 0x10, 0xbb, 0x3e, 0x5e, 0x64, 0x8c, 0x85, 0x69, 0x39, 0xe8, 0xd4, 0xf8,
+	0x65, 0x5d, 0x7d, 0xc3, 0xbb, 0x03, 0xe6, 0x0a, 0xdb, 0x9b, 0xf7, 0xc4, 0x9f, 0x83, 0xff, 0x07,
+	0x00, 0xca, 0x46, 0xac, 0x2e, 0xea, 0x0b, 0x00, 0x00,
 }
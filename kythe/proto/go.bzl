@@ -33,7 +33,7 @@ _go_proto_src = rule(
     },
 )
 
-def go_kythe_proto(proto = None, deps = [], importpath = None, visibility = None):
+def go_kythe_proto(proto = None, deps = [], importpath = None, visibility = None, compilers = None):
     """Helper for go_proto_library for kythe project.
 
     A shorthand for a go_proto_library with its import path set to the
@@ -56,6 +56,8 @@ def go_kythe_proto(proto = None, deps = [], importpath = None, visibility = None
     Args:
       proto: the proto lib to build a _go_proto lib for
       deps: the deps for the proto lib
+      compilers: optional go_proto_compilers used to generate the library
+        (e.g. ["@io_bazel_rules_go//proto:go_grpc"] for gRPC services)
     """
     base = proto.rsplit(":", 2)[-1]
     filename = "_".join(base.split("_")[:-1]) + ".pb.go"
@@ -66,12 +68,16 @@ def go_kythe_proto(proto = None, deps = [], importpath = None, visibility = None
 
     if not importpath:
         importpath = KYTHE_IMPORT_BASE + "/" + name
+    kwargs = {}
+    if compilers:
+        kwargs["compilers"] = compilers
     go_proto_library(
         name = name,
         deps = deps,
         importpath = importpath,
         proto = proto,
         visibility = visibility,
+        **kwargs
     )
 
     # Copy the generated source from the proto library so we can compare it to
@@ -28,6 +28,8 @@ type GoDetails struct {
 	Compiler             string   `protobuf:"bytes,5,opt,name=compiler,proto3" json:"compiler,omitempty"`
 	BuildTags            []string `protobuf:"bytes,6,rep,name=build_tags,json=buildTags,proto3" json:"build_tags,omitempty"`
 	CgoEnabled           bool     `protobuf:"varint,7,opt,name=cgo_enabled,json=cgoEnabled,proto3" json:"cgo_enabled,omitempty"`
+	ModulePath           string   `protobuf:"bytes,8,opt,name=module_path,json=modulePath,proto3" json:"module_path,omitempty"`
+	ModuleVersion        string   `protobuf:"bytes,9,opt,name=module_version,json=moduleVersion,proto3" json:"module_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -107,6 +109,20 @@ func (m *GoDetails) GetCgoEnabled() bool {
 	return false
 }
 
+func (m *GoDetails) GetModulePath() string {
+	if m != nil {
+		return m.ModulePath
+	}
+	return ""
+}
+
+func (m *GoDetails) GetModuleVersion() string {
+	if m != nil {
+		return m.ModuleVersion
+	}
+	return ""
+}
+
 type GoPackageInfo struct {
 	ImportPath           string   `protobuf:"bytes,1,opt,name=import_path,json=importPath,proto3" json:"import_path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -154,21 +170,23 @@ func init() {
 func init() { proto.RegisterFile("kythe/proto/go.proto", fileDescriptor_01b832ac89a99f34) }
 
 var fileDescriptor_01b832ac89a99f34 = []byte{
-	// 250 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0xd0, 0x41, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0x71, 0xea, 0x66, 0x5d, 0xdf, 0xe2, 0x25, 0x88, 0x04, 0x41, 0x56, 0x76, 0xea, 0xa9,
-	0x13, 0xfc, 0x06, 0xa2, 0x0c, 0x6f, 0xa3, 0x78, 0xf2, 0x52, 0xd2, 0x34, 0xbe, 0x2d, 0x4b, 0xf7,
-	0x94, 0x36, 0x0a, 0x7e, 0x3e, 0xbf, 0x98, 0x34, 0x71, 0xb2, 0x53, 0xde, 0xff, 0x2f, 0x04, 0x92,
-	0xd0, 0xcd, 0xe1, 0xdb, 0xb5, 0x66, 0x3b, 0x8c, 0x70, 0xd8, 0x32, 0x0a, 0x3f, 0x88, 0xd4, 0x6b,
-	0x88, 0xcd, 0x4f, 0x44, 0xc9, 0x0e, 0xcf, 0xc6, 0xa9, 0xce, 0x4e, 0x42, 0xd0, 0x92, 0x81, 0x49,
-	0x46, 0x59, 0x94, 0x27, 0xa5, 0x9f, 0xc5, 0x2d, 0xc5, 0x0c, 0x35, 0xea, 0x56, 0x5e, 0x78, 0xfd,
-	0xab, 0xe0, 0x23, 0xe0, 0xe4, 0xe2, 0xe4, 0x73, 0x05, 0x1f, 0x94, 0x6b, 0xe5, 0xf2, 0xe4, 0x73,
-	0x89, 0x3b, 0x5a, 0x69, 0xf4, 0x43, 0x67, 0xcd, 0x28, 0x2f, 0xfd, 0xce, 0x7f, 0x8b, 0x7b, 0xa2,
-	0xfa, 0xb3, 0xb3, 0x4d, 0xe5, 0x14, 0x4f, 0x32, 0xce, 0x16, 0x79, 0x52, 0x26, 0x5e, 0xde, 0x14,
-	0x4f, 0x62, 0x4d, 0xa9, 0x66, 0x54, 0xe6, 0xa8, 0x6a, 0x6b, 0x1a, 0x79, 0x95, 0x45, 0xf9, 0xaa,
-	0x24, 0xcd, 0x78, 0x09, 0xb2, 0x79, 0xa0, 0xeb, 0x1d, 0xf6, 0x4a, 0x1f, 0x14, 0x9b, 0xd7, 0xe3,
-	0x07, 0xe6, 0x13, 0x5d, 0x3f, 0x60, 0x74, 0x95, 0xbf, 0x49, 0x78, 0x0f, 0x05, 0xda, 0x2b, 0xd7,
-	0x3e, 0x15, 0xb4, 0xd6, 0xe8, 0x0b, 0x06, 0xd8, 0x9a, 0xa2, 0x31, 0x5f, 0x0e, 0xb0, 0x53, 0x71,
-	0xf6, 0x35, 0xef, 0x29, 0xa3, 0x62, 0x54, 0x3e, 0xea, 0xd8, 0x2f, 0x8f, 0xbf, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0xc6, 0x29, 0xc8, 0xc3, 0x53, 0x01, 0x00, 0x00,
+	// 281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xcf, 0x4a, 0x33, 0x31,
+	0x14, 0xc5, 0xe9, 0x9f, 0xaf, 0x5f, 0xe7, 0x96, 0xba, 0x08, 0x22, 0x41, 0x90, 0x96, 0x82, 0xd0,
+	0xd5, 0x54, 0xf0, 0x0d, 0x44, 0x29, 0xee, 0x4a, 0x11, 0x17, 0x6e, 0x86, 0x74, 0x26, 0xde, 0x0e,
+	0xcd, 0xcc, 0x19, 0x92, 0xb4, 0xe0, 0x33, 0xf8, 0xd2, 0x92, 0xa4, 0x15, 0x57, 0xb9, 0xbf, 0x5f,
+	0x0e, 0xe7, 0xc2, 0xa5, 0xeb, 0xc3, 0x97, 0xdf, 0xeb, 0x55, 0x67, 0xe1, 0xb1, 0x62, 0xe4, 0x71,
+	0x10, 0x93, 0x68, 0x13, 0x2c, 0xbe, 0xfb, 0x94, 0xad, 0xf1, 0xac, 0xbd, 0xaa, 0x8d, 0x13, 0x82,
+	0x86, 0x0c, 0x38, 0xd9, 0x9b, 0xf7, 0x96, 0xd9, 0x36, 0xce, 0xe2, 0x86, 0x46, 0x0c, 0x65, 0xcb,
+	0xbd, 0xec, 0x47, 0x7b, 0xa6, 0xe4, 0x2d, 0xe0, 0xe5, 0xe0, 0xe2, 0x03, 0x25, 0xdf, 0x29, 0xbf,
+	0x97, 0xc3, 0x8b, 0x0f, 0x24, 0x6e, 0x69, 0x5c, 0xa2, 0xe9, 0x6a, 0xa3, 0xad, 0xfc, 0x17, 0x7f,
+	0x7e, 0x59, 0xdc, 0x11, 0xed, 0x8e, 0xb5, 0xa9, 0x0a, 0xaf, 0xd8, 0xc9, 0xd1, 0x7c, 0xb0, 0xcc,
+	0xb6, 0x59, 0x34, 0x6f, 0x8a, 0x9d, 0x98, 0xd1, 0xa4, 0x64, 0x14, 0xba, 0x55, 0x3b, 0xa3, 0x2b,
+	0xf9, 0x7f, 0xde, 0x5b, 0x8e, 0xb7, 0x54, 0x32, 0x5e, 0x92, 0x09, 0x81, 0x06, 0xd5, 0xd1, 0xe8,
+	0x22, 0x2e, 0x1e, 0xc7, 0x7a, 0x4a, 0x6a, 0x13, 0x96, 0xdf, 0xd3, 0xd5, 0x39, 0x70, 0xd2, 0xd6,
+	0xd5, 0x68, 0x65, 0x16, 0x33, 0xd3, 0x64, 0xdf, 0x93, 0x5c, 0x3c, 0xd0, 0x74, 0x8d, 0x8d, 0x2a,
+	0x0f, 0x8a, 0xf5, 0x6b, 0xfb, 0x89, 0x50, 0x5c, 0x37, 0x1d, 0xac, 0x4f, 0xc5, 0xe9, 0x2e, 0x94,
+	0x54, 0x28, 0x7e, 0xca, 0x69, 0x56, 0xa2, 0xc9, 0x19, 0x60, 0xa3, 0xf3, 0x4a, 0x9f, 0x3c, 0x60,
+	0x5c, 0xfe, 0xe7, 0xc4, 0x1f, 0x13, 0x46, 0xc1, 0x28, 0x22, 0xec, 0x46, 0xf1, 0x79, 0xfc, 0x19,
+	0x00, 0xe0, 0xa3, 0x21, 0x88, 0x9b, 0x01, 0x00, 0x00,
 }
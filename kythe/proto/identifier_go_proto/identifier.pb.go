@@ -4,8 +4,12 @@
 package identifier_go_proto
 
 import (
+	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	math "math"
 )
 
@@ -20,13 +24,48 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type FindRequest_MatchMode int32
+
+const (
+	FindRequest_EXACT      FindRequest_MatchMode = 0
+	FindRequest_PREFIX     FindRequest_MatchMode = 1
+	FindRequest_SUBSTRING  FindRequest_MatchMode = 2
+	FindRequest_CAMEL_HUMP FindRequest_MatchMode = 3
+)
+
+var FindRequest_MatchMode_name = map[int32]string{
+	0: "EXACT",
+	1: "PREFIX",
+	2: "SUBSTRING",
+	3: "CAMEL_HUMP",
+}
+
+var FindRequest_MatchMode_value = map[string]int32{
+	"EXACT":      0,
+	"PREFIX":     1,
+	"SUBSTRING":  2,
+	"CAMEL_HUMP": 3,
+}
+
+func (x FindRequest_MatchMode) String() string {
+	return proto.EnumName(FindRequest_MatchMode_name, int32(x))
+}
+
+func (FindRequest_MatchMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e4e74f173922aaa6, []int{0, 0}
+}
+
 type FindRequest struct {
-	Identifier           string   `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
-	Corpus               []string `protobuf:"bytes,2,rep,name=corpus,proto3" json:"corpus,omitempty"`
-	Languages            []string `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Identifier           string                `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Corpus               []string              `protobuf:"bytes,2,rep,name=corpus,proto3" json:"corpus,omitempty"`
+	Languages            []string              `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty"`
+	MatchMode            FindRequest_MatchMode `protobuf:"varint,4,opt,name=match_mode,json=matchMode,proto3,enum=kythe.proto.FindRequest_MatchMode" json:"match_mode,omitempty"`
+	CaseInsensitive      bool                  `protobuf:"varint,5,opt,name=case_insensitive,json=caseInsensitive,proto3" json:"case_insensitive,omitempty"`
+	NodeKind             []string              `protobuf:"bytes,6,rep,name=node_kind,json=nodeKind,proto3" json:"node_kind,omitempty"`
+	MaxMatches           int32                 `protobuf:"varint,7,opt,name=max_matches,json=maxMatches,proto3" json:"max_matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *FindRequest) Reset()         { *m = FindRequest{} }
@@ -75,6 +114,34 @@ func (m *FindRequest) GetLanguages() []string {
 	return nil
 }
 
+func (m *FindRequest) GetMatchMode() FindRequest_MatchMode {
+	if m != nil {
+		return m.MatchMode
+	}
+	return FindRequest_EXACT
+}
+
+func (m *FindRequest) GetCaseInsensitive() bool {
+	if m != nil {
+		return m.CaseInsensitive
+	}
+	return false
+}
+
+func (m *FindRequest) GetNodeKind() []string {
+	if m != nil {
+		return m.NodeKind
+	}
+	return nil
+}
+
+func (m *FindRequest) GetMaxMatches() int32 {
+	if m != nil {
+		return m.MaxMatches
+	}
+	return 0
+}
+
 type FindReply struct {
 	Matches              []*FindReply_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
@@ -120,6 +187,7 @@ type FindReply_Match struct {
 	NodeSubkind          string   `protobuf:"bytes,3,opt,name=node_subkind,json=nodeSubkind,proto3" json:"node_subkind,omitempty"`
 	BaseName             string   `protobuf:"bytes,4,opt,name=base_name,json=baseName,proto3" json:"base_name,omitempty"`
 	QualifiedName        string   `protobuf:"bytes,5,opt,name=qualified_name,json=qualifiedName,proto3" json:"qualified_name,omitempty"`
+	RefCount             int32    `protobuf:"varint,6,opt,name=ref_count,json=refCount,proto3" json:"ref_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -185,7 +253,15 @@ func (m *FindReply_Match) GetQualifiedName() string {
 	return ""
 }
 
+func (m *FindReply_Match) GetRefCount() int32 {
+	if m != nil {
+		return m.RefCount
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("kythe.proto.FindRequest_MatchMode", FindRequest_MatchMode_name, FindRequest_MatchMode_value)
 	proto.RegisterType((*FindRequest)(nil), "kythe.proto.FindRequest")
 	proto.RegisterType((*FindReply)(nil), "kythe.proto.FindReply")
 	proto.RegisterType((*FindReply_Match)(nil), "kythe.proto.FindReply.Match")
@@ -194,26 +270,116 @@ func init() {
 func init() { proto.RegisterFile("kythe/proto/identifier.proto", fileDescriptor_e4e74f173922aaa6) }
 
 var fileDescriptor_e4e74f173922aaa6 = []byte{
-	// 325 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x41, 0x6b, 0xf2, 0x30,
-	0x18, 0xc7, 0xa9, 0x55, 0xdf, 0xb7, 0x4f, 0xb7, 0xc1, 0x32, 0x90, 0xe0, 0x64, 0x73, 0xc2, 0xc0,
-	0x53, 0x05, 0x07, 0xb2, 0xf3, 0x0e, 0x83, 0x31, 0xdc, 0xa1, 0xde, 0x76, 0x29, 0xb1, 0x79, 0x56,
-	0x83, 0x6d, 0xa2, 0x4d, 0x2a, 0xf8, 0x79, 0xf6, 0xe9, 0xf6, 0x2d, 0x46, 0x52, 0xa7, 0x3d, 0x78,
-	0x2a, 0xcf, 0xef, 0xff, 0x0b, 0x7d, 0xf2, 0x0f, 0x0c, 0xd6, 0x7b, 0xb3, 0xc2, 0xc9, 0xa6, 0x54,
-	0x46, 0x4d, 0x04, 0x47, 0x69, 0xc4, 0x97, 0xc0, 0x32, 0x72, 0x80, 0x84, 0x2e, 0xad, 0x87, 0x51,
-	0x0a, 0xe1, 0xab, 0x90, 0x3c, 0xc6, 0x6d, 0x85, 0xda, 0x90, 0x3b, 0x80, 0x93, 0x4f, 0xbd, 0xa1,
-	0x37, 0x0e, 0xe2, 0x06, 0x21, 0x3d, 0xe8, 0xa6, 0xaa, 0xdc, 0x54, 0x9a, 0xb6, 0x86, 0xfe, 0x38,
-	0x88, 0x0f, 0x13, 0x19, 0x40, 0x90, 0x33, 0x99, 0x55, 0x2c, 0x43, 0x4d, 0x7d, 0x17, 0x9d, 0xc0,
-	0xe8, 0xc7, 0x83, 0xa0, 0xfe, 0xcb, 0x26, 0xdf, 0x93, 0x19, 0xfc, 0x2b, 0x98, 0x49, 0x57, 0xa8,
-	0xa9, 0x37, 0xf4, 0xc7, 0xe1, 0x74, 0x10, 0x35, 0x36, 0x8a, 0x8e, 0x62, 0x34, 0xb7, 0x56, 0xfc,
-	0x27, 0xf7, 0xbf, 0x3d, 0xe8, 0x38, 0x64, 0xb7, 0x30, 0x22, 0x5d, 0xa3, 0x39, 0x6c, 0x78, 0x98,
-	0xc8, 0x2d, 0x04, 0x52, 0x71, 0x4c, 0xd6, 0x42, 0x72, 0xda, 0x72, 0xd1, 0x7f, 0x0b, 0xde, 0x85,
-	0xe4, 0xe4, 0x01, 0x2e, 0x5c, 0xa8, 0xab, 0xa5, 0xcb, 0x7d, 0x97, 0x87, 0x96, 0x2d, 0x6a, 0x64,
-	0xcf, 0x2f, 0x99, 0xc6, 0x44, 0xb2, 0x02, 0x69, 0xbb, 0x3e, 0x6f, 0xc1, 0x07, 0x2b, 0x90, 0x3c,
-	0xc2, 0xd5, 0xb6, 0x62, 0xb9, 0xed, 0x81, 0xd7, 0x46, 0xc7, 0x19, 0x97, 0x47, 0x6a, 0xb5, 0xe9,
-	0x1c, 0xae, 0xdf, 0x8e, 0x7d, 0x2d, 0xb0, 0xdc, 0x89, 0x14, 0xc9, 0x33, 0xb4, 0xed, 0xb5, 0x08,
-	0x3d, 0x73, 0x53, 0x57, 0x7c, 0xbf, 0x77, 0xbe, 0x83, 0x97, 0x19, 0xdc, 0xa7, 0xaa, 0x88, 0x32,
-	0xa5, 0xb2, 0x1c, 0x23, 0x8e, 0x3b, 0xa3, 0x54, 0xae, 0x9b, 0xf2, 0xe7, 0xcd, 0xe9, 0x7d, 0x92,
-	0x4c, 0x25, 0x0e, 0x2e, 0xbb, 0xee, 0xf3, 0xf4, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x8a, 0x7c, 0x37,
-	0x1b, 0x0b, 0x02, 0x00, 0x00,
+	// 481 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xdf, 0x6a, 0xdb, 0x30,
+	0x14, 0xc6, 0xe7, 0xa4, 0x49, 0xa3, 0x93, 0x35, 0xcb, 0x34, 0x28, 0xa6, 0x0d, 0xab, 0x17, 0x18,
+	0x78, 0x37, 0x2e, 0x64, 0x50, 0x76, 0x9b, 0x86, 0x74, 0x0b, 0x9b, 0x4b, 0x51, 0x5a, 0x28, 0xbb,
+	0x31, 0x8e, 0x7d, 0x92, 0x8a, 0xd8, 0x52, 0x6a, 0xcb, 0xa1, 0x79, 0x81, 0x3d, 0xd7, 0x9e, 0x61,
+	0x4f, 0x34, 0x24, 0xe7, 0x5f, 0xa1, 0xbd, 0xb2, 0xcf, 0xef, 0xfb, 0x64, 0x9f, 0xef, 0xe8, 0x40,
+	0x67, 0xbe, 0x52, 0x0f, 0x78, 0xbe, 0xc8, 0xa4, 0x92, 0xe7, 0x3c, 0x46, 0xa1, 0xf8, 0x94, 0x63,
+	0xe6, 0x19, 0x40, 0x9b, 0x46, 0x2d, 0x8b, 0xee, 0xbf, 0x0a, 0x34, 0xaf, 0xb8, 0x88, 0x19, 0x3e,
+	0x16, 0x98, 0x2b, 0xfa, 0x11, 0x60, 0x77, 0xc0, 0xb6, 0x1c, 0xcb, 0x25, 0x6c, 0x8f, 0xd0, 0x63,
+	0xa8, 0x47, 0x32, 0x5b, 0x14, 0xb9, 0x5d, 0x71, 0xaa, 0x2e, 0x61, 0xeb, 0x8a, 0x76, 0x80, 0x24,
+	0xa1, 0x98, 0x15, 0xe1, 0x0c, 0x73, 0xbb, 0x6a, 0xa4, 0x1d, 0xa0, 0x7d, 0x80, 0x34, 0x54, 0xd1,
+	0x43, 0x90, 0xca, 0x18, 0xed, 0x03, 0xc7, 0x72, 0x5b, 0xbd, 0xae, 0xb7, 0xd7, 0x87, 0xb7, 0xd7,
+	0x83, 0xe7, 0x6b, 0xab, 0x2f, 0x63, 0x64, 0x24, 0xdd, 0xbc, 0xd2, 0x2f, 0xd0, 0x8e, 0xc2, 0x1c,
+	0x03, 0x2e, 0x72, 0x14, 0x39, 0x57, 0x7c, 0x89, 0x76, 0xcd, 0xb1, 0xdc, 0x06, 0x7b, 0xa7, 0xf9,
+	0x68, 0x87, 0xe9, 0x29, 0x10, 0x21, 0x63, 0x0c, 0xe6, 0x5c, 0xc4, 0x76, 0xdd, 0xf4, 0xd2, 0xd0,
+	0xe0, 0x27, 0x17, 0x31, 0x3d, 0x83, 0x66, 0x1a, 0x3e, 0x05, 0xe6, 0xc3, 0x98, 0xdb, 0x87, 0x8e,
+	0xe5, 0xd6, 0x18, 0xa4, 0xe1, 0x93, 0x5f, 0x92, 0x6e, 0x1f, 0xc8, 0xb6, 0x01, 0x4a, 0xa0, 0x36,
+	0xbc, 0xef, 0x0f, 0x6e, 0xdb, 0x6f, 0x28, 0x40, 0xfd, 0x86, 0x0d, 0xaf, 0x46, 0xf7, 0x6d, 0x8b,
+	0x1e, 0x01, 0x19, 0xdf, 0x5d, 0x8e, 0x6f, 0xd9, 0xe8, 0xfa, 0x7b, 0xbb, 0x42, 0x5b, 0x00, 0x83,
+	0xbe, 0x3f, 0xfc, 0x15, 0xfc, 0xb8, 0xf3, 0x6f, 0xda, 0xd5, 0xee, 0x9f, 0x0a, 0x90, 0x32, 0xd0,
+	0x22, 0x59, 0xd1, 0x0b, 0x38, 0xdc, 0xfc, 0xcd, 0x72, 0xaa, 0x6e, 0xb3, 0xd7, 0x79, 0x21, 0xf9,
+	0x22, 0x59, 0x95, 0xb9, 0xd9, 0xc6, 0x7c, 0xf2, 0xd7, 0x82, 0x9a, 0x41, 0x7a, 0xe8, 0x8a, 0x47,
+	0x73, 0x54, 0xeb, 0x0b, 0x59, 0x57, 0xcf, 0x83, 0x56, 0x1c, 0xeb, 0x59, 0xd0, 0x4f, 0xf0, 0xd6,
+	0x88, 0x79, 0x31, 0x31, 0x7a, 0xd5, 0xe8, 0x4d, 0xcd, 0xc6, 0x25, 0xd2, 0xe7, 0x27, 0x7a, 0xa6,
+	0x22, 0x4c, 0xcb, 0x5b, 0x21, 0xac, 0xa1, 0xc1, 0x75, 0x98, 0x22, 0xfd, 0x0c, 0xad, 0xc7, 0x22,
+	0x4c, 0xf4, 0xb5, 0xc7, 0xa5, 0xa3, 0x66, 0x1c, 0x47, 0x5b, 0x6a, 0x6c, 0xa7, 0x40, 0x32, 0x9c,
+	0x06, 0x91, 0x2c, 0x84, 0xb2, 0xeb, 0x66, 0x9a, 0x8d, 0x0c, 0xa7, 0x03, 0x5d, 0xf7, 0x7c, 0x78,
+	0x3f, 0xda, 0xee, 0xce, 0x18, 0xb3, 0x25, 0x8f, 0x90, 0x7e, 0x83, 0x03, 0x9d, 0x99, 0xda, 0xaf,
+	0x2d, 0xc0, 0xc9, 0xf1, 0xcb, 0x03, 0xba, 0xbc, 0x80, 0xb3, 0x48, 0xa6, 0xde, 0x4c, 0xca, 0x59,
+	0x82, 0x5e, 0x8c, 0x4b, 0x25, 0x65, 0x92, 0xef, 0x9b, 0x7f, 0x7f, 0xd8, 0xed, 0x6a, 0x30, 0x93,
+	0x81, 0x81, 0x93, 0xba, 0x79, 0x7c, 0xfd, 0x3f, 0x00, 0x08, 0x6c, 0xc1, 0xce, 0x18, 0x03, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IdentifierServiceClient is the client API for IdentifierService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IdentifierServiceClient interface {
+	Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*FindReply, error)
+}
+
+type identifierServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIdentifierServiceClient(cc *grpc.ClientConn) IdentifierServiceClient {
+	return &identifierServiceClient{cc}
+}
+
+func (c *identifierServiceClient) Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (*FindReply, error) {
+	out := new(FindReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.IdentifierService/Find", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IdentifierServiceServer is the server API for IdentifierService service.
+type IdentifierServiceServer interface {
+	Find(context.Context, *FindRequest) (*FindReply, error)
+}
+
+// UnimplementedIdentifierServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedIdentifierServiceServer struct {
+}
+
+func (*UnimplementedIdentifierServiceServer) Find(ctx context.Context, req *FindRequest) (*FindReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+
+func RegisterIdentifierServiceServer(s *grpc.Server, srv IdentifierServiceServer) {
+	s.RegisterService(&_IdentifierService_serviceDesc, srv)
+}
+
+func _IdentifierService_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdentifierServiceServer).Find(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.IdentifierService/Find",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdentifierServiceServer).Find(ctx, req.(*FindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _IdentifierService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "kythe.proto.IdentifierService",
+	HandlerType: (*IdentifierServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Find",
+			Handler:    _IdentifierService_Find_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/identifier.proto",
 }
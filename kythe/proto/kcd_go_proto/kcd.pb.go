@@ -0,0 +1,1325 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: kythe/proto/kcd.proto
+
+package kcd_go_proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Revision struct {
+	Revision             string   `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Corpus               string   `protobuf:"bytes,2,opt,name=corpus,proto3" json:"corpus,omitempty"`
+	TimestampNanos       int64    `protobuf:"varint,3,opt,name=timestamp_nanos,json=timestampNanos,proto3" json:"timestamp_nanos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Revision) Reset()         { *m = Revision{} }
+func (m *Revision) String() string { return proto.CompactTextString(m) }
+func (*Revision) ProtoMessage()    {}
+func (*Revision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{0}
+}
+
+func (m *Revision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Revision.Unmarshal(m, b)
+}
+func (m *Revision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Revision.Marshal(b, m, deterministic)
+}
+func (m *Revision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Revision.Merge(m, src)
+}
+func (m *Revision) XXX_Size() int {
+	return xxx_messageInfo_Revision.Size(m)
+}
+func (m *Revision) XXX_DiscardUnknown() {
+	xxx_messageInfo_Revision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Revision proto.InternalMessageInfo
+
+func (m *Revision) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *Revision) GetCorpus() string {
+	if m != nil {
+		return m.Corpus
+	}
+	return ""
+}
+
+func (m *Revision) GetTimestampNanos() int64 {
+	if m != nil {
+		return m.TimestampNanos
+	}
+	return 0
+}
+
+type RevisionsRequest struct {
+	Revision             string   `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Corpus               string   `protobuf:"bytes,2,opt,name=corpus,proto3" json:"corpus,omitempty"`
+	UntilNanos           int64    `protobuf:"varint,3,opt,name=until_nanos,json=untilNanos,proto3" json:"until_nanos,omitempty"`
+	SinceNanos           int64    `protobuf:"varint,4,opt,name=since_nanos,json=sinceNanos,proto3" json:"since_nanos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevisionsRequest) Reset()         { *m = RevisionsRequest{} }
+func (m *RevisionsRequest) String() string { return proto.CompactTextString(m) }
+func (*RevisionsRequest) ProtoMessage()    {}
+func (*RevisionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{1}
+}
+
+func (m *RevisionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevisionsRequest.Unmarshal(m, b)
+}
+func (m *RevisionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevisionsRequest.Marshal(b, m, deterministic)
+}
+func (m *RevisionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevisionsRequest.Merge(m, src)
+}
+func (m *RevisionsRequest) XXX_Size() int {
+	return xxx_messageInfo_RevisionsRequest.Size(m)
+}
+func (m *RevisionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevisionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevisionsRequest proto.InternalMessageInfo
+
+func (m *RevisionsRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *RevisionsRequest) GetCorpus() string {
+	if m != nil {
+		return m.Corpus
+	}
+	return ""
+}
+
+func (m *RevisionsRequest) GetUntilNanos() int64 {
+	if m != nil {
+		return m.UntilNanos
+	}
+	return 0
+}
+
+func (m *RevisionsRequest) GetSinceNanos() int64 {
+	if m != nil {
+		return m.SinceNanos
+	}
+	return 0
+}
+
+type FindRequest struct {
+	Revisions            []string `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	Languages            []string `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	Corpus               []string `protobuf:"bytes,3,rep,name=corpus,proto3" json:"corpus,omitempty"`
+	Targets              []string `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	Sources              []string `protobuf:"bytes,5,rep,name=sources,proto3" json:"sources,omitempty"`
+	Outputs              []string `protobuf:"bytes,6,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindRequest) Reset()         { *m = FindRequest{} }
+func (m *FindRequest) String() string { return proto.CompactTextString(m) }
+func (*FindRequest) ProtoMessage()    {}
+func (*FindRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{2}
+}
+
+func (m *FindRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindRequest.Unmarshal(m, b)
+}
+func (m *FindRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindRequest.Marshal(b, m, deterministic)
+}
+func (m *FindRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindRequest.Merge(m, src)
+}
+func (m *FindRequest) XXX_Size() int {
+	return xxx_messageInfo_FindRequest.Size(m)
+}
+func (m *FindRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindRequest proto.InternalMessageInfo
+
+func (m *FindRequest) GetRevisions() []string {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+func (m *FindRequest) GetLanguages() []string {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *FindRequest) GetCorpus() []string {
+	if m != nil {
+		return m.Corpus
+	}
+	return nil
+}
+
+func (m *FindRequest) GetTargets() []string {
+	if m != nil {
+		return m.Targets
+	}
+	return nil
+}
+
+func (m *FindRequest) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *FindRequest) GetOutputs() []string {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type FindReply struct {
+	Digest               string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindReply) Reset()         { *m = FindReply{} }
+func (m *FindReply) String() string { return proto.CompactTextString(m) }
+func (*FindReply) ProtoMessage()    {}
+func (*FindReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{3}
+}
+
+func (m *FindReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindReply.Unmarshal(m, b)
+}
+func (m *FindReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindReply.Marshal(b, m, deterministic)
+}
+func (m *FindReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindReply.Merge(m, src)
+}
+func (m *FindReply) XXX_Size() int {
+	return xxx_messageInfo_FindReply.Size(m)
+}
+func (m *FindReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindReply proto.InternalMessageInfo
+
+func (m *FindReply) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+type UnitsRequest struct {
+	Digests              []string `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnitsRequest) Reset()         { *m = UnitsRequest{} }
+func (m *UnitsRequest) String() string { return proto.CompactTextString(m) }
+func (*UnitsRequest) ProtoMessage()    {}
+func (*UnitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{4}
+}
+
+func (m *UnitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnitsRequest.Unmarshal(m, b)
+}
+func (m *UnitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnitsRequest.Marshal(b, m, deterministic)
+}
+func (m *UnitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnitsRequest.Merge(m, src)
+}
+func (m *UnitsRequest) XXX_Size() int {
+	return xxx_messageInfo_UnitsRequest.Size(m)
+}
+func (m *UnitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnitsRequest proto.InternalMessageInfo
+
+func (m *UnitsRequest) GetDigests() []string {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
+}
+
+type Unit struct {
+	Digest               string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	FormatKey            string   `protobuf:"bytes,2,opt,name=format_key,json=formatKey,proto3" json:"format_key,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Unit) Reset()         { *m = Unit{} }
+func (m *Unit) String() string { return proto.CompactTextString(m) }
+func (*Unit) ProtoMessage()    {}
+func (*Unit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{5}
+}
+
+func (m *Unit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Unit.Unmarshal(m, b)
+}
+func (m *Unit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Unit.Marshal(b, m, deterministic)
+}
+func (m *Unit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Unit.Merge(m, src)
+}
+func (m *Unit) XXX_Size() int {
+	return xxx_messageInfo_Unit.Size(m)
+}
+func (m *Unit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Unit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Unit proto.InternalMessageInfo
+
+func (m *Unit) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *Unit) GetFormatKey() string {
+	if m != nil {
+		return m.FormatKey
+	}
+	return ""
+}
+
+func (m *Unit) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type FilesRequest struct {
+	Digests              []string `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FilesRequest) Reset()         { *m = FilesRequest{} }
+func (m *FilesRequest) String() string { return proto.CompactTextString(m) }
+func (*FilesRequest) ProtoMessage()    {}
+func (*FilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{6}
+}
+
+func (m *FilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FilesRequest.Unmarshal(m, b)
+}
+func (m *FilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FilesRequest.Marshal(b, m, deterministic)
+}
+func (m *FilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FilesRequest.Merge(m, src)
+}
+func (m *FilesRequest) XXX_Size() int {
+	return xxx_messageInfo_FilesRequest.Size(m)
+}
+func (m *FilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FilesRequest proto.InternalMessageInfo
+
+func (m *FilesRequest) GetDigests() []string {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
+}
+
+type File struct {
+	Digest               string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *File) Reset()         { *m = File{} }
+func (m *File) String() string { return proto.CompactTextString(m) }
+func (*File) ProtoMessage()    {}
+func (*File) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{7}
+}
+
+func (m *File) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_File.Unmarshal(m, b)
+}
+func (m *File) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_File.Marshal(b, m, deterministic)
+}
+func (m *File) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_File.Merge(m, src)
+}
+func (m *File) XXX_Size() int {
+	return xxx_messageInfo_File.Size(m)
+}
+func (m *File) XXX_DiscardUnknown() {
+	xxx_messageInfo_File.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_File proto.InternalMessageInfo
+
+func (m *File) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *File) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type WriteRevisionRequest struct {
+	Revision             *Revision `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Replace              bool      `protobuf:"varint,2,opt,name=replace,proto3" json:"replace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *WriteRevisionRequest) Reset()         { *m = WriteRevisionRequest{} }
+func (m *WriteRevisionRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteRevisionRequest) ProtoMessage()    {}
+func (*WriteRevisionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{8}
+}
+
+func (m *WriteRevisionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteRevisionRequest.Unmarshal(m, b)
+}
+func (m *WriteRevisionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteRevisionRequest.Marshal(b, m, deterministic)
+}
+func (m *WriteRevisionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteRevisionRequest.Merge(m, src)
+}
+func (m *WriteRevisionRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteRevisionRequest.Size(m)
+}
+func (m *WriteRevisionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteRevisionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteRevisionRequest proto.InternalMessageInfo
+
+func (m *WriteRevisionRequest) GetRevision() *Revision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
+func (m *WriteRevisionRequest) GetReplace() bool {
+	if m != nil {
+		return m.Replace
+	}
+	return false
+}
+
+type WriteUnitRequest struct {
+	Revision             string   `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Corpus               string   `protobuf:"bytes,2,opt,name=corpus,proto3" json:"corpus,omitempty"`
+	FormatKey            string   `protobuf:"bytes,3,opt,name=format_key,json=formatKey,proto3" json:"format_key,omitempty"`
+	Data                 []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	DigestData           []byte   `protobuf:"bytes,7,opt,name=digest_data,json=digestData,proto3" json:"digest_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteUnitRequest) Reset()         { *m = WriteUnitRequest{} }
+func (m *WriteUnitRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteUnitRequest) ProtoMessage()    {}
+func (*WriteUnitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{9}
+}
+
+func (m *WriteUnitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteUnitRequest.Unmarshal(m, b)
+}
+func (m *WriteUnitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteUnitRequest.Marshal(b, m, deterministic)
+}
+func (m *WriteUnitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteUnitRequest.Merge(m, src)
+}
+func (m *WriteUnitRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteUnitRequest.Size(m)
+}
+func (m *WriteUnitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteUnitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteUnitRequest proto.InternalMessageInfo
+
+func (m *WriteUnitRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *WriteUnitRequest) GetCorpus() string {
+	if m != nil {
+		return m.Corpus
+	}
+	return ""
+}
+
+func (m *WriteUnitRequest) GetFormatKey() string {
+	if m != nil {
+		return m.FormatKey
+	}
+	return ""
+}
+
+func (m *WriteUnitRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *WriteUnitRequest) GetDigestData() []byte {
+	if m != nil {
+		return m.DigestData
+	}
+	return nil
+}
+
+type WriteReply struct {
+	Digest               string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteReply) Reset()         { *m = WriteReply{} }
+func (m *WriteReply) String() string { return proto.CompactTextString(m) }
+func (*WriteReply) ProtoMessage()    {}
+func (*WriteReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{10}
+}
+
+func (m *WriteReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteReply.Unmarshal(m, b)
+}
+func (m *WriteReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteReply.Marshal(b, m, deterministic)
+}
+func (m *WriteReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteReply.Merge(m, src)
+}
+func (m *WriteReply) XXX_Size() int {
+	return xxx_messageInfo_WriteReply.Size(m)
+}
+func (m *WriteReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteReply proto.InternalMessageInfo
+
+func (m *WriteReply) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	Digest               string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Revision             string   `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	Corpus               string   `protobuf:"bytes,3,opt,name=corpus,proto3" json:"corpus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{11}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetCorpus() string {
+	if m != nil {
+		return m.Corpus
+	}
+	return ""
+}
+
+type DeleteReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteReply) Reset()         { *m = DeleteReply{} }
+func (m *DeleteReply) String() string { return proto.CompactTextString(m) }
+func (*DeleteReply) ProtoMessage()    {}
+func (*DeleteReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e734a51877f39a11, []int{12}
+}
+
+func (m *DeleteReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteReply.Unmarshal(m, b)
+}
+func (m *DeleteReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteReply.Marshal(b, m, deterministic)
+}
+func (m *DeleteReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteReply.Merge(m, src)
+}
+func (m *DeleteReply) XXX_Size() int {
+	return xxx_messageInfo_DeleteReply.Size(m)
+}
+func (m *DeleteReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteReply proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*Revision)(nil), "kythe.proto.kcd.Revision")
+	proto.RegisterType((*RevisionsRequest)(nil), "kythe.proto.kcd.RevisionsRequest")
+	proto.RegisterType((*FindRequest)(nil), "kythe.proto.kcd.FindRequest")
+	proto.RegisterType((*FindReply)(nil), "kythe.proto.kcd.FindReply")
+	proto.RegisterType((*UnitsRequest)(nil), "kythe.proto.kcd.UnitsRequest")
+	proto.RegisterType((*Unit)(nil), "kythe.proto.kcd.Unit")
+	proto.RegisterType((*FilesRequest)(nil), "kythe.proto.kcd.FilesRequest")
+	proto.RegisterType((*File)(nil), "kythe.proto.kcd.File")
+	proto.RegisterType((*WriteRevisionRequest)(nil), "kythe.proto.kcd.WriteRevisionRequest")
+	proto.RegisterType((*WriteUnitRequest)(nil), "kythe.proto.kcd.WriteUnitRequest")
+	proto.RegisterType((*WriteReply)(nil), "kythe.proto.kcd.WriteReply")
+	proto.RegisterType((*DeleteRequest)(nil), "kythe.proto.kcd.DeleteRequest")
+	proto.RegisterType((*DeleteReply)(nil), "kythe.proto.kcd.DeleteReply")
+}
+
+func init() { proto.RegisterFile("kythe/proto/kcd.proto", fileDescriptor_e734a51877f39a11) }
+
+var fileDescriptor_e734a51877f39a11 = []byte{
+	// 683 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0xc5, 0x8d, 0x73, 0x9b, 0xf4, 0x12, 0x19, 0x8a, 0x8c, 0x69, 0x69, 0x31, 0x20, 0xf2, 0x94,
+	0xa2, 0x22, 0x3e, 0xa0, 0x50, 0xfa, 0x50, 0x2e, 0x02, 0x4b, 0x15, 0x12, 0x3c, 0x44, 0x5b, 0x7b,
+	0x31, 0x4b, 0x1c, 0xaf, 0xf1, 0xae, 0xab, 0xe6, 0x0f, 0xf8, 0x1b, 0x1e, 0xf8, 0x10, 0x7e, 0x09,
+	0xed, 0xd8, 0xeb, 0x3a, 0x17, 0x53, 0xd4, 0x3c, 0x65, 0x67, 0xce, 0xe4, 0xcc, 0xed, 0x8c, 0x61,
+	0x7b, 0x3c, 0x95, 0xdf, 0xe8, 0x41, 0x92, 0x72, 0xc9, 0x0f, 0xc6, 0x7e, 0x30, 0xc4, 0x97, 0xb5,
+	0x85, 0xee, 0xdc, 0x18, 0x8e, 0xfd, 0xc0, 0x0d, 0xa1, 0xe3, 0xd1, 0x0b, 0x26, 0x18, 0x8f, 0x2d,
+	0x07, 0x3a, 0x69, 0xf1, 0xb6, 0x8d, 0x7d, 0x63, 0xd0, 0xf5, 0x4a, 0xdb, 0xba, 0x0b, 0x2d, 0x9f,
+	0xa7, 0x49, 0x26, 0xec, 0x35, 0x44, 0x0a, 0xcb, 0x7a, 0x0a, 0x5b, 0x92, 0x4d, 0xa8, 0x90, 0x64,
+	0x92, 0x8c, 0x62, 0x12, 0x73, 0x61, 0x37, 0xf6, 0x8d, 0x41, 0xc3, 0xdb, 0x2c, 0xdd, 0xef, 0x95,
+	0xd7, 0xfd, 0x69, 0x40, 0x5f, 0x67, 0x12, 0x1e, 0xfd, 0x91, 0x51, 0x21, 0x6f, 0x94, 0x71, 0x0f,
+	0x7a, 0x59, 0x2c, 0x59, 0x34, 0x93, 0x0d, 0xd0, 0x85, 0x99, 0x54, 0x80, 0x60, 0xb1, 0x4f, 0x8b,
+	0x00, 0x33, 0x0f, 0x40, 0x57, 0x5e, 0xca, 0x2f, 0x03, 0x7a, 0x27, 0x2c, 0x0e, 0x74, 0x15, 0x3b,
+	0xd0, 0xd5, 0x59, 0x85, 0x6d, 0xec, 0x37, 0x06, 0x5d, 0xef, 0xca, 0xa1, 0xd0, 0x88, 0xc4, 0x61,
+	0x46, 0x42, 0xaa, 0x4a, 0x41, 0xb4, 0x74, 0x54, 0xaa, 0x6c, 0x20, 0xa4, 0xab, 0xb4, 0xa1, 0x2d,
+	0x49, 0x1a, 0x52, 0xa9, 0x0a, 0x50, 0x80, 0x36, 0x15, 0x22, 0x78, 0x96, 0xfa, 0x54, 0xd8, 0xcd,
+	0x1c, 0x29, 0x4c, 0x85, 0xf0, 0x4c, 0x26, 0x99, 0x14, 0x76, 0x2b, 0x47, 0x0a, 0xd3, 0x7d, 0x04,
+	0xdd, 0xbc, 0xe0, 0x24, 0x9a, 0xaa, 0x94, 0x01, 0x0b, 0xa9, 0x90, 0xc5, 0xc8, 0x0a, 0xcb, 0x1d,
+	0xc0, 0xfa, 0x59, 0xcc, 0x64, 0x39, 0x5c, 0x1b, 0xda, 0x39, 0xa2, 0x9b, 0xd2, 0xa6, 0xfb, 0x11,
+	0x4c, 0x15, 0x59, 0xc7, 0x64, 0xed, 0x02, 0x7c, 0xe5, 0xe9, 0x84, 0xc8, 0xd1, 0x98, 0x4e, 0x8b,
+	0xf1, 0x77, 0x73, 0xcf, 0x1b, 0x3a, 0xb5, 0x2c, 0x30, 0x03, 0x22, 0x09, 0x8e, 0x7e, 0xdd, 0xc3,
+	0xb7, 0x4a, 0x7e, 0xc2, 0x22, 0xfa, 0x1f, 0xc9, 0x0f, 0xc1, 0x54, 0x91, 0xb5, 0xc9, 0x35, 0xfb,
+	0x5a, 0x85, 0x3d, 0x84, 0x3b, 0x9f, 0x52, 0x26, 0xa9, 0x16, 0x90, 0xce, 0xf2, 0x62, 0x4e, 0x3f,
+	0xbd, 0xc3, 0x7b, 0xc3, 0x39, 0x85, 0x0f, 0xcb, 0xff, 0x5c, 0x49, 0xcb, 0x86, 0x76, 0x4a, 0x93,
+	0x88, 0xf8, 0x14, 0xb3, 0x74, 0x3c, 0x6d, 0xba, 0xbf, 0x0d, 0xe8, 0x63, 0x26, 0x35, 0x9f, 0x55,
+	0x54, 0x3a, 0x3b, 0xc2, 0x46, 0xdd, 0x08, 0xcd, 0xab, 0x26, 0x95, 0x6e, 0xf3, 0x11, 0x8c, 0x10,
+	0x6a, 0x23, 0x04, 0xb9, 0xeb, 0x98, 0x48, 0x72, 0x6a, 0x76, 0x9a, 0xfd, 0xd6, 0xa9, 0xd9, 0x69,
+	0xf5, 0xdb, 0x9e, 0xf9, 0x5d, 0xf0, 0xd8, 0x6b, 0xb2, 0x38, 0xa0, 0x97, 0xee, 0x63, 0x80, 0x62,
+	0x3c, 0xff, 0xd2, 0xc7, 0x17, 0xd8, 0x38, 0xa6, 0x11, 0x95, 0x54, 0xf7, 0x55, 0xb7, 0x81, 0x6a,
+	0xbf, 0x6b, 0xb5, 0xfd, 0x36, 0xaa, 0xfd, 0xba, 0x1b, 0xd0, 0xd3, 0xe4, 0x49, 0x34, 0x3d, 0xfc,
+	0xd3, 0x82, 0xdb, 0xaf, 0xf8, 0x24, 0x61, 0x11, 0x91, 0x8c, 0xc7, 0xaa, 0xfc, 0x73, 0x22, 0xa8,
+	0xf5, 0x0e, 0xba, 0xe5, 0x47, 0xc0, 0x7a, 0x58, 0xbb, 0x2b, 0x2d, 0x23, 0xa7, 0x7e, 0x9d, 0xee,
+	0xad, 0x67, 0x86, 0x75, 0xac, 0xb4, 0x14, 0x07, 0xd6, 0xce, 0x42, 0x58, 0xe5, 0xbe, 0x1d, 0xa7,
+	0x06, 0x4d, 0xa2, 0x29, 0xb2, 0x1c, 0x41, 0x13, 0x0f, 0xc7, 0xda, 0x5d, 0x08, 0xac, 0x1e, 0x94,
+	0xb3, 0xbd, 0x14, 0xd6, 0x14, 0x28, 0xff, 0x25, 0x14, 0xd5, 0xb3, 0x70, 0xb6, 0x97, 0xc2, 0x48,
+	0x71, 0x02, 0x80, 0xa1, 0xaf, 0x2f, 0x99, 0x3a, 0xc1, 0x1b, 0xf3, 0x9c, 0xc1, 0xc6, 0xcc, 0xad,
+	0x58, 0x4f, 0x16, 0x62, 0x97, 0xdd, 0x92, 0x73, 0xbf, 0x2e, 0x0c, 0xc7, 0xa4, 0x36, 0x57, 0x1e,
+	0xc6, 0x92, 0xcd, 0xcd, 0x1f, 0xcd, 0x75, 0x74, 0x47, 0x05, 0x1d, 0x7e, 0x0a, 0x96, 0x77, 0x73,
+	0x1d, 0xc5, 0x5b, 0x80, 0x5c, 0x72, 0x58, 0xd2, 0x83, 0x85, 0xe0, 0x19, 0xb1, 0x3b, 0x3b, 0xb5,
+	0xf8, 0x1c, 0x1b, 0x56, 0xb4, 0x2a, 0xdb, 0x07, 0xd8, 0xd4, 0x8e, 0x62, 0x0b, 0x2b, 0x32, 0xbe,
+	0x3c, 0x80, 0x3d, 0x9f, 0x4f, 0x86, 0x21, 0xe7, 0x61, 0x44, 0x87, 0x01, 0xbd, 0x90, 0x9c, 0x47,
+	0xa2, 0xfa, 0xa7, 0xcf, 0xeb, 0x63, 0x3f, 0x18, 0x85, 0x7c, 0x84, 0xd6, 0x79, 0x0b, 0x7f, 0x9e,
+	0xff, 0x1d, 0x00, 0x74, 0x61, 0xdb, 0xe1, 0x0a, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CompilationDatabaseClient is the client API for CompilationDatabase service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CompilationDatabaseClient interface {
+	Revisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (CompilationDatabase_RevisionsClient, error)
+	Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (CompilationDatabase_FindClient, error)
+	Units(ctx context.Context, in *UnitsRequest, opts ...grpc.CallOption) (CompilationDatabase_UnitsClient, error)
+	Files(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (CompilationDatabase_FilesClient, error)
+	FilesExist(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (CompilationDatabase_FilesExistClient, error)
+	WriteRevision(ctx context.Context, in *WriteRevisionRequest, opts ...grpc.CallOption) (*WriteReply, error)
+	WriteUnit(ctx context.Context, in *WriteUnitRequest, opts ...grpc.CallOption) (*WriteReply, error)
+	WriteFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*WriteReply, error)
+	DeleteUnit(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error)
+	DeleteFile(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error)
+	DeleteRevision(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error)
+}
+
+type compilationDatabaseClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCompilationDatabaseClient(cc *grpc.ClientConn) CompilationDatabaseClient {
+	return &compilationDatabaseClient{cc}
+}
+
+func (c *compilationDatabaseClient) Revisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (CompilationDatabase_RevisionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CompilationDatabase_serviceDesc.Streams[0], "/kythe.proto.kcd.CompilationDatabase/Revisions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compilationDatabaseRevisionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompilationDatabase_RevisionsClient interface {
+	Recv() (*Revision, error)
+	grpc.ClientStream
+}
+
+type compilationDatabaseRevisionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *compilationDatabaseRevisionsClient) Recv() (*Revision, error) {
+	m := new(Revision)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compilationDatabaseClient) Find(ctx context.Context, in *FindRequest, opts ...grpc.CallOption) (CompilationDatabase_FindClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CompilationDatabase_serviceDesc.Streams[1], "/kythe.proto.kcd.CompilationDatabase/Find", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compilationDatabaseFindClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompilationDatabase_FindClient interface {
+	Recv() (*FindReply, error)
+	grpc.ClientStream
+}
+
+type compilationDatabaseFindClient struct {
+	grpc.ClientStream
+}
+
+func (x *compilationDatabaseFindClient) Recv() (*FindReply, error) {
+	m := new(FindReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compilationDatabaseClient) Units(ctx context.Context, in *UnitsRequest, opts ...grpc.CallOption) (CompilationDatabase_UnitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CompilationDatabase_serviceDesc.Streams[2], "/kythe.proto.kcd.CompilationDatabase/Units", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compilationDatabaseUnitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompilationDatabase_UnitsClient interface {
+	Recv() (*Unit, error)
+	grpc.ClientStream
+}
+
+type compilationDatabaseUnitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *compilationDatabaseUnitsClient) Recv() (*Unit, error) {
+	m := new(Unit)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compilationDatabaseClient) Files(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (CompilationDatabase_FilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CompilationDatabase_serviceDesc.Streams[3], "/kythe.proto.kcd.CompilationDatabase/Files", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compilationDatabaseFilesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompilationDatabase_FilesClient interface {
+	Recv() (*File, error)
+	grpc.ClientStream
+}
+
+type compilationDatabaseFilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *compilationDatabaseFilesClient) Recv() (*File, error) {
+	m := new(File)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compilationDatabaseClient) FilesExist(ctx context.Context, in *FilesRequest, opts ...grpc.CallOption) (CompilationDatabase_FilesExistClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CompilationDatabase_serviceDesc.Streams[4], "/kythe.proto.kcd.CompilationDatabase/FilesExist", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compilationDatabaseFilesExistClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompilationDatabase_FilesExistClient interface {
+	Recv() (*File, error)
+	grpc.ClientStream
+}
+
+type compilationDatabaseFilesExistClient struct {
+	grpc.ClientStream
+}
+
+func (x *compilationDatabaseFilesExistClient) Recv() (*File, error) {
+	m := new(File)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compilationDatabaseClient) WriteRevision(ctx context.Context, in *WriteRevisionRequest, opts ...grpc.CallOption) (*WriteReply, error) {
+	out := new(WriteReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.kcd.CompilationDatabase/WriteRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compilationDatabaseClient) WriteUnit(ctx context.Context, in *WriteUnitRequest, opts ...grpc.CallOption) (*WriteReply, error) {
+	out := new(WriteReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.kcd.CompilationDatabase/WriteUnit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compilationDatabaseClient) WriteFile(ctx context.Context, in *File, opts ...grpc.CallOption) (*WriteReply, error) {
+	out := new(WriteReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.kcd.CompilationDatabase/WriteFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compilationDatabaseClient) DeleteUnit(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
+	out := new(DeleteReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.kcd.CompilationDatabase/DeleteUnit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compilationDatabaseClient) DeleteFile(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
+	out := new(DeleteReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.kcd.CompilationDatabase/DeleteFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compilationDatabaseClient) DeleteRevision(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
+	out := new(DeleteReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.kcd.CompilationDatabase/DeleteRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CompilationDatabaseServer is the server API for CompilationDatabase service.
+type CompilationDatabaseServer interface {
+	Revisions(*RevisionsRequest, CompilationDatabase_RevisionsServer) error
+	Find(*FindRequest, CompilationDatabase_FindServer) error
+	Units(*UnitsRequest, CompilationDatabase_UnitsServer) error
+	Files(*FilesRequest, CompilationDatabase_FilesServer) error
+	FilesExist(*FilesRequest, CompilationDatabase_FilesExistServer) error
+	WriteRevision(context.Context, *WriteRevisionRequest) (*WriteReply, error)
+	WriteUnit(context.Context, *WriteUnitRequest) (*WriteReply, error)
+	WriteFile(context.Context, *File) (*WriteReply, error)
+	DeleteUnit(context.Context, *DeleteRequest) (*DeleteReply, error)
+	DeleteFile(context.Context, *DeleteRequest) (*DeleteReply, error)
+	DeleteRevision(context.Context, *DeleteRequest) (*DeleteReply, error)
+}
+
+// UnimplementedCompilationDatabaseServer can be embedded to have forward compatible implementations.
+type UnimplementedCompilationDatabaseServer struct {
+}
+
+func (*UnimplementedCompilationDatabaseServer) Revisions(req *RevisionsRequest, srv CompilationDatabase_RevisionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Revisions not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) Find(req *FindRequest, srv CompilationDatabase_FindServer) error {
+	return status.Errorf(codes.Unimplemented, "method Find not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) Units(req *UnitsRequest, srv CompilationDatabase_UnitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Units not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) Files(req *FilesRequest, srv CompilationDatabase_FilesServer) error {
+	return status.Errorf(codes.Unimplemented, "method Files not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) FilesExist(req *FilesRequest, srv CompilationDatabase_FilesExistServer) error {
+	return status.Errorf(codes.Unimplemented, "method FilesExist not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) WriteRevision(ctx context.Context, req *WriteRevisionRequest) (*WriteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteRevision not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) WriteUnit(ctx context.Context, req *WriteUnitRequest) (*WriteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteUnit not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) WriteFile(ctx context.Context, req *File) (*WriteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteFile not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) DeleteUnit(ctx context.Context, req *DeleteRequest) (*DeleteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUnit not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) DeleteFile(ctx context.Context, req *DeleteRequest) (*DeleteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (*UnimplementedCompilationDatabaseServer) DeleteRevision(ctx context.Context, req *DeleteRequest) (*DeleteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRevision not implemented")
+}
+
+func RegisterCompilationDatabaseServer(s *grpc.Server, srv CompilationDatabaseServer) {
+	s.RegisterService(&_CompilationDatabase_serviceDesc, srv)
+}
+
+func _CompilationDatabase_Revisions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RevisionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompilationDatabaseServer).Revisions(m, &compilationDatabaseRevisionsServer{stream})
+}
+
+type CompilationDatabase_RevisionsServer interface {
+	Send(*Revision) error
+	grpc.ServerStream
+}
+
+type compilationDatabaseRevisionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *compilationDatabaseRevisionsServer) Send(m *Revision) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompilationDatabase_Find_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompilationDatabaseServer).Find(m, &compilationDatabaseFindServer{stream})
+}
+
+type CompilationDatabase_FindServer interface {
+	Send(*FindReply) error
+	grpc.ServerStream
+}
+
+type compilationDatabaseFindServer struct {
+	grpc.ServerStream
+}
+
+func (x *compilationDatabaseFindServer) Send(m *FindReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompilationDatabase_Units_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UnitsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompilationDatabaseServer).Units(m, &compilationDatabaseUnitsServer{stream})
+}
+
+type CompilationDatabase_UnitsServer interface {
+	Send(*Unit) error
+	grpc.ServerStream
+}
+
+type compilationDatabaseUnitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *compilationDatabaseUnitsServer) Send(m *Unit) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompilationDatabase_Files_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FilesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompilationDatabaseServer).Files(m, &compilationDatabaseFilesServer{stream})
+}
+
+type CompilationDatabase_FilesServer interface {
+	Send(*File) error
+	grpc.ServerStream
+}
+
+type compilationDatabaseFilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *compilationDatabaseFilesServer) Send(m *File) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompilationDatabase_FilesExist_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FilesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompilationDatabaseServer).FilesExist(m, &compilationDatabaseFilesExistServer{stream})
+}
+
+type CompilationDatabase_FilesExistServer interface {
+	Send(*File) error
+	grpc.ServerStream
+}
+
+type compilationDatabaseFilesExistServer struct {
+	grpc.ServerStream
+}
+
+func (x *compilationDatabaseFilesExistServer) Send(m *File) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompilationDatabase_WriteRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompilationDatabaseServer).WriteRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.kcd.CompilationDatabase/WriteRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompilationDatabaseServer).WriteRevision(ctx, req.(*WriteRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompilationDatabase_WriteUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteUnitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompilationDatabaseServer).WriteUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.kcd.CompilationDatabase/WriteUnit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompilationDatabaseServer).WriteUnit(ctx, req.(*WriteUnitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompilationDatabase_WriteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(File)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompilationDatabaseServer).WriteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.kcd.CompilationDatabase/WriteFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompilationDatabaseServer).WriteFile(ctx, req.(*File))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompilationDatabase_DeleteUnit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompilationDatabaseServer).DeleteUnit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.kcd.CompilationDatabase/DeleteUnit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompilationDatabaseServer).DeleteUnit(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompilationDatabase_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompilationDatabaseServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.kcd.CompilationDatabase/DeleteFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompilationDatabaseServer).DeleteFile(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompilationDatabase_DeleteRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompilationDatabaseServer).DeleteRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.kcd.CompilationDatabase/DeleteRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompilationDatabaseServer).DeleteRevision(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CompilationDatabase_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "kythe.proto.kcd.CompilationDatabase",
+	HandlerType: (*CompilationDatabaseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteRevision",
+			Handler:    _CompilationDatabase_WriteRevision_Handler,
+		},
+		{
+			MethodName: "WriteUnit",
+			Handler:    _CompilationDatabase_WriteUnit_Handler,
+		},
+		{
+			MethodName: "WriteFile",
+			Handler:    _CompilationDatabase_WriteFile_Handler,
+		},
+		{
+			MethodName: "DeleteUnit",
+			Handler:    _CompilationDatabase_DeleteUnit_Handler,
+		},
+		{
+			MethodName: "DeleteFile",
+			Handler:    _CompilationDatabase_DeleteFile_Handler,
+		},
+		{
+			MethodName: "DeleteRevision",
+			Handler:    _CompilationDatabase_DeleteRevision_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Revisions",
+			Handler:       _CompilationDatabase_Revisions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Find",
+			Handler:       _CompilationDatabase_Find_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Units",
+			Handler:       _CompilationDatabase_Units_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Files",
+			Handler:       _CompilationDatabase_Files_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FilesExist",
+			Handler:       _CompilationDatabase_FilesExist_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kythe/proto/kcd.proto",
+}
@@ -136,6 +136,7 @@ type DecorationPiece struct {
 	//	*DecorationPiece_Node
 	//	*DecorationPiece_Definition_
 	//	*DecorationPiece_Diagnostic
+	//	*DecorationPiece_TargetOverride
 	Piece                isDecorationPiece_Piece `protobuf_oneof:"piece"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -198,6 +199,10 @@ type DecorationPiece_Diagnostic struct {
 	Diagnostic *common_go_proto.Diagnostic `protobuf:"bytes,6,opt,name=diagnostic,proto3,oneof"`
 }
 
+type DecorationPiece_TargetOverride struct {
+	TargetOverride *serving_go_proto.FileDecorations_Override `protobuf:"bytes,7,opt,name=target_override,json=targetOverride,proto3,oneof"`
+}
+
 func (*DecorationPiece_File) isDecorationPiece_Piece() {}
 
 func (*DecorationPiece_Reference) isDecorationPiece_Piece() {}
@@ -208,6 +213,8 @@ func (*DecorationPiece_Definition_) isDecorationPiece_Piece() {}
 
 func (*DecorationPiece_Diagnostic) isDecorationPiece_Piece() {}
 
+func (*DecorationPiece_TargetOverride) isDecorationPiece_Piece() {}
+
 func (m *DecorationPiece) GetPiece() isDecorationPiece_Piece {
 	if m != nil {
 		return m.Piece
@@ -250,6 +257,13 @@ func (m *DecorationPiece) GetDiagnostic() *common_go_proto.Diagnostic {
 	return nil
 }
 
+func (m *DecorationPiece) GetTargetOverride() *serving_go_proto.FileDecorations_Override {
+	if x, ok := m.GetPiece().(*DecorationPiece_TargetOverride); ok {
+		return x.TargetOverride
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*DecorationPiece) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -258,6 +272,7 @@ func (*DecorationPiece) XXX_OneofWrappers() []interface{} {
 		(*DecorationPiece_Node)(nil),
 		(*DecorationPiece_Definition_)(nil),
 		(*DecorationPiece_Diagnostic)(nil),
+		(*DecorationPiece_TargetOverride)(nil),
 	}
 }
 
@@ -317,34 +332,36 @@ func init() {
 func init() { proto.RegisterFile("kythe/proto/pipeline.proto", fileDescriptor_1b726955a4487b4b) }
 
 var fileDescriptor_1b726955a4487b4b = []byte{
-	// 461 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xc1, 0x8b, 0xd3, 0x4e,
-	0x14, 0x6e, 0xfb, 0x4b, 0xf3, 0x23, 0x2f, 0xa2, 0x38, 0x78, 0xc8, 0x16, 0x71, 0xcb, 0x2e, 0x48,
-	0xf1, 0x90, 0x4a, 0xf4, 0x26, 0xa2, 0xd6, 0xae, 0x14, 0x84, 0x45, 0x72, 0xf0, 0xe0, 0x25, 0xc4,
-	0x99, 0xd7, 0x74, 0xd8, 0x64, 0x5e, 0x98, 0xc4, 0xe2, 0x1e, 0xbd, 0xfa, 0x57, 0x4b, 0x26, 0xd3,
-	0x98, 0x48, 0x56, 0x3c, 0x25, 0x6f, 0xbe, 0xef, 0x7d, 0xf3, 0xbe, 0x6f, 0x1e, 0x2c, 0x6e, 0x6e,
-	0xeb, 0x03, 0xae, 0x4b, 0x4d, 0x35, 0xad, 0x4b, 0x59, 0x62, 0x2e, 0x15, 0x86, 0xa6, 0x64, 0x8f,
-	0x0c, 0xd6, 0x16, 0xe1, 0x09, 0x5b, 0x04, 0xfd, 0x0e, 0x4e, 0x45, 0x41, 0xaa, 0xa5, 0x0c, 0x91,
-	0x8a, 0x1f, 0xb0, 0x48, 0x2d, 0x72, 0x36, 0x40, 0x50, 0x1f, 0xa5, 0xca, 0x46, 0xa1, 0x9a, 0x74,
-	0x9a, 0xd9, 0x2b, 0x2f, 0x7e, 0xce, 0xc0, 0x8b, 0x71, 0x8f, 0x1a, 0x15, 0x47, 0xf6, 0x0c, 0xdc,
-	0x8a, 0xbe, 0x69, 0x8e, 0xc1, 0x74, 0x39, 0x5d, 0xf9, 0x11, 0x0b, 0xfb, 0xe3, 0x7d, 0xbe, 0x4e,
-	0x0b, 0x8c, 0x2d, 0x83, 0xbd, 0x06, 0x30, 0x60, 0x72, 0x23, 0x95, 0x08, 0x66, 0xcb, 0xe9, 0xea,
-	0x7e, 0xf4, 0x78, 0xc0, 0xb7, 0xe3, 0x5d, 0x89, 0x0c, 0x3f, 0x4a, 0x25, 0x76, 0x93, 0xd8, 0x33,
-	0x70, 0x53, 0xb0, 0x4b, 0xb8, 0x97, 0xa1, 0x42, 0x2d, 0x79, 0x2b, 0xf0, 0xdf, 0x72, 0xba, 0xf2,
-	0x76, 0x93, 0xd8, 0xb7, 0xa7, 0x86, 0xf4, 0x0a, 0xdc, 0x54, 0xf1, 0x03, 0xe9, 0xc0, 0x31, 0xf3,
-	0x5c, 0x0e, 0xf5, 0xad, 0xc9, 0xab, 0xef, 0x65, 0xaa, 0x04, 0x8a, 0x77, 0x86, 0x1a, 0xdb, 0x16,
-	0xb6, 0x82, 0x79, 0xc5, 0xa9, 0xc4, 0x60, 0x7e, 0xa7, 0x97, 0x96, 0xb0, 0x71, 0xc1, 0x69, 0x66,
-	0xb8, 0xf8, 0xe1, 0xc0, 0x83, 0x2d, 0x72, 0xd2, 0x69, 0x2d, 0x49, 0x7d, 0x92, 0xc8, 0x91, 0x45,
-	0xe0, 0xef, 0x65, 0x8e, 0xc9, 0x31, 0x51, 0x69, 0xf1, 0xb7, 0x5c, 0xbc, 0x86, 0x66, 0x7e, 0xd9,
-	0x1a, 0x9c, 0xa6, 0x30, 0xa1, 0xf8, 0xd1, 0xd9, 0xe8, 0xd0, 0x1f, 0x64, 0x8e, 0xbb, 0x49, 0x6c,
-	0x88, 0xec, 0x0d, 0x78, 0xfa, 0xf4, 0x08, 0x26, 0x09, 0x3f, 0x3a, 0x0f, 0xc7, 0x36, 0x23, 0xec,
-	0xde, 0xaa, 0x49, 0xb3, 0xeb, 0x61, 0x21, 0x38, 0x8a, 0x04, 0xda, 0x98, 0x82, 0xb1, 0x67, 0xb8,
-	0x26, 0x61, 0x2e, 0x6c, 0x78, 0x2c, 0x06, 0x10, 0xb8, 0x97, 0x4a, 0x36, 0x46, 0x6d, 0x40, 0xcf,
-	0xc7, 0x6f, 0xfc, 0x23, 0x90, 0x70, 0xdb, 0xf5, 0xed, 0x26, 0x71, 0x4f, 0x85, 0xbd, 0x05, 0x10,
-	0x32, 0xcd, 0x14, 0x55, 0xb5, 0xe4, 0x81, 0x6b, 0x34, 0x9f, 0x0c, 0x34, 0xed, 0x26, 0x6f, 0x3b,
-	0x96, 0x51, 0xe8, 0xaa, 0xc5, 0x2d, 0xc0, 0x6f, 0x75, 0xf6, 0xd4, 0x7a, 0xba, 0x3b, 0xf2, 0xd6,
-	0xcb, 0xfb, 0x81, 0x97, 0xd9, 0xbf, 0x2f, 0x4a, 0xaf, 0x6d, 0xf3, 0x3f, 0xcc, 0xcb, 0xc6, 0xde,
-	0xe6, 0x25, 0x9c, 0x73, 0x2a, 0xc2, 0x8c, 0x28, 0xcb, 0x31, 0x14, 0x78, 0xac, 0x89, 0xf2, 0xaa,
-	0x2f, 0xf7, 0xe5, 0xe1, 0x29, 0x9b, 0x24, 0xa3, 0xc4, 0x1c, 0x7d, 0x75, 0xcd, 0xe7, 0xc5, 0xaf,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x80, 0x0e, 0xff, 0x22, 0xeb, 0x03, 0x00, 0x00,
+	// 493 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0xd1, 0x8a, 0xd3, 0x40,
+	0x14, 0x6d, 0xd7, 0xb6, 0x4b, 0x6f, 0x65, 0x17, 0x07, 0x1f, 0xb2, 0x45, 0xdc, 0xb2, 0x0b, 0x52,
+	0x04, 0xa7, 0x52, 0x7d, 0x13, 0x51, 0x6b, 0x57, 0x02, 0xc2, 0x2a, 0x79, 0x10, 0xf1, 0x25, 0xc4,
+	0x99, 0xdb, 0x74, 0xd8, 0x64, 0x6e, 0x98, 0xc4, 0xe2, 0xfe, 0x82, 0xbf, 0xe8, 0xcf, 0x48, 0x26,
+	0xd3, 0x6c, 0x22, 0xa9, 0xf8, 0x94, 0xdc, 0x39, 0xe7, 0x9e, 0x39, 0x73, 0xee, 0x85, 0xe9, 0xcd,
+	0x6d, 0xb1, 0xc5, 0x45, 0x66, 0xa8, 0xa0, 0x45, 0xa6, 0x32, 0x4c, 0x94, 0x46, 0x6e, 0x4b, 0xf6,
+	0xd0, 0x62, 0x55, 0xc1, 0xf7, 0xd8, 0xd4, 0x6b, 0x76, 0x08, 0x4a, 0x53, 0xd2, 0x15, 0xa5, 0x8d,
+	0xe4, 0x62, 0x8b, 0x69, 0xe4, 0x90, 0xb3, 0x16, 0x82, 0x66, 0xa7, 0x74, 0xdc, 0x09, 0x15, 0x64,
+	0xa2, 0xd8, 0x5d, 0x79, 0xf1, 0xeb, 0x08, 0xc6, 0x01, 0x6e, 0xd0, 0xa0, 0x16, 0xc8, 0x9e, 0xc2,
+	0x28, 0xa7, 0x1f, 0x46, 0xa0, 0xd7, 0x9f, 0xf5, 0xe7, 0x93, 0x25, 0xe3, 0x4d, 0x7b, 0x5f, 0xae,
+	0xa3, 0x14, 0x03, 0xc7, 0x60, 0xaf, 0x01, 0x2c, 0x18, 0xde, 0x28, 0x2d, 0xbd, 0xa3, 0x59, 0x7f,
+	0x7e, 0xb2, 0x7c, 0xd4, 0xe2, 0x3b, 0x7b, 0x57, 0x32, 0xc6, 0x8f, 0x4a, 0x4b, 0xbf, 0x17, 0x8c,
+	0x2d, 0x5c, 0x16, 0xec, 0x12, 0xee, 0xc7, 0xa8, 0xd1, 0x28, 0x51, 0x09, 0xdc, 0x9b, 0xf5, 0xe7,
+	0x63, 0xbf, 0x17, 0x4c, 0xdc, 0xa9, 0x25, 0xbd, 0x82, 0x51, 0xa4, 0xc5, 0x96, 0x8c, 0x37, 0xb0,
+	0x7e, 0x2e, 0xdb, 0xfa, 0xee, 0x91, 0x57, 0x3f, 0xb3, 0x48, 0x4b, 0x94, 0xef, 0x2c, 0x35, 0x70,
+	0x2d, 0x6c, 0x0e, 0xc3, 0x5c, 0x50, 0x86, 0xde, 0xf0, 0xe0, 0x5b, 0x2a, 0xc2, 0x6a, 0x04, 0x83,
+	0xd2, 0xc3, 0xc5, 0xef, 0x01, 0x9c, 0xae, 0x51, 0x90, 0x89, 0x0a, 0x45, 0xfa, 0xb3, 0x42, 0x81,
+	0x6c, 0x09, 0x93, 0x8d, 0x4a, 0x30, 0xdc, 0x85, 0x3a, 0x4a, 0xff, 0x95, 0xcb, 0xb8, 0xa4, 0xd9,
+	0x5f, 0xb6, 0x80, 0x41, 0x59, 0xd8, 0x50, 0x26, 0xcb, 0xb3, 0x4e, 0xd3, 0x1f, 0x54, 0x82, 0x7e,
+	0x2f, 0xb0, 0x44, 0xf6, 0x06, 0xc6, 0x66, 0x3f, 0x04, 0x9b, 0xc4, 0x64, 0x79, 0xce, 0xbb, 0x36,
+	0x83, 0xd7, 0xb3, 0x2a, 0xd3, 0xac, 0x7b, 0x18, 0x87, 0x81, 0x26, 0x89, 0x2e, 0x26, 0xaf, 0x6b,
+	0x0c, 0xd7, 0x24, 0xed, 0x85, 0x25, 0x8f, 0x05, 0x00, 0x12, 0x37, 0x4a, 0xab, 0xf2, 0xa1, 0x2e,
+	0xa0, 0xe7, 0xdd, 0x37, 0xfe, 0x15, 0x08, 0x5f, 0xd7, 0x7d, 0x7e, 0x2f, 0x68, 0xa8, 0xb0, 0xb7,
+	0x00, 0x52, 0x45, 0xb1, 0xa6, 0xbc, 0x50, 0xc2, 0x1b, 0x59, 0xcd, 0xc7, 0x2d, 0x4d, 0xb7, 0xc9,
+	0xeb, 0x9a, 0x65, 0x15, 0xea, 0x8a, 0x7d, 0x85, 0xd3, 0x22, 0x32, 0x31, 0x16, 0x21, 0xed, 0xd0,
+	0x18, 0x25, 0xd1, 0x3b, 0xb6, 0x32, 0xcf, 0x0e, 0x46, 0x78, 0xe7, 0x2e, 0xe7, 0x9f, 0x5c, 0x93,
+	0xdf, 0x0b, 0x4e, 0x2a, 0x9d, 0xfd, 0xc9, 0xf4, 0x16, 0xe0, 0xce, 0x37, 0x7b, 0xe2, 0xd2, 0x3a,
+	0x3c, 0xcc, 0x2a, 0xa5, 0xf7, 0xad, 0x94, 0x8e, 0xfe, 0x7f, 0x05, 0x1b, 0x6d, 0xab, 0x63, 0x18,
+	0x66, 0x65, 0x70, 0xab, 0x97, 0x70, 0x2e, 0x28, 0xe5, 0x31, 0x51, 0x9c, 0x20, 0x97, 0xb8, 0x2b,
+	0x88, 0x92, 0xbc, 0x29, 0xf7, 0xed, 0xc1, 0x3e, 0xf5, 0x30, 0xa6, 0xd0, 0x1e, 0x7d, 0x1f, 0xd9,
+	0xcf, 0x8b, 0x3f, 0x03, 0x00, 0x50, 0x3b, 0x8f, 0xfb, 0x45, 0x04, 0x00, 0x00,
 }
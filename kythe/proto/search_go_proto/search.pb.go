@@ -0,0 +1,411 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: kythe/proto/search.proto
+
+package search_go_proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	common_go_proto "kythe.io/kythe/proto/common_go_proto"
+	xref_go_proto "kythe.io/kythe/proto/xref_go_proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SearchRequest struct {
+	Query                string   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Regexp               bool     `protobuf:"varint,2,opt,name=regexp,proto3" json:"regexp,omitempty"`
+	Corpus               []string `protobuf:"bytes,3,rep,name=corpus,proto3" json:"corpus,omitempty"`
+	Root                 []string `protobuf:"bytes,4,rep,name=root,proto3" json:"root,omitempty"`
+	Path                 string   `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Language             []string `protobuf:"bytes,6,rep,name=language,proto3" json:"language,omitempty"`
+	ContextLines         int32    `protobuf:"varint,7,opt,name=context_lines,json=contextLines,proto3" json:"context_lines,omitempty"`
+	PageSize             int32    `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken            string   `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b99d343832230929, []int{0}
+}
+
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetRegexp() bool {
+	if m != nil {
+		return m.Regexp
+	}
+	return false
+}
+
+func (m *SearchRequest) GetCorpus() []string {
+	if m != nil {
+		return m.Corpus
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetRoot() []string {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetLanguage() []string {
+	if m != nil {
+		return m.Language
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetContextLines() int32 {
+	if m != nil {
+		return m.ContextLines
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type SearchReply struct {
+	Hit                  []*SearchReply_Hit `protobuf:"bytes,1,rep,name=hit,proto3" json:"hit,omitempty"`
+	NextPageToken        string             `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SearchReply) Reset()         { *m = SearchReply{} }
+func (m *SearchReply) String() string { return proto.CompactTextString(m) }
+func (*SearchReply) ProtoMessage()    {}
+func (*SearchReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b99d343832230929, []int{1}
+}
+
+func (m *SearchReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchReply.Unmarshal(m, b)
+}
+func (m *SearchReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchReply.Marshal(b, m, deterministic)
+}
+func (m *SearchReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchReply.Merge(m, src)
+}
+func (m *SearchReply) XXX_Size() int {
+	return xxx_messageInfo_SearchReply.Size(m)
+}
+func (m *SearchReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchReply proto.InternalMessageInfo
+
+func (m *SearchReply) GetHit() []*SearchReply_Hit {
+	if m != nil {
+		return m.Hit
+	}
+	return nil
+}
+
+func (m *SearchReply) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type SearchReply_Line struct {
+	LineNumber           int32    `protobuf:"varint,1,opt,name=line_number,json=lineNumber,proto3" json:"line_number,omitempty"`
+	Text                 string   `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchReply_Line) Reset()         { *m = SearchReply_Line{} }
+func (m *SearchReply_Line) String() string { return proto.CompactTextString(m) }
+func (*SearchReply_Line) ProtoMessage()    {}
+func (*SearchReply_Line) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b99d343832230929, []int{1, 0}
+}
+
+func (m *SearchReply_Line) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchReply_Line.Unmarshal(m, b)
+}
+func (m *SearchReply_Line) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchReply_Line.Marshal(b, m, deterministic)
+}
+func (m *SearchReply_Line) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchReply_Line.Merge(m, src)
+}
+func (m *SearchReply_Line) XXX_Size() int {
+	return xxx_messageInfo_SearchReply_Line.Size(m)
+}
+func (m *SearchReply_Line) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchReply_Line.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchReply_Line proto.InternalMessageInfo
+
+func (m *SearchReply_Line) GetLineNumber() int32 {
+	if m != nil {
+		return m.LineNumber
+	}
+	return 0
+}
+
+func (m *SearchReply_Line) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+type SearchReply_Hit struct {
+	FileTicket           string                                      `protobuf:"bytes,1,opt,name=file_ticket,json=fileTicket,proto3" json:"file_ticket,omitempty"`
+	Span                 *common_go_proto.Span                       `protobuf:"bytes,2,opt,name=span,proto3" json:"span,omitempty"`
+	Snippet              []*SearchReply_Line                         `protobuf:"bytes,3,rep,name=snippet,proto3" json:"snippet,omitempty"`
+	Reference            []*xref_go_proto.DecorationsReply_Reference `protobuf:"bytes,4,rep,name=reference,proto3" json:"reference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *SearchReply_Hit) Reset()         { *m = SearchReply_Hit{} }
+func (m *SearchReply_Hit) String() string { return proto.CompactTextString(m) }
+func (*SearchReply_Hit) ProtoMessage()    {}
+func (*SearchReply_Hit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b99d343832230929, []int{1, 1}
+}
+
+func (m *SearchReply_Hit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchReply_Hit.Unmarshal(m, b)
+}
+func (m *SearchReply_Hit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchReply_Hit.Marshal(b, m, deterministic)
+}
+func (m *SearchReply_Hit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchReply_Hit.Merge(m, src)
+}
+func (m *SearchReply_Hit) XXX_Size() int {
+	return xxx_messageInfo_SearchReply_Hit.Size(m)
+}
+func (m *SearchReply_Hit) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchReply_Hit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchReply_Hit proto.InternalMessageInfo
+
+func (m *SearchReply_Hit) GetFileTicket() string {
+	if m != nil {
+		return m.FileTicket
+	}
+	return ""
+}
+
+func (m *SearchReply_Hit) GetSpan() *common_go_proto.Span {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
+func (m *SearchReply_Hit) GetSnippet() []*SearchReply_Line {
+	if m != nil {
+		return m.Snippet
+	}
+	return nil
+}
+
+func (m *SearchReply_Hit) GetReference() []*xref_go_proto.DecorationsReply_Reference {
+	if m != nil {
+		return m.Reference
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SearchRequest)(nil), "kythe.proto.SearchRequest")
+	proto.RegisterType((*SearchReply)(nil), "kythe.proto.SearchReply")
+	proto.RegisterType((*SearchReply_Line)(nil), "kythe.proto.SearchReply.Line")
+	proto.RegisterType((*SearchReply_Hit)(nil), "kythe.proto.SearchReply.Hit")
+}
+
+func init() { proto.RegisterFile("kythe/proto/search.proto", fileDescriptor_b99d343832230929) }
+
+var fileDescriptor_b99d343832230929 = []byte{
+	// 484 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xcf, 0x6b, 0xdb, 0x30,
+	0x14, 0xc7, 0x71, 0xf3, 0xa3, 0xc9, 0xf3, 0x42, 0x41, 0x8c, 0x22, 0xbc, 0x95, 0x86, 0x0e, 0xb6,
+	0x1c, 0x86, 0x0b, 0xde, 0x60, 0x87, 0xc1, 0x0e, 0x63, 0x83, 0x1e, 0xc6, 0x56, 0x94, 0x9e, 0x76,
+	0x31, 0xae, 0xf7, 0xe2, 0x88, 0x38, 0x92, 0x2a, 0xcb, 0x25, 0xe9, 0xdf, 0xb9, 0x7f, 0x66, 0x3b,
+	0x0d, 0x3d, 0xdb, 0x9b, 0x03, 0xeb, 0xc9, 0x7a, 0x9f, 0xf7, 0x7d, 0x7a, 0xdf, 0x27, 0xc9, 0xc0,
+	0x37, 0x7b, 0xb7, 0xc6, 0x4b, 0x63, 0xb5, 0xd3, 0x97, 0x15, 0x66, 0x36, 0x5f, 0xc7, 0x14, 0xb0,
+	0x90, 0x32, 0x4d, 0x10, 0x1d, 0xc8, 0x72, 0xbd, 0xdd, 0x6a, 0xd5, 0x66, 0x4e, 0xfb, 0x99, 0x9d,
+	0xc5, 0x55, 0xc3, 0x2f, 0x7e, 0x07, 0x30, 0x5b, 0xd2, 0x7e, 0x02, 0xef, 0x6a, 0xac, 0x1c, 0x7b,
+	0x0a, 0xa3, 0xbb, 0x1a, 0xed, 0x9e, 0x07, 0xf3, 0x60, 0x31, 0x15, 0x4d, 0xc0, 0x4e, 0x61, 0x6c,
+	0xb1, 0xc0, 0x9d, 0xe1, 0x47, 0xf3, 0x60, 0x31, 0x11, 0x6d, 0xe4, 0x79, 0xae, 0xad, 0xa9, 0x2b,
+	0x3e, 0x98, 0x0f, 0x16, 0x53, 0xd1, 0x46, 0x8c, 0xc1, 0xd0, 0x6a, 0xed, 0xf8, 0x90, 0x28, 0xad,
+	0x3d, 0x33, 0x99, 0x5b, 0xf3, 0x11, 0x6d, 0x4c, 0x6b, 0x16, 0xc1, 0xa4, 0xcc, 0x54, 0x51, 0x67,
+	0x05, 0xf2, 0x31, 0x69, 0xff, 0xc6, 0xec, 0x05, 0xcc, 0x72, 0xad, 0x1c, 0xee, 0x5c, 0x5a, 0x4a,
+	0x85, 0x15, 0x3f, 0x9e, 0x07, 0x8b, 0x91, 0x78, 0xd2, 0xc2, 0x2f, 0x9e, 0xb1, 0x67, 0x30, 0x35,
+	0x59, 0x81, 0x69, 0x25, 0x1f, 0x90, 0x4f, 0x48, 0x30, 0xf1, 0x60, 0x29, 0x1f, 0x90, 0x9d, 0x01,
+	0x50, 0xd2, 0xe9, 0x0d, 0x2a, 0x3e, 0xa5, 0xbe, 0x24, 0xbf, 0xf1, 0xe0, 0xe2, 0xd7, 0x11, 0x84,
+	0xdd, 0xf0, 0xa6, 0xdc, 0xb3, 0x18, 0x06, 0x6b, 0xe9, 0x78, 0x30, 0x1f, 0x2c, 0xc2, 0xe4, 0x79,
+	0xdc, 0x3b, 0xd9, 0xb8, 0x27, 0x8b, 0xaf, 0xa4, 0x13, 0x5e, 0xc8, 0x5e, 0xc2, 0x89, 0xf2, 0xee,
+	0x7a, 0x3d, 0x8e, 0xa8, 0xc7, 0xcc, 0xe3, 0xeb, 0xae, 0x4f, 0xf4, 0x1e, 0x86, 0xde, 0x2c, 0x3b,
+	0x87, 0xd0, 0x0f, 0x92, 0xaa, 0x7a, 0x7b, 0x8b, 0x96, 0x0e, 0x78, 0x24, 0xc0, 0xa3, 0xaf, 0x44,
+	0xfc, 0x09, 0xf9, 0xc9, 0xda, 0x5d, 0x68, 0x1d, 0xfd, 0x0c, 0x60, 0x70, 0x25, 0x9d, 0x2f, 0x5e,
+	0xc9, 0x12, 0x53, 0x27, 0xf3, 0x0d, 0xba, 0xf6, 0x76, 0xc0, 0xa3, 0x1b, 0x22, 0xec, 0x35, 0x0c,
+	0x2b, 0x93, 0x35, 0x16, 0xc2, 0x84, 0x1f, 0xd8, 0x6f, 0xdf, 0xc2, 0xd2, 0x64, 0x4a, 0x90, 0x8a,
+	0xbd, 0x83, 0xe3, 0x4a, 0x49, 0x63, 0xd0, 0xd1, 0xcd, 0x85, 0xc9, 0xd9, 0xa3, 0xf3, 0x7a, 0xef,
+	0xa2, 0x53, 0xb3, 0xcf, 0x30, 0xb5, 0xb8, 0x42, 0x8b, 0x2a, 0x47, 0xba, 0xde, 0x30, 0x79, 0x75,
+	0x50, 0xfa, 0x09, 0x73, 0x6d, 0x33, 0x27, 0xb5, 0xaa, 0x9a, 0x7a, 0xd1, 0xc9, 0xc5, 0xbf, 0xca,
+	0xe4, 0x5b, 0xf7, 0xee, 0x96, 0x68, 0xef, 0x65, 0x8e, 0xec, 0x03, 0x8c, 0x1b, 0xc0, 0xa2, 0xff,
+	0x3a, 0xa1, 0xd7, 0x19, 0xf1, 0xc7, 0x5c, 0x7e, 0x7c, 0x0b, 0xe7, 0xb9, 0xde, 0xc6, 0x85, 0xd6,
+	0x45, 0x89, 0xf1, 0x0f, 0xbc, 0x77, 0x5a, 0x97, 0x55, 0x5f, 0x7e, 0x1d, 0x7c, 0x3f, 0x69, 0xfe,
+	0x9d, 0xb4, 0xd0, 0x29, 0xa1, 0xdb, 0x31, 0x7d, 0xde, 0xfc, 0x19, 0x00, 0xf9, 0x5e, 0x8e, 0x64,
+	0x61, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SearchServiceClient is the client API for SearchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SearchServiceClient interface {
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchReply, error)
+}
+
+type searchServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSearchServiceClient(cc *grpc.ClientConn) SearchServiceClient {
+	return &searchServiceClient{cc}
+}
+
+func (c *searchServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchReply, error) {
+	out := new(SearchReply)
+	err := c.cc.Invoke(ctx, "/kythe.proto.SearchService/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchServiceServer is the server API for SearchService service.
+type SearchServiceServer interface {
+	Search(context.Context, *SearchRequest) (*SearchReply, error)
+}
+
+// UnimplementedSearchServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSearchServiceServer struct {
+}
+
+func (*UnimplementedSearchServiceServer) Search(ctx context.Context, req *SearchRequest) (*SearchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+
+func RegisterSearchServiceServer(s *grpc.Server, srv SearchServiceServer) {
+	s.RegisterService(&_SearchService_serviceDesc, srv)
+}
+
+func _SearchService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kythe.proto.SearchService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SearchService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "kythe.proto.SearchService",
+	HandlerType: (*SearchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _SearchService_Search_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kythe/proto/search.proto",
+}
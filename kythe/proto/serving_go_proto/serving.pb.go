@@ -99,7 +99,7 @@ func (x Relatives_Type) String() string {
 }
 
 func (Relatives_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fa5eced3c734cc8b, []int{15, 0}
+	return fileDescriptor_fa5eced3c734cc8b, []int{16, 0}
 }
 
 type Callgraph_Type int32
@@ -127,7 +127,35 @@ func (x Callgraph_Type) String() string {
 }
 
 func (Callgraph_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_fa5eced3c734cc8b, []int{16, 0}
+	return fileDescriptor_fa5eced3c734cc8b, []int{17, 0}
+}
+
+type TypeHierarchy_Type int32
+
+const (
+	TypeHierarchy_UNKNOWN    TypeHierarchy_Type = 0
+	TypeHierarchy_SUPERTYPES TypeHierarchy_Type = 1
+	TypeHierarchy_SUBTYPES   TypeHierarchy_Type = 2
+)
+
+var TypeHierarchy_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "SUPERTYPES",
+	2: "SUBTYPES",
+}
+
+var TypeHierarchy_Type_value = map[string]int32{
+	"UNKNOWN":    0,
+	"SUPERTYPES": 1,
+	"SUBTYPES":   2,
+}
+
+func (x TypeHierarchy_Type) String() string {
+	return proto.EnumName(TypeHierarchy_Type_name, int32(x))
+}
+
+func (TypeHierarchy_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fa5eced3c734cc8b, []int{18, 0}
 }
 
 type Node struct {
@@ -1729,6 +1757,7 @@ type IdentifierMatch_Node struct {
 	Ticket               string   `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
 	NodeKind             string   `protobuf:"bytes,2,opt,name=node_kind,json=nodeKind,proto3" json:"node_kind,omitempty"`
 	NodeSubkind          string   `protobuf:"bytes,3,opt,name=node_subkind,json=nodeSubkind,proto3" json:"node_subkind,omitempty"`
+	RefCount             int32    `protobuf:"varint,4,opt,name=ref_count,json=refCount,proto3" json:"ref_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1780,6 +1809,60 @@ func (m *IdentifierMatch_Node) GetNodeSubkind() string {
 	return ""
 }
 
+func (m *IdentifierMatch_Node) GetRefCount() int32 {
+	if m != nil {
+		return m.RefCount
+	}
+	return 0
+}
+
+type IdentifierPostings struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	QualifiedName        []string `protobuf:"bytes,2,rep,name=qualified_name,json=qualifiedName,proto3" json:"qualified_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IdentifierPostings) Reset()         { *m = IdentifierPostings{} }
+func (m *IdentifierPostings) String() string { return proto.CompactTextString(m) }
+func (*IdentifierPostings) ProtoMessage()    {}
+func (*IdentifierPostings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa5eced3c734cc8b, []int{15}
+}
+
+func (m *IdentifierPostings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IdentifierPostings.Unmarshal(m, b)
+}
+func (m *IdentifierPostings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IdentifierPostings.Marshal(b, m, deterministic)
+}
+func (m *IdentifierPostings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IdentifierPostings.Merge(m, src)
+}
+func (m *IdentifierPostings) XXX_Size() int {
+	return xxx_messageInfo_IdentifierPostings.Size(m)
+}
+func (m *IdentifierPostings) XXX_DiscardUnknown() {
+	xxx_messageInfo_IdentifierPostings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IdentifierPostings proto.InternalMessageInfo
+
+func (m *IdentifierPostings) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *IdentifierPostings) GetQualifiedName() []string {
+	if m != nil {
+		return m.QualifiedName
+	}
+	return nil
+}
+
 type Relatives struct {
 	Tickets              []string       `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
 	Type                 Relatives_Type `protobuf:"varint,2,opt,name=type,proto3,enum=kythe.proto.serving.Relatives_Type" json:"type,omitempty"`
@@ -1792,7 +1875,7 @@ func (m *Relatives) Reset()         { *m = Relatives{} }
 func (m *Relatives) String() string { return proto.CompactTextString(m) }
 func (*Relatives) ProtoMessage()    {}
 func (*Relatives) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fa5eced3c734cc8b, []int{15}
+	return fileDescriptor_fa5eced3c734cc8b, []int{16}
 }
 
 func (m *Relatives) XXX_Unmarshal(b []byte) error {
@@ -1839,7 +1922,7 @@ func (m *Callgraph) Reset()         { *m = Callgraph{} }
 func (m *Callgraph) String() string { return proto.CompactTextString(m) }
 func (*Callgraph) ProtoMessage()    {}
 func (*Callgraph) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fa5eced3c734cc8b, []int{16}
+	return fileDescriptor_fa5eced3c734cc8b, []int{17}
 }
 
 func (m *Callgraph) XXX_Unmarshal(b []byte) error {
@@ -1874,11 +1957,311 @@ func (m *Callgraph) GetType() Callgraph_Type {
 	return Callgraph_UNKNOWN
 }
 
+type TypeHierarchy struct {
+	Tickets              []string           `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Type                 TypeHierarchy_Type `protobuf:"varint,2,opt,name=type,proto3,enum=kythe.proto.serving.TypeHierarchy_Type" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *TypeHierarchy) Reset()         { *m = TypeHierarchy{} }
+func (m *TypeHierarchy) String() string { return proto.CompactTextString(m) }
+func (*TypeHierarchy) ProtoMessage()    {}
+func (*TypeHierarchy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa5eced3c734cc8b, []int{18}
+}
+
+func (m *TypeHierarchy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TypeHierarchy.Unmarshal(m, b)
+}
+func (m *TypeHierarchy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TypeHierarchy.Marshal(b, m, deterministic)
+}
+func (m *TypeHierarchy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypeHierarchy.Merge(m, src)
+}
+func (m *TypeHierarchy) XXX_Size() int {
+	return xxx_messageInfo_TypeHierarchy.Size(m)
+}
+func (m *TypeHierarchy) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypeHierarchy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypeHierarchy proto.InternalMessageInfo
+
+func (m *TypeHierarchy) GetTickets() []string {
+	if m != nil {
+		return m.Tickets
+	}
+	return nil
+}
+
+func (m *TypeHierarchy) GetType() TypeHierarchy_Type {
+	if m != nil {
+		return m.Type
+	}
+	return TypeHierarchy_UNKNOWN
+}
+
+type FunctionParameters struct {
+	Ticket               string                          `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind                 string                          `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Subkind              string                          `protobuf:"bytes,3,opt,name=subkind,proto3" json:"subkind,omitempty"`
+	MarkedSource         *common_go_proto.MarkedSource   `protobuf:"bytes,4,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	Parameter            []*FunctionParameters_Parameter `protobuf:"bytes,5,rep,name=parameter,proto3" json:"parameter,omitempty"`
+	ReturnTypeTicket     string                          `protobuf:"bytes,6,opt,name=return_type_ticket,json=returnTypeTicket,proto3" json:"return_type_ticket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *FunctionParameters) Reset()         { *m = FunctionParameters{} }
+func (m *FunctionParameters) String() string { return proto.CompactTextString(m) }
+func (*FunctionParameters) ProtoMessage()    {}
+func (*FunctionParameters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa5eced3c734cc8b, []int{19}
+}
+
+func (m *FunctionParameters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FunctionParameters.Unmarshal(m, b)
+}
+func (m *FunctionParameters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FunctionParameters.Marshal(b, m, deterministic)
+}
+func (m *FunctionParameters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FunctionParameters.Merge(m, src)
+}
+func (m *FunctionParameters) XXX_Size() int {
+	return xxx_messageInfo_FunctionParameters.Size(m)
+}
+func (m *FunctionParameters) XXX_DiscardUnknown() {
+	xxx_messageInfo_FunctionParameters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FunctionParameters proto.InternalMessageInfo
+
+func (m *FunctionParameters) GetTicket() string {
+	if m != nil {
+		return m.Ticket
+	}
+	return ""
+}
+
+func (m *FunctionParameters) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *FunctionParameters) GetSubkind() string {
+	if m != nil {
+		return m.Subkind
+	}
+	return ""
+}
+
+func (m *FunctionParameters) GetMarkedSource() *common_go_proto.MarkedSource {
+	if m != nil {
+		return m.MarkedSource
+	}
+	return nil
+}
+
+func (m *FunctionParameters) GetParameter() []*FunctionParameters_Parameter {
+	if m != nil {
+		return m.Parameter
+	}
+	return nil
+}
+
+func (m *FunctionParameters) GetReturnTypeTicket() string {
+	if m != nil {
+		return m.ReturnTypeTicket
+	}
+	return ""
+}
+
+type FunctionParameters_Parameter struct {
+	Ticket               string                        `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Ordinal              int32                         `protobuf:"varint,2,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	Kind                 string                        `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Subkind              string                        `protobuf:"bytes,4,opt,name=subkind,proto3" json:"subkind,omitempty"`
+	MarkedSource         *common_go_proto.MarkedSource `protobuf:"bytes,5,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	TypeTicket           string                        `protobuf:"bytes,6,opt,name=type_ticket,json=typeTicket,proto3" json:"type_ticket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *FunctionParameters_Parameter) Reset()         { *m = FunctionParameters_Parameter{} }
+func (m *FunctionParameters_Parameter) String() string { return proto.CompactTextString(m) }
+func (*FunctionParameters_Parameter) ProtoMessage()    {}
+func (*FunctionParameters_Parameter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa5eced3c734cc8b, []int{19, 0}
+}
+
+func (m *FunctionParameters_Parameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FunctionParameters_Parameter.Unmarshal(m, b)
+}
+func (m *FunctionParameters_Parameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FunctionParameters_Parameter.Marshal(b, m, deterministic)
+}
+func (m *FunctionParameters_Parameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FunctionParameters_Parameter.Merge(m, src)
+}
+func (m *FunctionParameters_Parameter) XXX_Size() int {
+	return xxx_messageInfo_FunctionParameters_Parameter.Size(m)
+}
+func (m *FunctionParameters_Parameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_FunctionParameters_Parameter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FunctionParameters_Parameter proto.InternalMessageInfo
+
+func (m *FunctionParameters_Parameter) GetTicket() string {
+	if m != nil {
+		return m.Ticket
+	}
+	return ""
+}
+
+func (m *FunctionParameters_Parameter) GetOrdinal() int32 {
+	if m != nil {
+		return m.Ordinal
+	}
+	return 0
+}
+
+func (m *FunctionParameters_Parameter) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *FunctionParameters_Parameter) GetSubkind() string {
+	if m != nil {
+		return m.Subkind
+	}
+	return ""
+}
+
+func (m *FunctionParameters_Parameter) GetMarkedSource() *common_go_proto.MarkedSource {
+	if m != nil {
+		return m.MarkedSource
+	}
+	return nil
+}
+
+func (m *FunctionParameters_Parameter) GetTypeTicket() string {
+	if m != nil {
+		return m.TypeTicket
+	}
+	return ""
+}
+
+type SearchPostings struct {
+	Trigram              []byte   `protobuf:"bytes,1,opt,name=trigram,proto3" json:"trigram,omitempty"`
+	FileTicket           []string `protobuf:"bytes,2,rep,name=file_ticket,json=fileTicket,proto3" json:"file_ticket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchPostings) Reset()         { *m = SearchPostings{} }
+func (m *SearchPostings) String() string { return proto.CompactTextString(m) }
+func (*SearchPostings) ProtoMessage()    {}
+func (*SearchPostings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa5eced3c734cc8b, []int{20}
+}
+
+func (m *SearchPostings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchPostings.Unmarshal(m, b)
+}
+func (m *SearchPostings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchPostings.Marshal(b, m, deterministic)
+}
+func (m *SearchPostings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchPostings.Merge(m, src)
+}
+func (m *SearchPostings) XXX_Size() int {
+	return xxx_messageInfo_SearchPostings.Size(m)
+}
+func (m *SearchPostings) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchPostings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchPostings proto.InternalMessageInfo
+
+func (m *SearchPostings) GetTrigram() []byte {
+	if m != nil {
+		return m.Trigram
+	}
+	return nil
+}
+
+func (m *SearchPostings) GetFileTicket() []string {
+	if m != nil {
+		return m.FileTicket
+	}
+	return nil
+}
+
+type SearchFile struct {
+	Ticket               string   `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Language             []string `protobuf:"bytes,2,rep,name=language,proto3" json:"language,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchFile) Reset()         { *m = SearchFile{} }
+func (m *SearchFile) String() string { return proto.CompactTextString(m) }
+func (*SearchFile) ProtoMessage()    {}
+func (*SearchFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fa5eced3c734cc8b, []int{21}
+}
+
+func (m *SearchFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchFile.Unmarshal(m, b)
+}
+func (m *SearchFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchFile.Marshal(b, m, deterministic)
+}
+func (m *SearchFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchFile.Merge(m, src)
+}
+func (m *SearchFile) XXX_Size() int {
+	return xxx_messageInfo_SearchFile.Size(m)
+}
+func (m *SearchFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchFile proto.InternalMessageInfo
+
+func (m *SearchFile) GetTicket() string {
+	if m != nil {
+		return m.Ticket
+	}
+	return ""
+}
+
+func (m *SearchFile) GetLanguage() []string {
+	if m != nil {
+		return m.Language
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("kythe.proto.serving.FileDirectory_Kind", FileDirectory_Kind_name, FileDirectory_Kind_value)
 	proto.RegisterEnum("kythe.proto.serving.FileDecorations_Override_Kind", FileDecorations_Override_Kind_name, FileDecorations_Override_Kind_value)
 	proto.RegisterEnum("kythe.proto.serving.Relatives_Type", Relatives_Type_name, Relatives_Type_value)
 	proto.RegisterEnum("kythe.proto.serving.Callgraph_Type", Callgraph_Type_name, Callgraph_Type_value)
+	proto.RegisterEnum("kythe.proto.serving.TypeHierarchy_Type", TypeHierarchy_Type_name, TypeHierarchy_Type_value)
 	proto.RegisterType((*Node)(nil), "kythe.proto.serving.Node")
 	proto.RegisterType((*Edge)(nil), "kythe.proto.serving.Edge")
 	proto.RegisterType((*EdgeGroup)(nil), "kythe.proto.serving.EdgeGroup")
@@ -1905,127 +2288,148 @@ func init() {
 	proto.RegisterType((*Document)(nil), "kythe.proto.serving.Document")
 	proto.RegisterType((*IdentifierMatch)(nil), "kythe.proto.serving.IdentifierMatch")
 	proto.RegisterType((*IdentifierMatch_Node)(nil), "kythe.proto.serving.IdentifierMatch.Node")
+	proto.RegisterType((*IdentifierPostings)(nil), "kythe.proto.serving.IdentifierPostings")
 	proto.RegisterType((*Relatives)(nil), "kythe.proto.serving.Relatives")
 	proto.RegisterType((*Callgraph)(nil), "kythe.proto.serving.Callgraph")
+	proto.RegisterType((*TypeHierarchy)(nil), "kythe.proto.serving.TypeHierarchy")
+	proto.RegisterType((*FunctionParameters)(nil), "kythe.proto.serving.FunctionParameters")
+	proto.RegisterType((*FunctionParameters_Parameter)(nil), "kythe.proto.serving.FunctionParameters.Parameter")
+	proto.RegisterType((*SearchPostings)(nil), "kythe.proto.serving.SearchPostings")
+	proto.RegisterType((*SearchFile)(nil), "kythe.proto.serving.SearchFile")
 }
 
 func init() { proto.RegisterFile("kythe/proto/serving.proto", fileDescriptor_fa5eced3c734cc8b) }
 
 var fileDescriptor_fa5eced3c734cc8b = []byte{
-	// 1826 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x5f, 0x73, 0x1b, 0x49,
-	0x11, 0xbf, 0x95, 0x57, 0xb2, 0xb6, 0x57, 0xb6, 0x75, 0x93, 0x70, 0xa5, 0x28, 0x45, 0xce, 0xb7,
-	0x29, 0x38, 0x53, 0x97, 0xc8, 0xe0, 0x00, 0x57, 0x45, 0xea, 0x2e, 0x95, 0xd8, 0x0a, 0xe7, 0x8b,
-	0x4f, 0xbe, 0x1a, 0x99, 0xe4, 0xe0, 0x81, 0xad, 0xf5, 0xee, 0x58, 0xde, 0xb2, 0x34, 0x23, 0x76,
-	0x47, 0x49, 0x5c, 0x7c, 0x84, 0x2b, 0xf8, 0x0a, 0xf0, 0x46, 0x51, 0x05, 0x2f, 0x3c, 0xf0, 0x09,
-	0xa0, 0xe0, 0x3b, 0xc0, 0xc7, 0xa0, 0x78, 0xa6, 0xa6, 0x67, 0x76, 0x35, 0xb2, 0x25, 0x5b, 0x18,
-	0x9e, 0x34, 0xd3, 0xdb, 0xdd, 0xd3, 0xfd, 0xeb, 0xe9, 0x3f, 0x23, 0xb8, 0x73, 0x76, 0x2e, 0x4f,
-	0xd9, 0xf6, 0x38, 0x13, 0x52, 0x6c, 0xe7, 0x2c, 0x7b, 0x9d, 0xf2, 0x41, 0x07, 0x77, 0xe4, 0x16,
-	0x7e, 0xd2, 0x9b, 0x8e, 0xf9, 0xd4, 0x6e, 0xd9, 0xfc, 0xb1, 0x18, 0x8d, 0x04, 0xd7, 0x1c, 0xc1,
-	0x9f, 0x1d, 0x70, 0x7b, 0x22, 0x61, 0xe4, 0x3d, 0xa8, 0xc9, 0x34, 0x3e, 0x63, 0xb2, 0xe5, 0x6c,
-	0x3a, 0x5b, 0x1e, 0x35, 0x3b, 0xf2, 0x00, 0xdc, 0x93, 0x28, 0x96, 0xad, 0xca, 0xe6, 0xca, 0x96,
-	0xbf, 0xd3, 0xea, 0xd8, 0xea, 0x8d, 0xa6, 0xe7, 0x51, 0x2c, 0x29, 0x72, 0x91, 0x23, 0xb8, 0x95,
-	0xb0, 0x93, 0x94, 0xa7, 0x32, 0x15, 0x3c, 0x1c, 0x8a, 0x38, 0x52, 0x8b, 0xd6, 0xca, 0xa6, 0xb3,
-	0xe5, 0xef, 0xdc, 0xef, 0xcc, 0xb1, 0xad, 0xd3, 0x7d, 0x3b, 0x8e, 0x78, 0xc2, 0x92, 0xa7, 0x3c,
-	0x3e, 0x15, 0x19, 0x25, 0x53, 0xf9, 0x03, 0x23, 0x4e, 0x08, 0xb8, 0x59, 0xc4, 0xcf, 0x5a, 0xee,
-	0xa6, 0xb3, 0xe5, 0x50, 0x5c, 0x07, 0x7f, 0x77, 0xc0, 0xed, 0x26, 0x03, 0x46, 0xbe, 0x07, 0xb5,
-	0x5c, 0x4c, 0xb2, 0x98, 0xa1, 0xe1, 0xfe, 0xce, 0x9d, 0xb9, 0xa7, 0x28, 0x1f, 0xa9, 0x61, 0x54,
-	0xfa, 0xce, 0x52, 0x9e, 0xb4, 0x2a, 0xe8, 0x29, 0xae, 0x49, 0x0b, 0x56, 0x45, 0x96, 0xa4, 0x3c,
-	0x1a, 0xb6, 0xaa, 0x9b, 0xce, 0x56, 0x95, 0x16, 0x5b, 0x75, 0x80, 0x8c, 0xb2, 0x01, 0x93, 0xc6,
-	0x8d, 0xab, 0x0e, 0xd0, 0x8c, 0x25, 0x68, 0xee, 0x32, 0xa0, 0x05, 0x7f, 0x74, 0xc0, 0x53, 0xae,
-	0xfc, 0x38, 0x13, 0x93, 0x71, 0x69, 0x9c, 0x63, 0x19, 0xf7, 0x31, 0xb8, 0x2c, 0x19, 0x30, 0x13,
-	0x84, 0x05, 0x38, 0x16, 0x1a, 0x70, 0x45, 0x51, 0xa0, 0xdd, 0x9f, 0x82, 0x64, 0x7c, 0x70, 0x96,
-	0xf5, 0xc1, 0x02, 0xa4, 0x32, 0x03, 0x48, 0xf0, 0x0f, 0x07, 0x1a, 0x5f, 0x46, 0x03, 0x96, 0x28,
-	0xd5, 0x7d, 0x26, 0x6f, 0x12, 0x82, 0xef, 0x43, 0x75, 0xa0, 0x8c, 0x35, 0x2e, 0xdd, 0xbb, 0xda,
-	0x25, 0xaa, 0x99, 0xc9, 0x7d, 0xf0, 0xa5, 0x90, 0xd1, 0x30, 0x54, 0xce, 0xe5, 0x18, 0x8f, 0xea,
-	0xb3, 0x4a, 0xcb, 0xa1, 0x80, 0x64, 0xc5, 0x9f, 0x93, 0x4f, 0x00, 0xc6, 0xd1, 0x80, 0x85, 0x29,
-	0x4f, 0xd8, 0x5b, 0x13, 0x82, 0xf9, 0xfa, 0x95, 0x13, 0xfb, 0x8a, 0x8b, 0x7a, 0xe3, 0x62, 0x19,
-	0x1c, 0x83, 0x57, 0xd2, 0xc9, 0x5d, 0xf0, 0xd4, 0x51, 0xa1, 0x15, 0x91, 0xba, 0x22, 0xbc, 0x50,
-	0x51, 0xf9, 0x26, 0x00, 0x7e, 0x8c, 0xc5, 0x84, 0x4b, 0x03, 0x12, 0xb2, 0xef, 0x2a, 0x02, 0xb9,
-	0x03, 0x75, 0xb4, 0xe3, 0x8c, 0x9d, 0xa3, 0xa5, 0x1e, 0x5d, 0x55, 0xfb, 0x17, 0xec, 0x3c, 0xf8,
-	0xda, 0x81, 0xba, 0x32, 0x56, 0x1d, 0x34, 0xc3, 0xe7, 0xcc, 0xf0, 0x91, 0xfb, 0xb0, 0xa6, 0xf1,
-	0x0a, 0x4d, 0x6e, 0xea, 0x1b, 0xdb, 0xd0, 0xc4, 0x23, 0x9d, 0xa1, 0x4f, 0xc0, 0x47, 0x38, 0x42,
-	0x0d, 0xa8, 0xbe, 0xa4, 0xd7, 0x01, 0x8a, 0x96, 0xe7, 0xb8, 0x0e, 0xfe, 0x56, 0x81, 0xb5, 0xe7,
-	0xe9, 0x90, 0xed, 0xa5, 0x19, 0x8b, 0xa5, 0xc8, 0xce, 0xc9, 0xa7, 0x50, 0x65, 0x5c, 0x66, 0xca,
-	0x6e, 0x85, 0xde, 0xd6, 0x5c, 0x65, 0x33, 0x22, 0x9d, 0xae, 0xe2, 0xa7, 0x5a, 0x8c, 0x7c, 0x1b,
-	0x1a, 0xf9, 0xe4, 0x38, 0x29, 0x3e, 0xb6, 0x9c, 0xcd, 0x95, 0x2d, 0x0f, 0x03, 0x35, 0x43, 0x57,
-	0xf1, 0x3c, 0x49, 0x87, 0x96, 0x77, 0x05, 0x1b, 0x28, 0xb2, 0xf6, 0xaf, 0xfd, 0x4b, 0xa8, 0xa2,
-	0x72, 0xf2, 0xd8, 0xca, 0x8c, 0xf5, 0x9d, 0x0f, 0x97, 0x30, 0x4a, 0x85, 0xc9, 0xa4, 0x10, 0x01,
-	0x97, 0x47, 0x23, 0x56, 0xe4, 0xbc, 0x5a, 0x93, 0x0f, 0xa0, 0x71, 0x3c, 0x49, 0x87, 0x49, 0x18,
-	0x0b, 0x7e, 0x92, 0x0e, 0xd0, 0x5b, 0x8f, 0xfa, 0x48, 0xdb, 0x45, 0x52, 0xf0, 0x00, 0x5c, 0x8c,
-	0xb5, 0x0f, 0xab, 0x3f, 0xe9, 0xbd, 0xe8, 0x1d, 0xbe, 0xea, 0x35, 0xdf, 0x21, 0x75, 0x70, 0x9f,
-	0xef, 0x1f, 0x74, 0x9b, 0x0e, 0x59, 0x03, 0x6f, 0x6f, 0x9f, 0x76, 0x77, 0x8f, 0x0e, 0xe9, 0x4f,
-	0x9b, 0x95, 0xe0, 0x77, 0x0e, 0xf8, 0xbb, 0x22, 0x1b, 0x4f, 0x72, 0x2a, 0x84, 0xcc, 0xc9, 0x13,
-	0xa8, 0xc5, 0xb8, 0x45, 0x04, 0xfc, 0x05, 0x36, 0x5b, 0x12, 0xc5, 0xda, 0x88, 0xb5, 0x5f, 0x41,
-	0x4d, 0x53, 0x54, 0x7d, 0x2e, 0x55, 0x61, 0x7d, 0xd6, 0x3b, 0xac, 0x8d, 0x42, 0x18, 0xec, 0x28,
-	0xae, 0x97, 0xf1, 0xab, 0x07, 0xae, 0x82, 0x6a, 0x61, 0xd9, 0x27, 0xe0, 0x4a, 0xf6, 0x56, 0x5f,
-	0xb8, 0x06, 0xc5, 0x35, 0x69, 0x43, 0x9d, 0xf1, 0x58, 0x24, 0x29, 0x1f, 0x98, 0x0b, 0x5d, 0xee,
-	0x83, 0x7f, 0x3a, 0xe0, 0xd1, 0xe8, 0x8d, 0x2e, 0xe2, 0x0b, 0xb5, 0x7e, 0x00, 0x8d, 0x5c, 0x46,
-	0x99, 0x0c, 0xc5, 0xc9, 0x49, 0xce, 0x8a, 0x9c, 0xf1, 0x91, 0x76, 0x88, 0x24, 0x4c, 0x2a, 0x9e,
-	0x14, 0x0c, 0x2b, 0x26, 0xa9, 0x78, 0x62, 0x3e, 0xab, 0x8c, 0xe0, 0xe9, 0x78, 0xcc, 0x64, 0x88,
-	0x52, 0xd8, 0x13, 0xaa, 0xb4, 0x61, 0x88, 0x7d, 0x45, 0x23, 0xef, 0x83, 0x5f, 0x30, 0x31, 0x9e,
-	0x98, 0x7a, 0x0e, 0x86, 0xd4, 0xe5, 0x09, 0xd9, 0x86, 0x5b, 0x36, 0x40, 0x93, 0x4c, 0xb7, 0xa9,
-	0x1a, 0x1a, 0x4b, 0x2c, 0x9c, 0xcc, 0x97, 0xe0, 0xb7, 0x15, 0x58, 0x9f, 0x6d, 0x54, 0x57, 0x21,
-	0x77, 0xa9, 0xb9, 0x14, 0x68, 0xba, 0x9a, 0x86, 0x68, 0x3e, 0x00, 0x37, 0x1f, 0x47, 0x1c, 0xad,
-	0x5b, 0xd0, 0x23, 0xfa, 0xe3, 0x88, 0x53, 0xe4, 0x52, 0xd5, 0xd8, 0xd8, 0x6f, 0xac, 0x2c, 0xb6,
-	0xe4, 0x31, 0x34, 0x4a, 0x44, 0x94, 0xbe, 0xd5, 0x6b, 0xf4, 0x15, 0xd0, 0xa8, 0xcd, 0x22, 0x20,
-	0xea, 0x8b, 0x80, 0x28, 0x5b, 0xb1, 0x37, 0x6d, 0xc5, 0x9f, 0xbb, 0xf5, 0x95, 0xa6, 0x1b, 0xfc,
-	0x61, 0x15, 0x36, 0x30, 0xfb, 0x58, 0x2c, 0x34, 0x73, 0x4e, 0x1e, 0x82, 0xab, 0x12, 0xf9, 0xca,
-	0xb6, 0xa0, 0x64, 0x28, 0xb2, 0x91, 0x43, 0x80, 0xa4, 0x94, 0x36, 0x9d, 0x61, 0x7b, 0x71, 0x9a,
-	0x4f, 0x0f, 0xea, 0x4c, 0xd7, 0xd4, 0x52, 0x61, 0xb5, 0x3d, 0xdd, 0x06, 0x96, 0x68, 0x7b, 0x14,
-	0x88, 0x5e, 0x85, 0xd3, 0x41, 0x24, 0x37, 0x75, 0x70, 0xa9, 0x01, 0xe6, 0x5d, 0x2d, 0xbe, 0x37,
-	0x95, 0x26, 0x2f, 0x61, 0xc3, 0xe8, 0x14, 0xaf, 0x59, 0x96, 0xa5, 0x09, 0x6b, 0x55, 0x51, 0xe1,
-	0xc3, 0xa5, 0x9c, 0x3b, 0x34, 0x42, 0x74, 0x5d, 0x6b, 0x29, 0xf6, 0xe4, 0x53, 0x80, 0x24, 0x8d,
-	0x06, 0x5c, 0xe4, 0x32, 0x8d, 0x5b, 0xb5, 0x39, 0x9d, 0xce, 0x04, 0x7e, 0xaf, 0xe4, 0xa2, 0x96,
-	0x44, 0xfb, 0x2f, 0x0e, 0xc0, 0xf4, 0x20, 0xf2, 0x43, 0xa8, 0x45, 0xe8, 0x83, 0x89, 0xd7, 0xfc,
-	0x1e, 0x52, 0x66, 0x39, 0x35, 0xdc, 0x73, 0x6f, 0xfc, 0x7b, 0x25, 0xf2, 0x55, 0x93, 0x1d, 0x1a,
-	0xde, 0x8f, 0xe0, 0xdd, 0x4b, 0xf0, 0x9a, 0xb4, 0x68, 0x5e, 0x04, 0x8e, 0x7c, 0x0b, 0xd6, 0x73,
-	0x36, 0x8a, 0xb8, 0x4c, 0xe3, 0x30, 0x8f, 0xc5, 0x98, 0x99, 0xbb, 0xbf, 0x56, 0x50, 0xfb, 0x8a,
-	0xd8, 0xfe, 0x53, 0x05, 0xea, 0x25, 0x26, 0xf7, 0x00, 0x0c, 0xc8, 0xaa, 0x4c, 0xe9, 0xd4, 0xb4,
-	0x28, 0xd6, 0xf7, 0x84, 0x71, 0x63, 0xb2, 0x45, 0x21, 0x8f, 0xe0, 0x1b, 0xd3, 0x9d, 0x6d, 0xa4,
-	0xf6, 0xe3, 0xf6, 0xf4, 0xa3, 0x65, 0xe8, 0x73, 0x83, 0xc0, 0x0a, 0x76, 0xa6, 0x9d, 0xff, 0x2a,
-	0xaa, 0x76, 0x93, 0xea, 0xc2, 0xda, 0x28, 0xca, 0xce, 0x58, 0x12, 0x9a, 0x79, 0xca, 0xc5, 0x40,
-	0x6c, 0xce, 0x8b, 0xe9, 0x17, 0xc8, 0xd8, 0x47, 0x3e, 0xda, 0x18, 0x59, 0xbb, 0x20, 0x30, 0x4d,
-	0x6b, 0x0d, 0xbc, 0xc3, 0x97, 0x5d, 0x4a, 0xf7, 0xf7, 0xba, 0xfd, 0xe6, 0x3b, 0xaa, 0x87, 0x75,
-	0xbf, 0x3a, 0xea, 0xf6, 0xf6, 0xfa, 0x4d, 0x27, 0xf8, 0x2b, 0xc0, 0x6d, 0x1c, 0xe2, 0x76, 0x33,
-	0x91, 0xe7, 0x94, 0x9d, 0xb0, 0x8c, 0xf1, 0x98, 0xe5, 0xaa, 0x00, 0x8f, 0x58, 0x36, 0x60, 0xe1,
-	0x9b, 0x54, 0x9e, 0xb6, 0x56, 0xb1, 0x75, 0x78, 0x48, 0x79, 0x95, 0xca, 0xd3, 0xcb, 0x23, 0x89,
-	0x33, 0x67, 0x24, 0xf9, 0x11, 0xf8, 0x86, 0x89, 0x8b, 0x84, 0x61, 0x39, 0xb9, 0x32, 0xf9, 0x40,
-	0x73, 0xe3, 0x43, 0xa4, 0x3b, 0x3b, 0x19, 0x6e, 0x2f, 0x9c, 0xdc, 0x2e, 0x5a, 0xde, 0x99, 0x19,
-	0x15, 0xe9, 0xcc, 0x14, 0xa8, 0xf3, 0xf7, 0xd1, 0xf2, 0xba, 0xe6, 0x8d, 0x86, 0xe4, 0x21, 0x34,
-	0xf5, 0xf8, 0x99, 0x95, 0x8c, 0xba, 0xff, 0xe0, 0xcc, 0xb2, 0x81, 0xdf, 0x2c, 0x24, 0xef, 0x01,
-	0xa4, 0x3c, 0x16, 0xa3, 0xf1, 0x90, 0x49, 0x86, 0xf7, 0xa7, 0x4e, 0x2d, 0xca, 0xe5, 0x68, 0xd7,
-	0x6e, 0x12, 0xed, 0xf6, 0x4b, 0xf0, 0x29, 0x1b, 0x46, 0x92, 0x25, 0x88, 0xdf, 0x43, 0x70, 0x11,
-	0xf4, 0x6b, 0x47, 0x71, 0x64, 0x5b, 0x3c, 0xe6, 0xb7, 0xff, 0xed, 0x40, 0x6d, 0x37, 0x1a, 0x0e,
-	0x59, 0x46, 0x1e, 0x43, 0x2d, 0xc6, 0x95, 0xd1, 0xba, 0x54, 0x21, 0x34, 0x22, 0xe4, 0x43, 0xd8,
-	0x28, 0xb3, 0xd8, 0x68, 0xd1, 0x69, 0x57, 0x26, 0xb7, 0x39, 0xe5, 0x12, 0x1e, 0x2b, 0x37, 0xc1,
-	0x83, 0x3c, 0x81, 0xba, 0x3a, 0x26, 0x4f, 0x25, 0x33, 0x65, 0x7f, 0x29, 0x73, 0x4b, 0xa1, 0xf6,
-	0x6f, 0x2a, 0x50, 0x5d, 0xfc, 0x16, 0xbb, 0x38, 0x5c, 0xe9, 0xba, 0x60, 0x0f, 0x57, 0x0a, 0x2e,
-	0x53, 0x48, 0x2b, 0xcb, 0x9f, 0x5f, 0x54, 0xd3, 0xaf, 0xa0, 0x91, 0xe9, 0x70, 0xea, 0xe4, 0xd1,
-	0x57, 0xf7, 0x07, 0xcb, 0x5f, 0x5d, 0xeb, 0x32, 0x50, 0x3f, 0xb3, 0x6e, 0xc6, 0x67, 0x65, 0x14,
-	0x35, 0x2c, 0xdf, 0x5d, 0x5e, 0xa7, 0x8e, 0x50, 0x11, 0xd2, 0xf6, 0xaf, 0x1c, 0x70, 0xff, 0x2f,
-	0x6f, 0x97, 0x32, 0xd9, 0x75, 0xa8, 0x6f, 0x98, 0xec, 0xed, 0xdc, 0x7e, 0xb3, 0xcd, 0x0b, 0xda,
-	0x6d, 0xa8, 0xda, 0xaf, 0x34, 0xbd, 0xb9, 0xe2, 0x85, 0x76, 0x29, 0xca, 0xee, 0xa5, 0x28, 0x7f,
-	0xee, 0xd6, 0xa1, 0xe9, 0x07, 0xbf, 0xaf, 0x40, 0x7d, 0x4f, 0xc4, 0x93, 0x11, 0xe3, 0x72, 0xe1,
-	0x4c, 0x78, 0xe9, 0x66, 0x57, 0x6e, 0x74, 0xb3, 0xef, 0x40, 0x3d, 0x8b, 0xde, 0x84, 0x38, 0x4a,
-	0x1a, 0x7b, 0xb3, 0xe8, 0xcd, 0x91, 0x99, 0x26, 0x87, 0x29, 0xfe, 0x45, 0xb2, 0xf0, 0x1f, 0x87,
-	0x83, 0x94, 0x9f, 0x51, 0xe4, 0x52, 0xde, 0xc5, 0xa7, 0xca, 0x3b, 0x63, 0x6d, 0x55, 0x3f, 0x10,
-	0x90, 0x66, 0x22, 0x53, 0x94, 0x91, 0xda, 0x75, 0x83, 0x93, 0x2e, 0x23, 0xf7, 0x61, 0x2d, 0x31,
-	0x28, 0xb0, 0x24, 0x3c, 0x3e, 0xc7, 0x31, 0xd4, 0xa3, 0x8d, 0x29, 0xf1, 0xd9, 0x79, 0xf0, 0x2f,
-	0x07, 0x36, 0xf6, 0x13, 0xc6, 0x65, 0x7a, 0x92, 0xb2, 0xec, 0x8b, 0x48, 0xc6, 0xa7, 0xaa, 0xc7,
-	0xff, 0x62, 0x12, 0x0d, 0x15, 0x25, 0x09, 0xf1, 0x85, 0xa6, 0xa1, 0x5b, 0x2b, 0xa9, 0x3d, 0xf5,
-	0x54, 0xbb, 0x0b, 0xde, 0x71, 0x94, 0xb3, 0xd0, 0x7a, 0xc3, 0xd5, 0x15, 0x01, 0x3f, 0x7e, 0x62,
-	0x6c, 0xd5, 0xa9, 0xf2, 0x9d, 0xb9, 0xb6, 0x5e, 0x38, 0xd7, 0xb2, 0xbd, 0xfd, 0xf3, 0x6b, 0xfe,
-	0x02, 0xbb, 0x0b, 0x9e, 0xe2, 0x0b, 0xad, 0x21, 0xa7, 0xae, 0x08, 0x2f, 0x4c, 0x39, 0xc0, 0x8f,
-	0xf9, 0xe4, 0xb8, 0x1c, 0x01, 0x3c, 0xea, 0x2b, 0x5a, 0x5f, 0x93, 0x82, 0x5f, 0xab, 0xb7, 0x91,
-	0xca, 0xc3, 0xf4, 0x35, 0xcb, 0x55, 0xc1, 0xd5, 0x7a, 0xf5, 0xa3, 0xd0, 0xa3, 0xc5, 0x96, 0x7c,
-	0x0c, 0xae, 0x3c, 0x1f, 0x6b, 0xf7, 0xd6, 0x17, 0x14, 0x8d, 0x52, 0x4f, 0xe7, 0xe8, 0x7c, 0xcc,
-	0x28, 0x0a, 0x04, 0x1d, 0x70, 0xd5, 0x6e, 0xf6, 0x91, 0xea, 0xc3, 0xea, 0x97, 0x4f, 0x69, 0xb7,
-	0x77, 0xd4, 0x6f, 0x3a, 0xa4, 0x01, 0xf5, 0xdd, 0xcf, 0xf6, 0x0f, 0xf6, 0x68, 0xb7, 0xd7, 0xac,
-	0x04, 0x5f, 0x3b, 0xe0, 0xa9, 0x8c, 0x1e, 0x64, 0xd1, 0xf8, 0xf4, 0x7f, 0x34, 0xa8, 0xd4, 0x63,
-	0x1b, 0xf4, 0xd1, 0x3c, 0x83, 0x00, 0x6a, 0xbb, 0x4f, 0x0f, 0x0e, 0xba, 0xb4, 0xe9, 0x94, 0xeb,
-	0x6e, 0xb3, 0xf2, 0xec, 0x11, 0xbc, 0x1f, 0x8b, 0x51, 0x67, 0x20, 0xc4, 0x60, 0xc8, 0x3a, 0x09,
-	0x7b, 0x2d, 0x85, 0x18, 0xe6, 0xf6, 0x61, 0x3f, 0x6b, 0x9a, 0xd3, 0xc2, 0x81, 0x08, 0x91, 0x72,
-	0x5c, 0xc3, 0x9f, 0x47, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xb7, 0x0e, 0x52, 0xda, 0x0a, 0x15,
+	// 2066 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x5f, 0x4a, 0x94, 0x2c, 0x3e, 0xc9, 0x8e, 0x76, 0x92, 0x2e, 0x14, 0x05, 0x4d, 0xbc, 0x0c,
+	0xda, 0x75, 0xb1, 0x89, 0xdc, 0x38, 0xfd, 0x03, 0x34, 0xd8, 0x4d, 0x13, 0x5b, 0x6e, 0xbc, 0x76,
+	0x6c, 0x63, 0xe4, 0x24, 0xbb, 0xbd, 0x10, 0x34, 0x39, 0xa2, 0x09, 0x4b, 0x43, 0x75, 0x48, 0x25,
+	0x31, 0xf6, 0x23, 0x14, 0x2d, 0xd0, 0x63, 0x4f, 0xed, 0xad, 0x28, 0xd0, 0x5e, 0x7a, 0xe8, 0x27,
+	0x68, 0xd1, 0xde, 0x7b, 0x6c, 0x3f, 0xc7, 0x9e, 0x8b, 0x79, 0x33, 0xa4, 0xa8, 0x88, 0x92, 0xb5,
+	0xde, 0x3d, 0x69, 0xde, 0x9b, 0x37, 0x8f, 0xef, 0xfd, 0xde, 0xbc, 0x3f, 0x23, 0xb8, 0x79, 0x7e,
+	0x91, 0x9c, 0xb1, 0xcd, 0x91, 0x88, 0x92, 0x68, 0x33, 0x66, 0xe2, 0x75, 0xc8, 0x83, 0x0e, 0x52,
+	0xe4, 0x3a, 0x6e, 0x29, 0xa2, 0xa3, 0xb7, 0xda, 0xad, 0xbc, 0xbc, 0x17, 0x0d, 0x87, 0x11, 0x57,
+	0x12, 0xf6, 0xdf, 0x0d, 0x30, 0x0f, 0x23, 0x9f, 0x91, 0x0f, 0xa0, 0x9a, 0x84, 0xde, 0x39, 0x4b,
+	0x5a, 0xc6, 0xba, 0xb1, 0x61, 0x51, 0x4d, 0x91, 0x7b, 0x60, 0xf6, 0x5d, 0x2f, 0x69, 0x95, 0xd6,
+	0xcb, 0x1b, 0xf5, 0xad, 0x56, 0x27, 0xaf, 0x5e, 0x6b, 0xda, 0x75, 0xbd, 0x84, 0xa2, 0x14, 0x39,
+	0x81, 0xeb, 0x3e, 0xeb, 0x87, 0x3c, 0x4c, 0xc2, 0x88, 0x3b, 0x83, 0xc8, 0x73, 0xe5, 0xa2, 0x55,
+	0x5e, 0x37, 0x36, 0xea, 0x5b, 0x77, 0x3b, 0x05, 0xb6, 0x75, 0xba, 0x6f, 0x47, 0x2e, 0xf7, 0x99,
+	0xff, 0x84, 0x7b, 0x67, 0x91, 0xa0, 0x64, 0x72, 0xfe, 0x40, 0x1f, 0x27, 0x04, 0x4c, 0xe1, 0xf2,
+	0xf3, 0x96, 0xb9, 0x6e, 0x6c, 0x18, 0x14, 0xd7, 0xf6, 0xbf, 0x0d, 0x30, 0xbb, 0x7e, 0xc0, 0xc8,
+	0x03, 0xa8, 0xc6, 0xd1, 0x58, 0x78, 0x0c, 0x0d, 0xaf, 0x6f, 0xdd, 0x2c, 0xfc, 0x8a, 0xf4, 0x91,
+	0x6a, 0x41, 0xa9, 0xef, 0x3c, 0xe4, 0x7e, 0xab, 0x84, 0x9e, 0xe2, 0x9a, 0xb4, 0x60, 0x25, 0x12,
+	0x7e, 0xc8, 0xdd, 0x41, 0xab, 0xb2, 0x6e, 0x6c, 0x54, 0x68, 0x4a, 0xca, 0x0f, 0x24, 0xae, 0x08,
+	0x58, 0xd2, 0x2a, 0x5f, 0xfa, 0x01, 0x25, 0x98, 0x81, 0x66, 0x2e, 0x03, 0x9a, 0xfd, 0x57, 0x03,
+	0x2c, 0xe9, 0xca, 0x2f, 0x44, 0x34, 0x1e, 0x65, 0xc6, 0x19, 0x39, 0xe3, 0x7e, 0x0a, 0x26, 0xf3,
+	0x03, 0xa6, 0x83, 0x30, 0x07, 0xc7, 0x54, 0x03, 0xae, 0x28, 0x1e, 0x68, 0xf7, 0x26, 0x20, 0x69,
+	0x1f, 0x8c, 0x65, 0x7d, 0xc8, 0x01, 0x52, 0x9a, 0x02, 0xc4, 0xfe, 0xaf, 0x01, 0x8d, 0x63, 0x37,
+	0x60, 0xbe, 0x54, 0xdd, 0x63, 0xc9, 0x55, 0x42, 0xf0, 0x23, 0xa8, 0x04, 0xd2, 0x58, 0xed, 0xd2,
+	0xed, 0xc5, 0x2e, 0x51, 0x25, 0x4c, 0xee, 0x42, 0x3d, 0x89, 0x12, 0x77, 0xe0, 0x48, 0xe7, 0x62,
+	0x8c, 0x47, 0xe5, 0x69, 0xa9, 0x65, 0x50, 0x40, 0xb6, 0x94, 0x8f, 0xc9, 0x27, 0x00, 0x23, 0x37,
+	0x60, 0x4e, 0xc8, 0x7d, 0xf6, 0xb6, 0x65, 0x2e, 0xd0, 0x2f, 0x9d, 0xd8, 0x93, 0x52, 0xd4, 0x1a,
+	0xa5, 0x4b, 0xfb, 0x14, 0xac, 0x8c, 0x4f, 0x6e, 0x81, 0x25, 0x3f, 0xe5, 0xe4, 0x22, 0x52, 0x93,
+	0x8c, 0x7d, 0x19, 0x95, 0xef, 0x02, 0xe0, 0xa6, 0x17, 0x8d, 0x79, 0xa2, 0x41, 0x42, 0xf1, 0x6d,
+	0xc9, 0x20, 0x37, 0xa1, 0x86, 0x76, 0x9c, 0xb3, 0x0b, 0xb4, 0xd4, 0xa2, 0x2b, 0x92, 0xde, 0x67,
+	0x17, 0xf6, 0xaf, 0x0d, 0xa8, 0x49, 0x63, 0xe5, 0x87, 0xa6, 0xe4, 0x8c, 0x29, 0x39, 0x72, 0x17,
+	0x56, 0x15, 0x5e, 0x8e, 0xce, 0x4d, 0x75, 0x63, 0x1b, 0x8a, 0x79, 0x82, 0x3c, 0xf2, 0x18, 0xea,
+	0x08, 0x87, 0xa3, 0x00, 0x55, 0x97, 0xf4, 0x32, 0x40, 0xd1, 0xf2, 0x18, 0xd7, 0xf6, 0xbf, 0x4a,
+	0xb0, 0xba, 0x1b, 0x0e, 0xd8, 0x4e, 0x28, 0x98, 0x97, 0x44, 0xe2, 0x82, 0x7c, 0x0a, 0x15, 0xc6,
+	0x13, 0x21, 0xed, 0x96, 0xe8, 0x6d, 0x14, 0x2a, 0x9b, 0x3a, 0xd2, 0xe9, 0x4a, 0x79, 0xaa, 0x8e,
+	0x91, 0xef, 0x43, 0x23, 0x1e, 0x9f, 0xfa, 0xe9, 0x66, 0xcb, 0x58, 0x2f, 0x6f, 0x58, 0x18, 0xa8,
+	0x29, 0xbe, 0x8c, 0x67, 0x3f, 0x1c, 0xe4, 0xbc, 0x4b, 0xc5, 0x40, 0xb2, 0x95, 0x7f, 0xed, 0x2f,
+	0xa1, 0x82, 0xca, 0xc9, 0xa3, 0x5c, 0x66, 0xac, 0x6d, 0x7d, 0xb4, 0x84, 0x51, 0x32, 0x4c, 0x3a,
+	0x85, 0x08, 0x98, 0xdc, 0x1d, 0xb2, 0x34, 0xe7, 0xe5, 0x9a, 0x7c, 0x08, 0x8d, 0xd3, 0x71, 0x38,
+	0xf0, 0x1d, 0x2f, 0xe2, 0xfd, 0x30, 0x40, 0x6f, 0x2d, 0x5a, 0x47, 0xde, 0x36, 0xb2, 0xec, 0x7b,
+	0x60, 0x62, 0xac, 0xeb, 0xb0, 0xf2, 0xe2, 0x70, 0xff, 0xf0, 0xe8, 0xd5, 0x61, 0xf3, 0x3d, 0x52,
+	0x03, 0x73, 0x77, 0xef, 0xa0, 0xdb, 0x34, 0xc8, 0x2a, 0x58, 0x3b, 0x7b, 0xb4, 0xbb, 0x7d, 0x72,
+	0x44, 0xbf, 0x68, 0x96, 0xec, 0x3f, 0x19, 0x50, 0xdf, 0x8e, 0xc4, 0x68, 0x1c, 0xd3, 0x28, 0x4a,
+	0x62, 0xf2, 0x18, 0xaa, 0x1e, 0x92, 0x88, 0x40, 0x7d, 0x8e, 0xcd, 0xb9, 0x13, 0xe9, 0x5a, 0x1f,
+	0x6b, 0xbf, 0x82, 0xaa, 0xe2, 0xc8, 0xfa, 0x9c, 0xa9, 0xc2, 0xfa, 0xac, 0x28, 0xac, 0x8d, 0x51,
+	0xa4, 0xb1, 0xa3, 0xb8, 0x5e, 0xc6, 0xaf, 0x43, 0x30, 0x25, 0x54, 0x73, 0xcb, 0x3e, 0x01, 0x33,
+	0x61, 0x6f, 0xd5, 0x85, 0x6b, 0x50, 0x5c, 0x93, 0x36, 0xd4, 0x18, 0xf7, 0x22, 0x3f, 0xe4, 0x81,
+	0xbe, 0xd0, 0x19, 0x6d, 0xff, 0xcf, 0x00, 0x8b, 0xba, 0x6f, 0x54, 0x11, 0x9f, 0xab, 0xf5, 0x43,
+	0x68, 0xc4, 0x89, 0x2b, 0x12, 0x27, 0xea, 0xf7, 0x63, 0x96, 0xe6, 0x4c, 0x1d, 0x79, 0x47, 0xc8,
+	0xc2, 0xa4, 0xe2, 0x7e, 0x2a, 0x50, 0xd6, 0x49, 0xc5, 0x7d, 0xbd, 0x2d, 0x33, 0x82, 0x87, 0xa3,
+	0x11, 0x4b, 0x1c, 0x3c, 0x85, 0x3d, 0xa1, 0x42, 0x1b, 0x9a, 0xd9, 0x93, 0x3c, 0x72, 0x07, 0xea,
+	0xa9, 0x10, 0xe3, 0xbe, 0xae, 0xe7, 0xa0, 0x59, 0x5d, 0xee, 0x93, 0x4d, 0xb8, 0x9e, 0x07, 0x68,
+	0x2c, 0x54, 0x9b, 0xaa, 0xa2, 0xb1, 0x24, 0x87, 0x93, 0xde, 0xb1, 0xff, 0x58, 0x82, 0xb5, 0xe9,
+	0x46, 0xb5, 0x08, 0xb9, 0x99, 0xe6, 0x92, 0xa2, 0x69, 0x2a, 0x9e, 0x5c, 0xcb, 0x1e, 0x11, 0x8f,
+	0x5c, 0x8e, 0xd6, 0xcd, 0xe9, 0x11, 0xbd, 0x91, 0xcb, 0x29, 0x4a, 0xc9, 0x6a, 0xac, 0xed, 0xd7,
+	0x56, 0xa6, 0x24, 0x79, 0x04, 0x8d, 0x0c, 0x11, 0xa9, 0x6f, 0xe5, 0x12, 0x7d, 0x29, 0x34, 0x92,
+	0x98, 0x07, 0x44, 0x6d, 0x1e, 0x10, 0x59, 0x2b, 0xb6, 0x26, 0xad, 0xf8, 0x33, 0xb3, 0x56, 0x6e,
+	0x9a, 0xf6, 0x5f, 0x56, 0xe0, 0x1a, 0x66, 0x1f, 0xf3, 0x22, 0x25, 0x1c, 0x93, 0xfb, 0x60, 0xca,
+	0x44, 0x5e, 0xd8, 0x16, 0xe4, 0x19, 0x8a, 0x62, 0xe4, 0x08, 0xc0, 0xcf, 0x4e, 0xeb, 0xce, 0xb0,
+	0x39, 0x3f, 0xcd, 0x27, 0x1f, 0xea, 0x4c, 0xd6, 0x34, 0xa7, 0x22, 0xd7, 0xf6, 0x54, 0x1b, 0x58,
+	0xa2, 0xed, 0x51, 0x20, 0x6a, 0xe5, 0x4c, 0x06, 0x91, 0xb8, 0x55, 0x5e, 0xd4, 0x78, 0xa7, 0x07,
+	0x98, 0xf7, 0xd5, 0xf1, 0x9d, 0xc9, 0x69, 0xf2, 0x12, 0xae, 0x69, 0x9d, 0xd1, 0x6b, 0x26, 0x44,
+	0xe8, 0xb3, 0x56, 0x05, 0x15, 0xde, 0x5f, 0xca, 0xb9, 0x23, 0x7d, 0x88, 0xae, 0x29, 0x2d, 0x29,
+	0x4d, 0x3e, 0x05, 0xf0, 0x43, 0x37, 0xe0, 0x51, 0x9c, 0x84, 0x5e, 0xab, 0x5a, 0xd0, 0xe9, 0x74,
+	0xe0, 0x77, 0x32, 0x29, 0x9a, 0x3b, 0xd1, 0xfe, 0x87, 0x01, 0x30, 0xf9, 0x10, 0xf9, 0x09, 0x54,
+	0x5d, 0xf4, 0x41, 0xc7, 0xab, 0xb8, 0x87, 0x64, 0x59, 0x4e, 0xb5, 0x74, 0xe1, 0x8d, 0xff, 0x20,
+	0x43, 0xbe, 0xa2, 0xb3, 0x03, 0x29, 0xf2, 0x31, 0xbc, 0x3f, 0x03, 0xaf, 0x4e, 0x8b, 0xe6, 0xbb,
+	0xc0, 0x91, 0xef, 0xc1, 0x5a, 0xcc, 0x86, 0x2e, 0x4f, 0x42, 0xcf, 0x89, 0xbd, 0x68, 0xc4, 0xf4,
+	0xdd, 0x5f, 0x4d, 0xb9, 0x3d, 0xc9, 0x6c, 0xff, 0xad, 0x04, 0xb5, 0x0c, 0x93, 0xdb, 0x00, 0x1a,
+	0x64, 0x59, 0xa6, 0x54, 0x6a, 0xe6, 0x38, 0xb9, 0x7d, 0x9f, 0x71, 0x6d, 0x72, 0x8e, 0x43, 0x1e,
+	0xc2, 0x77, 0x26, 0x54, 0xde, 0x48, 0xe5, 0xc7, 0x8d, 0xc9, 0x66, 0xce, 0xd0, 0x5d, 0x8d, 0x40,
+	0x19, 0x3b, 0xd3, 0xd6, 0xd7, 0x8a, 0x6a, 0xbe, 0x49, 0x75, 0x61, 0x75, 0xe8, 0x8a, 0x73, 0xe6,
+	0x3b, 0x7a, 0x9e, 0x32, 0x31, 0x10, 0xeb, 0x45, 0x31, 0x7d, 0x8e, 0x82, 0x3d, 0x94, 0xa3, 0x8d,
+	0x61, 0x8e, 0xb2, 0x6d, 0xdd, 0xb4, 0x56, 0xc1, 0x3a, 0x7a, 0xd9, 0xa5, 0x74, 0x6f, 0xa7, 0xdb,
+	0x6b, 0xbe, 0x27, 0x7b, 0x58, 0xf7, 0xf3, 0x93, 0xee, 0xe1, 0x4e, 0xaf, 0x69, 0xd8, 0xff, 0x04,
+	0xb8, 0x81, 0x43, 0xdc, 0xb6, 0x88, 0xe2, 0x98, 0xb2, 0x3e, 0x13, 0x8c, 0x7b, 0x2c, 0x96, 0x05,
+	0x78, 0xc8, 0x44, 0xc0, 0x9c, 0x37, 0x61, 0x72, 0xd6, 0x5a, 0xc1, 0xd6, 0x61, 0x21, 0xe7, 0x55,
+	0x98, 0x9c, 0xcd, 0x8e, 0x24, 0x46, 0xc1, 0x48, 0xf2, 0x33, 0xa8, 0x6b, 0x21, 0x1e, 0xf9, 0x0c,
+	0xcb, 0xc9, 0xc2, 0xe4, 0x03, 0x25, 0x2d, 0xd7, 0xa4, 0x3b, 0x3d, 0x19, 0x6e, 0xce, 0x9d, 0xdc,
+	0xde, 0xb5, 0xbc, 0x33, 0x35, 0x2a, 0xd2, 0xa9, 0x29, 0x50, 0xe5, 0xef, 0xc3, 0xe5, 0x75, 0x15,
+	0x8d, 0x86, 0xe4, 0x3e, 0x34, 0xd5, 0xf8, 0x29, 0x32, 0x41, 0xd5, 0x7f, 0x70, 0x66, 0xb9, 0x86,
+	0x7b, 0x39, 0x24, 0x6f, 0x03, 0x84, 0xdc, 0x8b, 0x86, 0xa3, 0x01, 0x4b, 0x18, 0xde, 0x9f, 0x1a,
+	0xcd, 0x71, 0x66, 0xa3, 0x5d, 0xbd, 0x4a, 0xb4, 0xdb, 0x2f, 0xa1, 0x4e, 0xd9, 0xc0, 0x4d, 0x98,
+	0x8f, 0xf8, 0xdd, 0x07, 0x13, 0x41, 0xbf, 0x74, 0x14, 0x47, 0xb1, 0xf9, 0x63, 0x7e, 0xfb, 0x2b,
+	0x03, 0xaa, 0xdb, 0xee, 0x60, 0xc0, 0x04, 0x79, 0x04, 0x55, 0x0f, 0x57, 0x5a, 0xeb, 0x52, 0x85,
+	0x50, 0x1f, 0x21, 0x1f, 0xc1, 0xb5, 0x2c, 0x8b, 0xb5, 0x16, 0x95, 0x76, 0x59, 0x72, 0xeb, 0xaf,
+	0xcc, 0xe0, 0x51, 0xbe, 0x0a, 0x1e, 0xe4, 0x31, 0xd4, 0xe4, 0x67, 0xe2, 0x30, 0x61, 0xba, 0xec,
+	0x2f, 0x65, 0x6e, 0x76, 0xa8, 0xfd, 0x87, 0x12, 0x54, 0xe6, 0xbf, 0xc5, 0xde, 0x1d, 0xae, 0x54,
+	0x5d, 0xc8, 0x0f, 0x57, 0x12, 0x2e, 0x5d, 0x48, 0x4b, 0xcb, 0x7f, 0x5f, 0x1f, 0x21, 0x9f, 0x43,
+	0x43, 0xa8, 0x70, 0xaa, 0xe4, 0x51, 0x57, 0xf7, 0xc7, 0xcb, 0x5f, 0xdd, 0xdc, 0x65, 0xa0, 0x75,
+	0x31, 0x21, 0xc8, 0xb3, 0x2c, 0x8a, 0x0a, 0x96, 0x1f, 0x2e, 0xaf, 0x53, 0x45, 0x28, 0x0d, 0x69,
+	0xfb, 0x37, 0x06, 0x98, 0xdf, 0xca, 0xdb, 0x25, 0x4b, 0x76, 0x15, 0xea, 0x2b, 0x26, 0x7b, 0x3b,
+	0xce, 0xbf, 0xd9, 0x8a, 0x82, 0x76, 0x03, 0x2a, 0xf9, 0x57, 0x9a, 0x22, 0x16, 0xbc, 0xd0, 0x66,
+	0xa2, 0x6c, 0xce, 0x44, 0xf9, 0x33, 0xb3, 0x06, 0xcd, 0xba, 0xfd, 0xe7, 0x12, 0xd4, 0x76, 0x22,
+	0x6f, 0x3c, 0x64, 0x3c, 0x99, 0x3b, 0x13, 0xce, 0xdc, 0xec, 0xd2, 0x95, 0x6e, 0xf6, 0x4d, 0xa8,
+	0x09, 0xf7, 0x8d, 0x83, 0xa3, 0xa4, 0xb6, 0x57, 0xb8, 0x6f, 0x4e, 0xf4, 0x34, 0x39, 0x08, 0xf9,
+	0xb9, 0x8e, 0x6c, 0xe1, 0xf4, 0x77, 0x10, 0xf2, 0x73, 0x8a, 0x52, 0xd2, 0x3b, 0xef, 0x4c, 0x7a,
+	0xa7, 0xad, 0xad, 0xa8, 0x07, 0x02, 0xf2, 0x74, 0x64, 0xd2, 0x32, 0x52, 0xbd, 0x6c, 0x70, 0x42,
+	0x31, 0x19, 0x6d, 0x5f, 0xa3, 0xc0, 0x7c, 0xe7, 0xf4, 0x02, 0xc7, 0x50, 0x8b, 0x36, 0x26, 0xcc,
+	0xa7, 0x17, 0xf6, 0xef, 0x4a, 0x70, 0x6d, 0xcf, 0x67, 0x3c, 0x09, 0xfb, 0x21, 0x13, 0xcf, 0xdd,
+	0xc4, 0x3b, 0x93, 0x3d, 0xfe, 0x57, 0x63, 0x77, 0x20, 0x39, 0xbe, 0x83, 0x2f, 0x34, 0x05, 0xdd,
+	0x6a, 0xc6, 0x3d, 0x94, 0x4f, 0xb5, 0x5b, 0x60, 0x9d, 0xba, 0x31, 0x73, 0x72, 0x6f, 0xb8, 0x9a,
+	0x64, 0xe0, 0xe6, 0x27, 0x60, 0xe6, 0x52, 0xe5, 0x07, 0x85, 0xb6, 0xbe, 0xf3, 0xdd, 0x9c, 0xed,
+	0xed, 0x2f, 0x2f, 0xf9, 0x0b, 0xec, 0x16, 0x58, 0x52, 0xce, 0xc9, 0x0d, 0x39, 0x35, 0xc9, 0xd8,
+	0xd7, 0xe5, 0x00, 0x37, 0xe3, 0xf1, 0x69, 0x36, 0x02, 0x58, 0xb4, 0x2e, 0x79, 0x3d, 0xc5, 0x92,
+	0xe7, 0x05, 0xeb, 0xeb, 0xbf, 0x09, 0xd4, 0x7b, 0xa5, 0x26, 0x58, 0x1f, 0xff, 0x25, 0xb0, 0x9f,
+	0x03, 0x99, 0x98, 0x76, 0x2c, 0xe7, 0x32, 0x1e, 0xc4, 0xa4, 0x09, 0xe5, 0x49, 0x4a, 0xc9, 0x65,
+	0x01, 0x4e, 0xea, 0xc5, 0x37, 0x8d, 0x93, 0xfd, 0x5b, 0xf9, 0x0e, 0x93, 0x39, 0x1f, 0xbe, 0x66,
+	0xb1, 0x2c, 0xee, 0xca, 0x07, 0xf5, 0x00, 0xb5, 0x68, 0x4a, 0xca, 0x7f, 0x94, 0x92, 0x8b, 0x91,
+	0x82, 0x72, 0x6d, 0x4e, 0x81, 0xca, 0xf4, 0x74, 0x4e, 0x2e, 0x46, 0x8c, 0xe2, 0x01, 0xbb, 0x03,
+	0xa6, 0xa4, 0xa6, 0x1f, 0xc4, 0x75, 0x58, 0x39, 0x7e, 0x42, 0xbb, 0x87, 0x27, 0xbd, 0xa6, 0x41,
+	0x1a, 0x50, 0xdb, 0x7e, 0xb6, 0x77, 0xb0, 0x43, 0xbb, 0x87, 0xcd, 0x92, 0xfc, 0xab, 0xc3, 0x92,
+	0xd5, 0x23, 0x10, 0xee, 0xe8, 0xec, 0x1b, 0x1a, 0x94, 0xe9, 0xc9, 0x1b, 0xf4, 0x71, 0x91, 0x41,
+	0x00, 0xd5, 0xed, 0x27, 0x07, 0x07, 0x5d, 0xda, 0x34, 0xb2, 0x75, 0xb7, 0x59, 0xb2, 0x7f, 0x6f,
+	0xc0, 0xaa, 0x94, 0x7e, 0x16, 0x32, 0xe1, 0x0a, 0xef, 0xec, 0x62, 0x81, 0x45, 0x8f, 0xa6, 0x2c,
+	0x2a, 0x7e, 0xba, 0x4f, 0xe9, 0xca, 0x5b, 0xf5, 0xa0, 0xc8, 0xaa, 0x35, 0x80, 0xde, 0x8b, 0xe3,
+	0x2e, 0x3d, 0xf9, 0xe2, 0xb8, 0xab, 0x91, 0xea, 0xbd, 0x78, 0xaa, 0xa8, 0x92, 0xfd, 0x55, 0x19,
+	0xc8, 0xee, 0x98, 0x7b, 0x72, 0x3c, 0x3c, 0x76, 0x85, 0x3b, 0x64, 0x09, 0x13, 0xf1, 0xd7, 0x7a,
+	0x67, 0xca, 0x57, 0xe2, 0xd4, 0x3d, 0x4c, 0xc9, 0x6f, 0x69, 0xb2, 0x24, 0x47, 0x60, 0x8d, 0x52,
+	0xd3, 0xf4, 0x1b, 0xe6, 0x41, 0xf1, 0xb4, 0x3b, 0xe3, 0x48, 0x27, 0x5b, 0xd2, 0x89, 0x0e, 0x72,
+	0x0f, 0x88, 0x60, 0xc9, 0x58, 0x70, 0x47, 0xc2, 0x96, 0xd6, 0x23, 0x35, 0xe6, 0x37, 0xd5, 0x8e,
+	0xc4, 0x51, 0xff, 0x15, 0xf4, 0x1f, 0x43, 0x16, 0xfa, 0xf4, 0xec, 0x3c, 0x64, 0xe6, 0x8e, 0x34,
+	0x19, 0x66, 0xe5, 0x62, 0xcc, 0xcc, 0x4b, 0x30, 0xab, 0x5c, 0x09, 0xb3, 0x3b, 0x50, 0x9f, 0xf5,
+	0x0d, 0x92, 0xcc, 0x2b, 0x7b, 0x1f, 0xd6, 0x7a, 0x4c, 0xde, 0xa0, 0x2c, 0xfd, 0xe5, 0xa5, 0x14,
+	0x61, 0x20, 0xdc, 0x21, 0xba, 0xd6, 0xa0, 0x29, 0x29, 0x95, 0xcd, 0xfc, 0x63, 0x96, 0xff, 0xb7,
+	0xcc, 0xfe, 0x39, 0x80, 0x52, 0xb6, 0xf0, 0xef, 0x9d, 0x36, 0xd4, 0x06, 0x2e, 0x0f, 0xc6, 0x6e,
+	0x90, 0xd6, 0x91, 0x8c, 0x7e, 0xfa, 0x10, 0xee, 0x78, 0xd1, 0xb0, 0x13, 0x44, 0x51, 0x30, 0x60,
+	0x1d, 0x9f, 0xbd, 0x4e, 0xa2, 0x68, 0x10, 0xe7, 0x9d, 0xfe, 0x65, 0x53, 0x87, 0xd9, 0x09, 0x22,
+	0x07, 0x39, 0xa7, 0x55, 0xfc, 0x79, 0xf8, 0xff, 0x01, 0x00, 0x2b, 0x2c, 0x6b, 0x8d, 0x9a, 0x18,
 	0x00, 0x00,
 }